@@ -8,6 +8,11 @@ import (
 	"github.com/ignite/cli/ignite/pkg/cosmosaccount"
 )
 
+const (
+	flagMultisig  = "multisig"
+	flagThreshold = "threshold"
+)
+
 func NewAccountCreate() *cobra.Command {
 	c := &cobra.Command{
 		Use:   "create [name]",
@@ -16,11 +21,18 @@ func NewAccountCreate() *cobra.Command {
 		RunE:  accountCreateHandler,
 	}
 
+	c.Flags().StringSlice(flagMultisig, nil, "create a multisig account with the public keys of the accounts (names or addresses)")
+	c.Flags().Int(flagThreshold, 1, "number of signatures required to sign the transactions of a multisig account")
+
 	return c
 }
 
 func accountCreateHandler(cmd *cobra.Command, args []string) error {
-	name := args[0]
+	var (
+		name         = args[0]
+		multisig, _  = cmd.Flags().GetStringSlice(flagMultisig)
+		threshold, _ = cmd.Flags().GetInt(flagThreshold)
+	)
 
 	ca, err := cosmosaccount.New(
 		cosmosaccount.WithKeyringBackend(getKeyringBackend(cmd)),
@@ -30,6 +42,15 @@ func accountCreateHandler(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("unable to create registry: %w", err)
 	}
 
+	if len(multisig) > 0 {
+		if _, err := ca.CreateMultisig(name, multisig, threshold); err != nil {
+			return fmt.Errorf("unable to create multisig account: %w", err)
+		}
+
+		fmt.Printf("Multisig account %q created with a threshold of %d of %d keys\n", name, threshold, len(multisig))
+		return nil
+	}
+
 	_, mnemonic, err := ca.Create(name)
 	if err != nil {
 		return fmt.Errorf("unable to create account: %w", err)
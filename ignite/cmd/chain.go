@@ -75,6 +75,12 @@ to send token from any other account that exists on chain.
 
 The "simulate" command helps you start a simulation testing process for your
 chain.
+
+The "index" command collects the transactions and events of your running chain
+into a database, so they can be queried.
+
+The "snapshot" command saves named copies of your chain state that can be
+restored later, for example to go back to a state that takes time to set up.
 `,
 		Aliases:           []string{"c"},
 		Args:              cobra.ExactArgs(1),
@@ -92,6 +98,9 @@ chain.
 		NewChainFaucet(),
 		NewChainSimulate(),
 		NewChainDebug(),
+		NewChainIndex(),
+		NewChainSnapshot(),
+		NewChainInspect(),
 	)
 
 	return c
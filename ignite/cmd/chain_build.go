@@ -1,6 +1,7 @@
 package ignitecmd
 
 import (
+	"fmt"
 	"path/filepath"
 
 	"github.com/spf13/cobra"
@@ -21,6 +22,10 @@ const (
 	flagBuildTags         = "build.tags"
 	flagReleasePrefix     = "release.prefix"
 	flagReleaseTargets    = "release.targets"
+	flagReleaseSignKey    = "release.sign-key"
+	flagCosmovisor        = "cosmovisor"
+	flagCosmovisorUpgrade = "cosmovisor.upgrade-name"
+	flagCosmovisorURL     = "cosmovisor.download-url"
 )
 
 // NewChainBuild returns a new build command to build a blockchain app.
@@ -79,6 +84,48 @@ tags. If the optional --release.targets is not specified, a binary is created
 for your current environment.
 
 	ignite chain build --release -t linux:amd64 -t darwin:amd64 -t darwin:arm64
+
+Release builds are reproducible: building the same source code for the same
+targets produces the same binaries and tarballs. File paths and build IDs are
+removed from the binaries and the files inside the tarballs use the time of the
+last commit as modification time. Set the SOURCE_DATE_EPOCH environment variable
+to use a different time.
+
+The release directory also contains a "sbom.cdx.json" file with the software
+bill of materials of the app dependencies in CycloneDX format, and a
+"release_checksum" file with the SHA256 checksums of the release files.
+
+To sign the checksum file, use an ed25519 private key in PEM format:
+
+	openssl genpkey -algorithm ed25519 -out release.key
+	ignite chain build --release --release.sign-key release.key
+
+The signature is saved in base64 in the "release_checksum.sig" file and can be
+verified with the public key of the signing key:
+
+	openssl pkey -in release.key -pubout -out release.pub
+	base64 -d release_checksum.sig > release_checksum.sig.bin
+	openssl pkeyutl -verify -pubin -inkey release.pub -rawin -in release_checksum -sigfile release_checksum.sig.bin
+
+To deploy the release with cosmovisor, use the --cosmovisor flag. The binary of
+each release target is saved in a cosmovisor directory layout inside the
+"release/cosmovisor" directory. Binaries are saved in "genesis/bin" by default,
+or in "upgrades/{name}/bin" when an upgrade name is specified:
+
+	ignite chain build --release -t linux:amd64 --cosmovisor --cosmovisor.upgrade-name v2
+
+The "release/cosmovisor/upgrade-info.json" file contains the download URL and
+the checksum of the tarball of each target, in the format expected by cosmovisor
+to download the binaries of an upgrade. Use --cosmovisor.download-url to set the
+URL where the release tarballs are published, otherwise the local release path
+is used:
+
+	ignite chain build --release -t linux:amd64 -t darwin:arm64 --cosmovisor \
+	  --cosmovisor.upgrade-name v2 \
+	  --cosmovisor.download-url https://github.com/org/mars/releases/download/v2.0.0
+
+The content of the file can be used as the info of the upgrade plan when
+submitting the software upgrade proposal.
 `,
 		Args: cobra.NoArgs,
 		RunE: chainBuildHandler,
@@ -93,6 +140,10 @@ for your current environment.
 	c.Flags().StringSliceP(flagReleaseTargets, "t", []string{}, "release targets. Available only with --release flag")
 	c.Flags().StringSlice(flagBuildTags, []string{cosmosver.DefaultVersion().String()}, "parameters to build the chain binary")
 	c.Flags().String(flagReleasePrefix, "", "tarball prefix for each release target. Available only with --release flag")
+	c.Flags().String(flagReleaseSignKey, "", "ed25519 private key in PEM format to sign the release checksum file. Available only with --release flag")
+	c.Flags().Bool(flagCosmovisor, false, "create a cosmovisor directory layout for the release. Available only with --release flag")
+	c.Flags().String(flagCosmovisorUpgrade, "", "upgrade name of the cosmovisor layout, the genesis layout is created when empty")
+	c.Flags().String(flagCosmovisorURL, "", "URL where the release tarballs are downloaded from, used in the cosmovisor upgrade info")
 	c.Flags().StringP(flagOutput, "o", "", "binary output path")
 	c.Flags().BoolP("verbose", "v", false, "verbose output")
 
@@ -104,6 +155,10 @@ func chainBuildHandler(cmd *cobra.Command, _ []string) error {
 		isRelease, _      = cmd.Flags().GetBool(flagRelease)
 		releaseTargets, _ = cmd.Flags().GetStringSlice(flagReleaseTargets)
 		releasePrefix, _  = cmd.Flags().GetString(flagReleasePrefix)
+		releaseSignKey, _ = cmd.Flags().GetString(flagReleaseSignKey)
+		cosmovisor, _     = cmd.Flags().GetBool(flagCosmovisor)
+		upgradeName, _    = cmd.Flags().GetString(flagCosmovisorUpgrade)
+		downloadURL, _    = cmd.Flags().GetString(flagCosmovisorURL)
 		buildTags, _      = cmd.Flags().GetStringSlice(flagBuildTags)
 		output, _         = cmd.Flags().GetString(flagOutput)
 		session           = cliui.New(
@@ -113,6 +168,10 @@ func chainBuildHandler(cmd *cobra.Command, _ []string) error {
 	)
 	defer session.End()
 
+	if cosmovisor && !isRelease {
+		return fmt.Errorf("--%s is available only with --%s flag", flagCosmovisor, flagRelease)
+	}
+
 	chainOption := []chain.Option{
 		chain.KeyringBackend(chaincmd.KeyringBackendTest),
 		chain.WithOutputer(session),
@@ -135,12 +194,33 @@ func chainBuildHandler(cmd *cobra.Command, _ []string) error {
 
 	ctx := cmd.Context()
 	if isRelease {
-		releasePath, err := c.BuildRelease(ctx, cacheStorage, buildTags, output, releasePrefix, releaseTargets...)
+		releasePath, err := c.BuildRelease(
+			ctx,
+			cacheStorage,
+			buildTags,
+			output,
+			releasePrefix,
+			releaseSignKey,
+			releaseTargets...,
+		)
+		if err != nil {
+			return err
+		}
+
+		if err := session.Printf("🗃  Release created: %s\n", colors.Info(releasePath)); err != nil {
+			return err
+		}
+
+		if !cosmovisor {
+			return nil
+		}
+
+		cosmovisorPath, err := c.CosmovisorRelease(releasePath, releasePrefix, upgradeName, downloadURL, releaseTargets...)
 		if err != nil {
 			return err
 		}
 
-		return session.Printf("🗃  Release created: %s\n", colors.Info(releasePath))
+		return session.Printf("🗃  Cosmovisor layout created: %s\n", colors.Info(cosmovisorPath))
 	}
 
 	binaryName, err := c.Build(ctx, cacheStorage, buildTags, output, flagGetSkipProto(cmd), flagGetDebug(cmd))
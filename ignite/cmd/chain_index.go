@@ -0,0 +1,191 @@
+package ignitecmd
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ignite/cli/ignite/pkg/cliui"
+	"github.com/ignite/cli/ignite/pkg/cosmosclient"
+	"github.com/ignite/cli/ignite/pkg/cosmostxcollector/adapter"
+	"github.com/ignite/cli/ignite/pkg/cosmostxcollector/adapter/postgres"
+	"github.com/ignite/cli/ignite/pkg/cosmostxcollector/adapter/sqlite"
+	"github.com/ignite/cli/ignite/pkg/xurl"
+	"github.com/ignite/cli/ignite/services/chain"
+)
+
+const (
+	flagIndexAdapter      = "adapter"
+	flagIndexDatabase     = "database"
+	flagIndexDBHost       = "db-host"
+	flagIndexDBPort       = "db-port"
+	flagIndexDBUser       = "db-user"
+	flagIndexDBPassword   = "db-password"
+	flagIndexDBParams     = "db-params"
+	flagIndexFromHeight   = "from-height"
+	flagIndexPollInterval = "poll-interval"
+
+	indexAdapterPostgres = "postgres"
+	indexAdapterSQLite   = "sqlite"
+)
+
+// NewChainIndex creates a new index command to collect the transactions of a running chain.
+func NewChainIndex() *cobra.Command {
+	c := &cobra.Command{
+		Use:   "index",
+		Short: "Collect the transactions and events of a running chain into a database",
+		Long: `The index command collects the transactions and events of a running blockchain
+and saves them into a database where they can be queried.
+
+Indexing resumes from the latest block saved in the database and then keeps
+following the new blocks produced by the chain until the command is stopped
+with Ctrl-C. Stopping the command is safe because the transactions of each
+block are saved all at once.
+
+By default the transactions are saved into a SQLite database stored next to
+the data that Ignite keeps for the chain and the chain's RPC address defined
+in config.yml is used to collect them:
+
+	ignite chain index
+
+A PostgreSQL database can also be used to save the transactions:
+
+	ignite chain index --adapter postgres --database cosmos --db-params sslmode=disable
+
+Use the "--node" flag to index a chain that runs in a different address:
+
+	ignite chain index --node https://rpc.example.com:443
+`,
+		Args: cobra.NoArgs,
+		RunE: chainIndexHandler,
+	}
+
+	flagSetPath(c)
+	c.Flags().AddFlagSet(flagSetHome())
+	c.Flags().String(flagNode, "", "<host>:<port> to tendermint rpc interface for this chain (default: RPC address from config.yml)")
+	c.Flags().String(flagIndexAdapter, indexAdapterSQLite, "database adapter (sqlite|postgres)")
+	c.Flags().String(flagIndexDatabase, "", "SQLite database file path or PostgreSQL database name")
+	c.Flags().String(flagIndexDBHost, postgres.DefaultHost, "PostgreSQL database host")
+	c.Flags().Uint(flagIndexDBPort, postgres.DefaultPort, "PostgreSQL database port")
+	c.Flags().String(flagIndexDBUser, "", "PostgreSQL database user")
+	c.Flags().String(flagIndexDBPassword, "", "PostgreSQL database password")
+	c.Flags().StringToString(flagIndexDBParams, nil, "PostgreSQL database connection parameters (e.g. sslmode=disable)")
+	c.Flags().Int64(flagIndexFromHeight, 0, "block height to start indexing from when the database is empty")
+	c.Flags().Duration(flagIndexPollInterval, chain.DefaultIndexPollInterval, "time to wait before checking for new blocks")
+
+	return c
+}
+
+func chainIndexHandler(cmd *cobra.Command, _ []string) error {
+	session := cliui.New(cliui.StartSpinner())
+	defer session.End()
+
+	chainOption := []chain.Option{
+		chain.WithOutputer(session),
+		chain.CollectEvents(session.EventBus()),
+	}
+
+	c, err := newChainWithHomeFlags(cmd, chainOption...)
+	if err != nil {
+		return err
+	}
+
+	db, err := newIndexAdapter(cmd, c)
+	if err != nil {
+		return err
+	}
+
+	defer db.Close()
+
+	client, err := newIndexClient(cmd, c)
+	if err != nil {
+		return err
+	}
+
+	fromHeight, _ := cmd.Flags().GetInt64(flagIndexFromHeight)
+	pollInterval, _ := cmd.Flags().GetDuration(flagIndexPollInterval)
+
+	return c.Index(
+		cmd.Context(),
+		client,
+		db,
+		chain.IndexFromHeight(fromHeight),
+		chain.IndexPollInterval(pollInterval),
+	)
+}
+
+// indexAdapter is a data backend adapter that must be closed once indexing stops.
+type indexAdapter interface {
+	adapter.Adapter
+	io.Closer
+}
+
+func newIndexAdapter(cmd *cobra.Command, c *chain.Chain) (indexAdapter, error) {
+	var (
+		adapterType, _ = cmd.Flags().GetString(flagIndexAdapter)
+		database, _    = cmd.Flags().GetString(flagIndexDatabase)
+	)
+
+	switch adapterType {
+	case indexAdapterSQLite:
+		if database == "" {
+			path, err := c.IndexDatabasePath()
+			if err != nil {
+				return nil, err
+			}
+
+			database = path
+		}
+
+		return sqlite.NewAdapter(database)
+	case indexAdapterPostgres:
+		if database == "" {
+			return nil, fmt.Errorf("a database name is required to use the %s adapter", indexAdapterPostgres)
+		}
+
+		var (
+			host, _     = cmd.Flags().GetString(flagIndexDBHost)
+			port, _     = cmd.Flags().GetUint(flagIndexDBPort)
+			user, _     = cmd.Flags().GetString(flagIndexDBUser)
+			password, _ = cmd.Flags().GetString(flagIndexDBPassword)
+			params, _   = cmd.Flags().GetStringToString(flagIndexDBParams)
+		)
+
+		return postgres.NewAdapter(
+			database,
+			postgres.WithHost(host),
+			postgres.WithPort(port),
+			postgres.WithUser(user),
+			postgres.WithPassword(password),
+			postgres.WithParams(params),
+		)
+	default:
+		return nil, fmt.Errorf("unknown database adapter %q", adapterType)
+	}
+}
+
+func newIndexClient(cmd *cobra.Command, c *chain.Chain) (cosmosclient.Client, error) {
+	node := getNode(cmd)
+	if node == "" {
+		address, err := c.RPCPublicAddress()
+		if err != nil {
+			return cosmosclient.Client{}, err
+		}
+
+		if node, err = xurl.HTTP(address); err != nil {
+			return cosmosclient.Client{}, fmt.Errorf("invalid rpc address format: %w", err)
+		}
+	}
+
+	home, err := c.Home()
+	if err != nil {
+		return cosmosclient.Client{}, err
+	}
+
+	return cosmosclient.New(
+		cmd.Context(),
+		cosmosclient.WithHome(home),
+		cosmosclient.WithNodeAddress(xurl.HTTPEnsurePort(node)),
+	)
+}
@@ -10,6 +10,8 @@ import (
 	"github.com/ignite/cli/ignite/services/chain"
 )
 
+const flagFromGenesis = "from-genesis"
+
 func NewChainInit() *cobra.Command {
 	c := &cobra.Command{
 		Use:   "init",
@@ -59,6 +61,16 @@ Refer to config.yml guide to see which values you can set.
 One of these accounts is a validator account and the amount of self-delegated
 tokens can be set in the top-level "validator" property.
 
+When more than one validator is defined, a data directory is initialized for
+each one of them. The first validator uses the default data directory while the
+rest use their "home" or a directory named after them, for example
+$HOME/.mychain-bob. Each validator must have an account with the same name
+so its key can be added to the keyring of its own node. The genesis transactions
+of all validators are collected into a single genesis which is shared by all the
+nodes, and the nodes are configured to connect to each other as persistent
+peers. Ports of the additional validators are automatically incremented to
+avoid clashing with the default addresses.
+
 One of the most important components of an initialized chain is the genesis
 file, the 0th block of the chain. The genesis file is stored in the data
 directory "config" subdirectory and contains the initial state of the chain,
@@ -74,6 +86,19 @@ genesis in config.yml:
 The example above changes the staking token to "foo". If you change the staking
 denom, make sure the validator account has the right tokens.
 
+To reproduce the state of a live network locally, initialize the chain from a
+genesis exported from one of its nodes, for example with "appd export":
+
+	ignite chain init --from-genesis mainnet-export.json
+
+The exported state is kept except for the validator set, which is replaced by
+the validators defined in config.yml. The accounts in config.yml are added to
+the state and the governance voting and unbonding periods are shortened to allow
+testing proposals and unbondings locally. The staking token of the validators
+must match the one of the exported genesis. The forked genesis is validated
+with "appd validate-genesis" and is imported again by "ignite chain serve"
+unless the state is reset.
+
 The init command is meant to be used ONLY FOR DEVELOPMENT PURPOSES. Under the
 hood it runs commands like "appd init", "appd add-genesis-account", "appd
 gentx", and "appd collect-gentx". For production, you may want to run these
@@ -89,6 +114,7 @@ commands manually to ensure a production-level node initialization.
 	c.Flags().AddFlagSet(flagSetCheckDependencies())
 	c.Flags().AddFlagSet(flagSetSkipProto())
 	c.Flags().AddFlagSet(flagSetDebug())
+	c.Flags().String(flagFromGenesis, "", "path of an exported genesis to fork the chain state from")
 	c.Flags().StringSlice(flagBuildTags, []string{cosmosver.DefaultVersion().String()}, "parameters to build the chain binary")
 
 	return c
@@ -129,7 +155,11 @@ func chainInitHandler(cmd *cobra.Command, _ []string) error {
 		return err
 	}
 
-	if err := c.Init(ctx, chain.InitArgsAll); err != nil {
+	if fromGenesis, _ := cmd.Flags().GetString(flagFromGenesis); fromGenesis != "" {
+		if err := c.InitFromGenesis(ctx, fromGenesis); err != nil {
+			return err
+		}
+	} else if err := c.Init(ctx, chain.InitArgsAll); err != nil {
 		return err
 	}
 
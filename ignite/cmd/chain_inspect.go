@@ -0,0 +1,321 @@
+package ignitecmd
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ignite/cli/ignite/pkg/cliui"
+	"github.com/ignite/cli/ignite/pkg/cliui/colors"
+	"github.com/ignite/cli/ignite/services/chain"
+)
+
+const (
+	flagHeight   = "height"
+	flagNoDecode = "no-decode"
+
+	hexPrefix = "0x"
+)
+
+// NewChainInspect returns a command that groups sub commands to inspect
+// the module stores of the chain state.
+func NewChainInspect() *cobra.Command {
+	c := &cobra.Command{
+		Use:   "inspect [command]",
+		Short: "Inspect the module stores of the local node",
+		Long: `Inspect the state of your blockchain by reading the module stores of the
+local node directly from its data directory, without having to write queries.
+
+The node must be stopped because its database is opened in read-only mode.
+
+List the module stores:
+
+	ignite chain inspect stores
+
+List the keys of a store with their values, optionally filtered by a key prefix.
+Prefixes starting with "0x" are hexadecimal, other prefixes are used as is:
+
+	ignite chain inspect keys bank 0x02
+	ignite chain inspect keys mars Planet/value/ --limit 10
+
+Show the keys of a store that changed between two heights:
+
+	ignite chain inspect diff mars 10 20
+
+The values are decoded with the proto messages defined by the app modules and
+by the Cosmos SDK modules. Values that can't be decoded are printed as hex.
+Use "--no-decode" to skip the discovery of the modules and print the raw values.
+
+The latest height is used by default, use "--height" to inspect an older height
+that wasn't pruned by the node.
+`,
+		Args: cobra.ExactArgs(1),
+	}
+
+	flagSetPath(c)
+	c.PersistentFlags().AddFlagSet(flagSetHome())
+	c.PersistentFlags().Bool(flagNoDecode, false, "don't decode the store values")
+
+	c.AddCommand(
+		NewChainInspectStores(),
+		NewChainInspectKeys(),
+		NewChainInspectDiff(),
+	)
+
+	return c
+}
+
+// NewChainInspectStores creates a command to list the module stores.
+func NewChainInspectStores() *cobra.Command {
+	c := &cobra.Command{
+		Use:   "stores",
+		Short: "List the module stores",
+		Args:  cobra.NoArgs,
+		RunE:  chainInspectStoresHandler,
+	}
+
+	c.Flags().Int64(flagHeight, 0, "height of the state (default latest)")
+
+	return c
+}
+
+// NewChainInspectKeys creates a command to list the keys of a module store.
+func NewChainInspectKeys() *cobra.Command {
+	c := &cobra.Command{
+		Use:   "keys [store] [prefix]",
+		Short: "List the keys of a module store with their values",
+		Args:  cobra.RangeArgs(1, 2),
+		RunE:  chainInspectKeysHandler,
+	}
+
+	c.Flags().Int64(flagHeight, 0, "height of the state (default latest)")
+	c.Flags().Int(flagLimit, 100, "maximum number of keys to list, zero lists all the keys")
+
+	return c
+}
+
+// NewChainInspectDiff creates a command to show the changes of a module store between two heights.
+func NewChainInspectDiff() *cobra.Command {
+	return &cobra.Command{
+		Use:   "diff [store] [from-height] [to-height] [prefix]",
+		Short: "Show the keys of a module store that changed between two heights",
+		Args:  cobra.RangeArgs(3, 4),
+		RunE:  chainInspectDiffHandler,
+	}
+}
+
+func chainInspectStoresHandler(cmd *cobra.Command, _ []string) error {
+	session := cliui.New()
+	defer session.End()
+
+	height, _ := cmd.Flags().GetInt64(flagHeight)
+
+	c, err := newChainWithHomeFlags(cmd)
+	if err != nil {
+		return err
+	}
+
+	cacheStorage, err := newCache(cmd)
+	if err != nil {
+		return err
+	}
+
+	// Stores are listed without decoding any value
+	inspector, err := c.InspectState(cmd.Context(), cacheStorage, false)
+	if err != nil {
+		return err
+	}
+	defer inspector.Close()
+
+	stores, err := inspector.Stores(height)
+	if err != nil {
+		return err
+	}
+
+	return session.Println(strings.Join(stores, "\n"))
+}
+
+func chainInspectKeysHandler(cmd *cobra.Command, args []string) error {
+	session := cliui.New(cliui.StartSpinner())
+	defer session.End()
+
+	var (
+		height, _ = cmd.Flags().GetInt64(flagHeight)
+		limit, _  = cmd.Flags().GetInt(flagLimit)
+		prefix    []byte
+	)
+
+	if len(args) > 1 {
+		var err error
+		if prefix, err = parseStoreKey(args[1]); err != nil {
+			return err
+		}
+	}
+
+	inspector, err := newStateInspector(cmd, session)
+	if err != nil {
+		return err
+	}
+	defer inspector.Close()
+
+	entries, err := inspector.Entries(args[0], height, prefix, limit)
+	if err != nil {
+		return err
+	}
+
+	session.StopSpinner()
+
+	if len(entries) == 0 {
+		return session.Println("No keys found.")
+	}
+
+	for _, e := range entries {
+		if err := session.Printf("%s\n%s\n", formatStoreKey(e.Key), formatStoreValue(e)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func chainInspectDiffHandler(cmd *cobra.Command, args []string) error {
+	session := cliui.New(cliui.StartSpinner())
+	defer session.End()
+
+	from, err := strconv.ParseInt(args[1], 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid from height: %s", args[1])
+	}
+
+	to, err := strconv.ParseInt(args[2], 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid to height: %s", args[2])
+	}
+
+	var prefix []byte
+	if len(args) > 3 {
+		if prefix, err = parseStoreKey(args[3]); err != nil {
+			return err
+		}
+	}
+
+	inspector, err := newStateInspector(cmd, session)
+	if err != nil {
+		return err
+	}
+	defer inspector.Close()
+
+	changes, err := inspector.Diff(args[0], from, to, prefix)
+	if err != nil {
+		return err
+	}
+
+	session.StopSpinner()
+
+	if len(changes) == 0 {
+		return session.Printf("No changes between heights %d and %d.\n", from, to)
+	}
+
+	for _, c := range changes {
+		var out string
+		switch {
+		case c.Old == nil:
+			out = fmt.Sprintf("%s %s\n%s\n", colors.Success("+"), formatStoreKey(c.Key), formatStoreValue(*c.New))
+		case c.New == nil:
+			out = fmt.Sprintf("%s %s\n%s\n", colors.Error("-"), formatStoreKey(c.Key), formatStoreValue(*c.Old))
+		default:
+			out = fmt.Sprintf(
+				"%s %s\n%s\n%s\n",
+				colors.Info("~"),
+				formatStoreKey(c.Key),
+				formatStoreValue(*c.Old),
+				formatStoreValue(*c.New),
+			)
+		}
+
+		if err := session.Print(out); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func newStateInspector(cmd *cobra.Command, session *cliui.Session) (*chain.StateInspector, error) {
+	noDecode, _ := cmd.Flags().GetBool(flagNoDecode)
+
+	c, err := newChainWithHomeFlags(cmd)
+	if err != nil {
+		return nil, err
+	}
+
+	cacheStorage, err := newCache(cmd)
+	if err != nil {
+		return nil, err
+	}
+
+	if !noDecode {
+		session.StartSpinner("Discovering the app modules...")
+	}
+
+	return c.InspectState(cmd.Context(), cacheStorage, !noDecode)
+}
+
+// parseStoreKey parses a store key that is hexadecimal when it starts with "0x".
+func parseStoreKey(s string) ([]byte, error) {
+	if !strings.HasPrefix(s, hexPrefix) {
+		return []byte(s), nil
+	}
+
+	key, err := hex.DecodeString(strings.TrimPrefix(s, hexPrefix))
+	if err != nil {
+		return nil, fmt.Errorf("invalid hexadecimal key %s: %w", s, err)
+	}
+
+	return key, nil
+}
+
+// formatStoreKey formats a store key as hexadecimal, followed by the key
+// as text when all its characters are printable.
+func formatStoreKey(key []byte) string {
+	s := colors.Info(hexPrefix + hex.EncodeToString(key))
+
+	printable := len(key) > 0
+	for _, r := range string(key) {
+		if r == unicode.ReplacementChar || !unicode.IsPrint(r) {
+			printable = false
+			break
+		}
+	}
+
+	if printable {
+		s += fmt.Sprintf(" %q", key)
+	}
+
+	return s
+}
+
+// formatStoreValue formats a store value indented, using the decoded value
+// when there is one or hexadecimal otherwise.
+func formatStoreValue(e chain.StateEntry) string {
+	if e.Decoded == nil {
+		s := "  " + hexPrefix + hex.EncodeToString(e.Value)
+		if len(e.Candidates) > 0 {
+			s += " " + colors.Faint(fmt.Sprintf("(one of %s)", strings.Join(e.Candidates, ", ")))
+		}
+
+		return s
+	}
+
+	bz, err := json.MarshalIndent(e.Decoded, "  ", "  ")
+	if err != nil {
+		return "  " + hexPrefix + hex.EncodeToString(e.Value)
+	}
+
+	return fmt.Sprintf("  %s %s", colors.Faint(e.Type), bz)
+}
@@ -3,6 +3,7 @@ package ignitecmd
 import (
 	"context"
 	"errors"
+	"fmt"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/spf13/cobra"
@@ -22,6 +23,10 @@ const (
 	flagGenerateClients = "generate-clients"
 	flagQuitOnFail      = "quit-on-fail"
 	flagResetOnce       = "reset-once"
+	flagTest            = "test"
+	flagTestSimBlocks   = "test.sim-blocks"
+	flagUpgradeFrom     = "upgrade-from"
+	flagUpgradeName     = "upgrade-name"
 )
 
 // NewChainServe creates a new serve command to serve a blockchain.
@@ -30,8 +35,8 @@ func NewChainServe() *cobra.Command {
 		Use:   "serve",
 		Short: "Start a blockchain node in development",
 		Long: `The serve command compiles and installs the binary (like "ignite chain build"),
-uses that binary to initialize the blockchain's data directory for one validator
-(like "ignite chain init"), and starts the node locally for development purposes
+uses that binary to initialize the blockchain's data directory for each validator
+(like "ignite chain init"), and starts the nodes locally for development purposes
 with automatic code reloading.
 
 Automatic code reloading means Ignite starts watching the project directory.
@@ -58,6 +63,40 @@ from one blockchain to another. To start a node using a specific config file:
 
 	ignite chain serve --config mars.yml
 
+When more than one validator is defined in config.yml, a node is started for each
+one of them. All nodes share the same genesis and are connected to each other as
+persistent peers, which is handy to test consensus or slashing related logic:
+
+	validators:
+	  - name: alice
+	    bonded: '100000000stake'
+	  - name: bob
+	    bonded: '100000000stake'
+
+To rehearse an in-place software upgrade of your chain, start it with the binary
+built from an older git reference and let Ignite upgrade it to the binary built
+from the working tree:
+
+	ignite chain serve --upgrade-from v1.0.0 --upgrade-name v2
+
+Ignite shortens the governance voting period, submits a software upgrade proposal
+with the name of the upgrade handler registered in your app, votes yes with every
+validator and swaps the binary when the chain halts at the upgrade height. The
+command fails with the panic message when the chain doesn't resume producing
+blocks, for example because a store migration fails. Source code changes are not
+watched while rehearsing an upgrade.
+
+To run the tests while you develop, use the "--test" flag. After each rebuild,
+Ignite runs "go test" for the packages changed since the last run and for the
+packages that import them, and displays the results below the chain info:
+
+	ignite chain serve --test
+
+Use "--test.sim-blocks" to also run the simulation tests of the app with a short
+number of blocks after the tests:
+
+	ignite chain serve --test --test.sim-blocks 10
+
 The serve command is meant to be used ONLY FOR DEVELOPMENT PURPOSES. Under the
 hood, it runs "appd start", where "appd" is the name of your chain's binary. For
 production, you may want to run "appd start" manually.
@@ -76,6 +115,10 @@ production, you may want to run "appd start" manually.
 	c.Flags().BoolP(flagResetOnce, "r", false, "reset the app state once on init")
 	c.Flags().Bool(flagGenerateClients, false, "generate code for the configured clients on reset or source code change")
 	c.Flags().Bool(flagQuitOnFail, false, "quit program if the app fails to start")
+	c.Flags().String(flagUpgradeFrom, "", "git reference of the app version to upgrade from")
+	c.Flags().String(flagUpgradeName, "", "name of the upgrade handler to run when upgrading from --upgrade-from")
+	c.Flags().Bool(flagTest, false, "run the tests of the packages affected by the changes after each rebuild")
+	c.Flags().Int(flagTestSimBlocks, 0, "run the simulation tests with the number of blocks after the tests")
 	c.Flags().StringSlice(flagBuildTags, []string{cosmosver.DefaultVersion().String()}, "parameters to build the chain binary")
 
 	return c
@@ -124,6 +167,18 @@ func chainServeCmd(cmd *cobra.Command, session *cliui.Session) tea.Cmd {
 }
 
 func chainServe(cmd *cobra.Command, session *cliui.Session) error {
+	upgradeFrom, _ := cmd.Flags().GetString(flagUpgradeFrom)
+	upgradeName, _ := cmd.Flags().GetString(flagUpgradeName)
+	if upgradeFrom != "" && upgradeName == "" {
+		return fmt.Errorf("--%s is required with --%s", flagUpgradeName, flagUpgradeFrom)
+	}
+
+	test, _ := cmd.Flags().GetBool(flagTest)
+	simBlocks, _ := cmd.Flags().GetInt(flagTestSimBlocks)
+	if simBlocks > 0 && !test {
+		return fmt.Errorf("--%s requires --%s", flagTestSimBlocks, flagTest)
+	}
+
 	chainOption := []chain.Option{
 		chain.WithOutputer(session),
 		chain.CollectEvents(session.EventBus()),
@@ -209,5 +264,13 @@ func chainServe(cmd *cobra.Command, session *cliui.Session) error {
 		serveOptions = append(serveOptions, chain.QuitOnFail())
 	}
 
+	if upgradeFrom != "" {
+		serveOptions = append(serveOptions, chain.ServeUpgrade(upgradeFrom, upgradeName))
+	}
+
+	if test {
+		serveOptions = append(serveOptions, chain.ServeTest(simBlocks))
+	}
+
 	return c.Serve(cmd.Context(), cacheStorage, serveOptions...)
 }
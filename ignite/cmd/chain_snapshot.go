@@ -0,0 +1,165 @@
+package ignitecmd
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/ignite/cli/ignite/pkg/cliui"
+	"github.com/ignite/cli/ignite/services/chain"
+)
+
+// NewChainSnapshot returns a command that groups sub commands to manage
+// the named snapshots of the chain state.
+func NewChainSnapshot() *cobra.Command {
+	c := &cobra.Command{
+		Use:   "snapshot [command]",
+		Short: "Save, list, restore and delete snapshots of the chain state",
+		Long: `Snapshots keep a named copy of the state of your blockchain, so you can go back
+to it at any moment while developing instead of replaying the transactions that
+lead to it after every reset.
+
+A snapshot contains the genesis exported from the chain state and a copy of the
+data directory of each validator node. Snapshots are saved next to the data that
+Ignite keeps for the chain.
+
+The chain must be stopped to save or restore a snapshot:
+
+	ignite chain snapshot save setup
+	ignite chain snapshot restore setup
+
+After a snapshot is restored "ignite chain serve" starts the chain from the
+restored state. When the source code changed since the snapshot was saved, the
+state is imported from the genesis exported in the snapshot.
+`,
+		Args: cobra.ExactArgs(1),
+	}
+
+	flagSetPath(c)
+	c.PersistentFlags().AddFlagSet(flagSetHome())
+
+	c.AddCommand(
+		NewChainSnapshotSave(),
+		NewChainSnapshotList(),
+		NewChainSnapshotRestore(),
+		NewChainSnapshotDelete(),
+	)
+
+	return c
+}
+
+// NewChainSnapshotSave creates a command to save a snapshot of the chain state.
+func NewChainSnapshotSave() *cobra.Command {
+	return &cobra.Command{
+		Use:   "save [name]",
+		Short: "Save the chain state into a new snapshot",
+		Args:  cobra.ExactArgs(1),
+		RunE:  chainSnapshotSaveHandler,
+	}
+}
+
+// NewChainSnapshotList creates a command to list the snapshots of the chain state.
+func NewChainSnapshotList() *cobra.Command {
+	return &cobra.Command{
+		Use:   "list",
+		Short: "List the snapshots of the chain state",
+		Args:  cobra.NoArgs,
+		RunE:  chainSnapshotListHandler,
+	}
+}
+
+// NewChainSnapshotRestore creates a command to restore a snapshot of the chain state.
+func NewChainSnapshotRestore() *cobra.Command {
+	return &cobra.Command{
+		Use:   "restore [name]",
+		Short: "Replace the chain state with a snapshot",
+		Args:  cobra.ExactArgs(1),
+		RunE:  chainSnapshotRestoreHandler,
+	}
+}
+
+// NewChainSnapshotDelete creates a command to delete a snapshot of the chain state.
+func NewChainSnapshotDelete() *cobra.Command {
+	return &cobra.Command{
+		Use:   "delete [name]",
+		Short: "Delete a snapshot of the chain state",
+		Args:  cobra.ExactArgs(1),
+		RunE:  chainSnapshotDeleteHandler,
+	}
+}
+
+func chainSnapshotSaveHandler(cmd *cobra.Command, args []string) error {
+	session := cliui.New(cliui.StartSpinner())
+	defer session.End()
+
+	c, err := newChainWithHomeFlags(
+		cmd,
+		chain.WithOutputer(session),
+		chain.CollectEvents(session.EventBus()),
+	)
+	if err != nil {
+		return err
+	}
+
+	return c.SaveSnapshot(cmd.Context(), args[0])
+}
+
+func chainSnapshotListHandler(cmd *cobra.Command, _ []string) error {
+	session := cliui.New()
+	defer session.End()
+
+	c, err := newChainWithHomeFlags(cmd)
+	if err != nil {
+		return err
+	}
+
+	snapshots, err := c.Snapshots()
+	if err != nil {
+		return err
+	}
+
+	if len(snapshots) == 0 {
+		return session.Println("No snapshots saved.")
+	}
+
+	var entries [][]string
+	for _, s := range snapshots {
+		entries = append(entries, []string{
+			s.Name,
+			s.CreatedAt.Format("2006-01-02 15:04:05"),
+			s.Path,
+		})
+	}
+
+	return session.PrintTable([]string{"Name", "Created", "Path"}, entries...)
+}
+
+func chainSnapshotRestoreHandler(cmd *cobra.Command, args []string) error {
+	session := cliui.New(cliui.StartSpinner())
+	defer session.End()
+
+	c, err := newChainWithHomeFlags(
+		cmd,
+		chain.WithOutputer(session),
+		chain.CollectEvents(session.EventBus()),
+	)
+	if err != nil {
+		return err
+	}
+
+	return c.RestoreSnapshot(cmd.Context(), args[0])
+}
+
+func chainSnapshotDeleteHandler(cmd *cobra.Command, args []string) error {
+	session := cliui.New()
+	defer session.End()
+
+	c, err := newChainWithHomeFlags(cmd)
+	if err != nil {
+		return err
+	}
+
+	if err := c.DeleteSnapshot(args[0]); err != nil {
+		return err
+	}
+
+	return session.Printf("Snapshot %s deleted.\n", args[0])
+}
@@ -1,28 +1,146 @@
 package ignitecmd
 
 import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
 	"github.com/spf13/cobra"
 
 	"github.com/ignite/cli/ignite/pkg/cliui"
+	"github.com/ignite/cli/ignite/pkg/cliui/colors"
+	"github.com/ignite/cli/ignite/pkg/cliui/icons"
 	"github.com/ignite/cli/ignite/services/doctor"
 )
 
+const (
+	flagFix  = "fix"
+	flagJSON = "json"
+)
+
+// errDoctorFailed is returned when at least one of the doctor checks fails.
+var errDoctorFailed = errors.New("doctor found problems in your environment")
+
 func NewDoctor() *cobra.Command {
-	return &cobra.Command{
-		Use:    "doctor",
-		Short:  "Fix chain configuration",
-		Hidden: true,
-		RunE: func(cmd *cobra.Command, args []string) error {
-			session := cliui.New()
-			defer session.End()
+	c := &cobra.Command{
+		Use:   "doctor",
+		Short: "Diagnose your development environment",
+		Long: `Run a set of checks to diagnose problems in your development environment.
+
+The doctor checks the installed Go version, the dependency tools and protoc
+plugins, the validity of the chain config file, the scaffolding placeholders of
+"app.go", the ports used by the chain, the Ignite accounts keyring and the
+Ignite cache. Each check passes, warns about a problem or fails.
 
-			doc := doctor.New(doctor.CollectEvents(session.EventBus()))
+Some problems can be fixed automatically, like an outdated config file or a
+missing "tools/tools.go" file. Use the "--fix" flag to fix them:
 
-			if err := doc.MigrateConfig(cmd.Context()); err != nil {
-				return err
-			}
+	ignite doctor --fix
 
-			return doc.FixDependencyTools(cmd.Context())
-		},
+Use the "--json" flag to print the results in JSON format:
+
+	ignite doctor --json
+
+The command fails when at least one of the checks fails.
+`,
+		Args: cobra.NoArgs,
+		RunE: doctorHandler,
 	}
+
+	c.Flags().Bool(flagFix, false, "fix the problems that can be fixed automatically")
+	c.Flags().Bool(flagJSON, false, "print the results in JSON format")
+
+	return c
+}
+
+func doctorHandler(cmd *cobra.Command, _ []string) error {
+	var (
+		fix, _      = cmd.Flags().GetBool(flagFix)
+		jsonOut, _  = cmd.Flags().GetBool(flagJSON)
+		sessionOpts []cliui.Option
+	)
+
+	if !jsonOut {
+		sessionOpts = append(sessionOpts, cliui.StartSpinner())
+	}
+
+	session := cliui.New(sessionOpts...)
+	defer session.End()
+
+	cacheStorage, err := newCache(cmd)
+	if err != nil {
+		return err
+	}
+
+	options := []doctor.Option{doctor.WithCacheStorage(cacheStorage)}
+	if !jsonOut {
+		options = append(options, doctor.CollectEvents(session.EventBus()))
+	}
+
+	reports, err := doctor.New(options...).Diagnose(cmd.Context(), fix)
+	if err != nil {
+		return err
+	}
+
+	if jsonOut {
+		bz, err := json.MarshalIndent(reports, "", "  ")
+		if err != nil {
+			return err
+		}
+
+		if err := session.Println(string(bz)); err != nil {
+			return err
+		}
+	} else if err := printDoctorReports(session, reports); err != nil {
+		return err
+	}
+
+	for _, r := range reports {
+		if r.Status == doctor.StatusFail {
+			return errDoctorFailed
+		}
+	}
+
+	return nil
+}
+
+func printDoctorReports(session *cliui.Session, reports []doctor.Report) error {
+	var (
+		header  = []string{"", "check", "status", "message"}
+		entries [][]string
+		fixable bool
+	)
+
+	for _, r := range reports {
+		var icon, status string
+		switch r.Status {
+		case doctor.StatusPass:
+			icon, status = icons.OK, colors.Success(r.Status)
+		case doctor.StatusWarn:
+			icon, status = icons.Bullet, colors.Info(r.Status)
+		default:
+			icon, status = icons.NotOK, colors.Error(r.Status)
+		}
+
+		message := r.Message
+		if r.Fixed {
+			message = fmt.Sprintf("%s (fixed)", message)
+		}
+		if r.Fixable {
+			message = fmt.Sprintf("%s (fixable)", message)
+			fixable = true
+		}
+
+		entries = append(entries, []string{icon, r.Check, status, message})
+	}
+
+	if err := session.PrintTable(header, entries...); err != nil {
+		return err
+	}
+
+	if fixable {
+		return session.Printf("\n%s Run %s to fix the fixable problems\n", icons.Info, colors.Info("ignite doctor --fix"))
+	}
+
+	return nil
 }
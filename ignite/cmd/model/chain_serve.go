@@ -27,6 +27,7 @@ const (
 var (
 	msgStopServe  = colors.Faint("Press the 'q' key to stop serve")
 	msgWaitingFix = colors.Info("Waiting for a fix before retrying...")
+	msgTests      = colors.Info("Tests")
 )
 
 type Context interface {
@@ -52,6 +53,7 @@ func NewChainServe(mCtx Context, bus events.Provider, cmd tea.Cmd) ChainServe {
 		runModel:     cliuimodel.NewEvents(bus),
 		rebuildModel: cliuimodel.NewStatusEvents(bus, maxStatusEvents),
 		quitModel:    cliuimodel.NewEvents(bus),
+		testModel:    cliuimodel.NewEvents(bus),
 	}
 }
 
@@ -69,6 +71,9 @@ type ChainServe struct {
 	runModel     cliuimodel.Events
 	rebuildModel cliuimodel.StatusEvents
 	quitModel    cliuimodel.Events
+
+	// Model for the test results displayed below the run view
+	testModel cliuimodel.Events
 }
 
 // Init is the first function that will be called.
@@ -161,6 +166,13 @@ func (m ChainServe) processKeyMsg(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 }
 
 func (m ChainServe) processEventMsg(msg cliuimodel.EventMsg) (tea.Model, tea.Cmd) {
+	// Test events are displayed in their own panel without changing the view
+	if msg.Group == events.GroupTest {
+		var cmd tea.Cmd
+		m.testModel, cmd = m.testModel.Update(msg)
+		return m, cmd
+	}
+
 	// When an error event is received it means there is an issue with
 	// the blockchain app's source code that the user must fix.
 	m.broken = msg.Group == events.GroupError
@@ -193,6 +205,7 @@ func (m ChainServe) processEventMsg(msg cliuimodel.EventMsg) (tea.Model, tea.Cmd
 			// When a status event is received during run it means something
 			// changed in the source code which triggers the blockchain rebuild.
 			m.runModel.ClearEvents()
+			m.testModel.ClearEvents()
 			m.state = stateChainServeRebuilding
 		}
 	case stateChainServeRebuilding:
@@ -229,6 +242,8 @@ func (m ChainServe) renderRunView() string {
 
 	if m.broken {
 		fmt.Fprintf(&view, "\n%s\n", msgWaitingFix)
+	} else if tests := m.testModel.View(); tests != "" {
+		fmt.Fprintf(&view, "\n%s\n\n%s", msgTests, tests)
 	}
 
 	return view.String()
@@ -171,3 +171,54 @@ func TestChainServeRebuildView(t *testing.T) {
 	// Assert
 	require.Equal(t, want, view)
 }
+
+func TestChainServeRunTestsView(t *testing.T) {
+	// Arrange
+	var model tea.Model
+
+	tests := []string{"Testing 2 affected packages...", "mars/x/mars"}
+	model = cmdmodel.NewChainServe(testdata.ModelContext{}, testdata.DummyEventsProvider{}, testdata.FooCmd)
+
+	want := fmt.Sprintf(
+		"Blockchain is running\n\nRun\n\n%s\n\n%s %s\n%s %s\n\n%s\n",
+		colors.Info("Tests"),
+		icons.Bullet,
+		tests[0],
+		icons.OK,
+		tests[1],
+		chainServeActions,
+	)
+	want = cliuimodel.FormatView(want)
+
+	// Arrange: Update model with test events received while the app starts
+	model, _ = model.Update(cliuimodel.EventMsg{
+		Event: events.New(tests[0], events.Icon(icons.Bullet), events.Group(events.GroupTest)),
+	})
+
+	// Arrange: Update model to display the run view
+	model, _ = model.Update(cliuimodel.EventMsg{
+		Event: events.New("Run", events.ProgressFinish()),
+	})
+
+	model, _ = model.Update(cliuimodel.EventMsg{
+		Event: events.New(tests[1], events.Icon(icons.OK), events.Group(events.GroupTest)),
+	})
+
+	// Act
+	view := model.View()
+
+	// Assert
+	require.Equal(t, want, view)
+
+	// Arrange: Update model to display the rebuild view
+	model, _ = model.Update(cliuimodel.EventMsg{
+		Event: events.New("Rebuild", events.ProgressStart()),
+		Start: time.Now(),
+	})
+
+	// Act
+	view = model.View()
+
+	// Assert
+	require.NotContains(t, view, tests[1])
+}
@@ -1,16 +1,34 @@
 package ignitecmd
 
 import (
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+
+	sdktypes "github.com/cosmos/cosmos-sdk/types"
+	"github.com/jhump/protoreflect/desc"
 	"github.com/spf13/cobra"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
 
+	chainconfig "github.com/ignite/cli/ignite/config/chain"
 	"github.com/ignite/cli/ignite/pkg/cosmosaccount"
 	"github.com/ignite/cli/ignite/pkg/cosmosclient"
+	"github.com/ignite/cli/ignite/pkg/cosmosreflect"
 	"github.com/ignite/cli/ignite/pkg/xurl"
 )
 
 const (
 	flagNode         = "node"
+	flagGRPC         = "grpc"
 	cosmosRPCAddress = "https://rpc.cosmos.network:443"
+	defaultGRPCPort  = "9090"
 )
 
 func NewNode() *cobra.Command {
@@ -21,6 +39,7 @@ func NewNode() *cobra.Command {
 	}
 
 	c.PersistentFlags().String(flagNode, cosmosRPCAddress, "<host>:<port> to tendermint rpc interface for this chain")
+	c.PersistentFlags().String(flagGRPC, "", fmt.Sprintf("<host>:<port> to gRPC interface for this chain (default node host with port %s)", defaultGRPCPort))
 
 	c.AddCommand(NewNodeQuery())
 	c.AddCommand(NewNodeTx())
@@ -28,7 +47,7 @@ func NewNode() *cobra.Command {
 	return c
 }
 
-func newNodeCosmosClient(cmd *cobra.Command) (cosmosclient.Client, error) {
+func newNodeCosmosClient(cmd *cobra.Command, extraOptions ...cosmosclient.Option) (cosmosclient.Client, error) {
 	var (
 		home           = getHome(cmd)
 		prefix         = getAddressPrefix(cmd)
@@ -69,10 +88,159 @@ func newNodeCosmosClient(cmd *cobra.Command) (cosmosclient.Client, error) {
 		options = append(options, cosmosclient.WithFees(fees))
 	}
 
+	options = append(options, extraOptions...)
+
 	return cosmosclient.New(cmd.Context(), options...)
 }
 
+// newNodeGRPCConn dials the gRPC interface of the node.
+// TLS is used when the node address uses HTTPS.
+func newNodeGRPCConn(cmd *cobra.Command) (*grpc.ClientConn, error) {
+	node, err := url.Parse(xurl.HTTPEnsurePort(getNode(cmd)))
+	if err != nil {
+		return nil, err
+	}
+
+	addr := getGRPC(cmd)
+	if addr == "" {
+		addr = net.JoinHostPort(node.Hostname(), defaultGRPCPort)
+	}
+
+	creds := insecure.NewCredentials()
+	if node.Scheme == "https" {
+		creds = credentials.NewTLS(&tls.Config{MinVersion: tls.VersionTLS12})
+	}
+
+	return grpc.DialContext(cmd.Context(), addr, grpc.WithTransportCredentials(creds))
+}
+
+// nodeModuleMethod finds a method of a module service of the node.
+func nodeModuleMethod(
+	cmd *cobra.Command,
+	conn *grpc.ClientConn,
+	module,
+	service,
+	name string,
+) (*desc.MethodDescriptor, error) {
+	resolvers, closeResolvers := newNodeResolvers(cmd, conn)
+	defer closeResolvers()
+
+	return findModuleMethod(resolvers, module, service, name)
+}
+
+// newNodeResolvers returns the resolvers of the services of the node.
+// The services are resolved using the gRPC reflection service of the node
+// or using the proto files of the app when reflection is not available.
+// The proto files are only compiled when they are needed, so the app config
+// is not read when reflection is available. The returned function must be
+// called to release the resolvers.
+func newNodeResolvers(cmd *cobra.Command, conn *grpc.ClientConn) ([]cosmosreflect.Resolver, func()) {
+	reflection := cosmosreflect.NewReflectionResolver(cmd.Context(), conn)
+	return []cosmosreflect.Resolver{reflection, newAppProtoResolver(cmd)}, reflection.Close
+}
+
+// newAppProtoResolver returns a resolver of the services of the app that
+// uses its proto files. The proto files are compiled the first time the
+// resolver is used.
+func newAppProtoResolver(cmd *cobra.Command) cosmosreflect.Resolver {
+	return cosmosreflect.NewLazyResolver(func() (cosmosreflect.Resolver, error) {
+		protoDir, err := appProtoDir(flagGetPath(cmd))
+		if err != nil {
+			return nil, err
+		}
+
+		if _, err := os.Stat(protoDir); err != nil {
+			return nil, fmt.Errorf("app proto files not found: %w", err)
+		}
+
+		return cosmosreflect.NewProtoResolver(cmd.Context(), protoDir)
+	})
+}
+
+// newNodeMsgDecoder returns a decoder of the messages of the app modules, which
+// are not registered in the client codec. The messages are resolved like the ones
+// of "ignite node tx", only the app proto files are used when offline is true.
+// The resolvers are created when the first message is decoded.
+// The returned function must be called to release the resolvers.
+func newNodeMsgDecoder(cmd *cobra.Command, offline bool) (cosmosclient.MsgJSONDecoder, func()) {
+	var (
+		resolvers []cosmosreflect.Resolver
+		release   = func() {}
+	)
+
+	decode := func(typeURL string, bz []byte) (sdktypes.Msg, error) {
+		if resolvers == nil {
+			if offline {
+				resolvers = []cosmosreflect.Resolver{newAppProtoResolver(cmd)}
+			} else {
+				conn, err := newNodeGRPCConn(cmd)
+				if err != nil {
+					return nil, err
+				}
+
+				var closeResolvers func()
+				resolvers, closeResolvers = newNodeResolvers(cmd, conn)
+				release = func() {
+					closeResolvers()
+					conn.Close()
+				}
+			}
+		}
+
+		md, err := cosmosreflect.FindMsg(resolvers, strings.TrimPrefix(typeURL, "/"))
+		if err != nil {
+			return nil, err
+		}
+
+		m, err := cosmosreflect.NewMessage(md, string(bz))
+		if err != nil {
+			return nil, err
+		}
+
+		return cosmosreflect.Msg{Message: m}, nil
+	}
+
+	return decode, func() { release() }
+}
+
+// findModuleMethod finds a method of a module service using the resolvers.
+func findModuleMethod(resolvers []cosmosreflect.Resolver, module, service, name string) (*desc.MethodDescriptor, error) {
+	s, err := cosmosreflect.FindService(resolvers, module, service)
+	if err != nil {
+		return nil, err
+	}
+
+	m, err := cosmosreflect.FindMethod(s, name)
+	if errors.Is(err, cosmosreflect.ErrMethodNotFound) {
+		return nil, fmt.Errorf("%w, available methods: %s", err, strings.Join(cosmosreflect.MethodNames(s), ", "))
+	}
+
+	return m, err
+}
+
+// appProtoDir returns the proto directory of the app in path.
+func appProtoDir(path string) (string, error) {
+	configPath, err := chainconfig.LocateDefault(path)
+	if errors.Is(err, chainconfig.ErrConfigNotFound) {
+		return filepath.Join(path, chainconfig.DefaultChainConfig().Build.Proto.Path), nil
+	} else if err != nil {
+		return "", err
+	}
+
+	cfg, err := chainconfig.ParseFile(configPath)
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(path, cfg.Build.Proto.Path), nil
+}
+
 func getNode(cmd *cobra.Command) (node string) {
 	node, _ = cmd.Flags().GetString(flagNode)
 	return
 }
+
+func getGRPC(cmd *cobra.Command) (addr string) {
+	addr, _ = cmd.Flags().GetString(flagGRPC)
+	return
+}
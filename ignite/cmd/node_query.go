@@ -20,12 +20,34 @@ const (
 
 func NewNodeQuery() *cobra.Command {
 	c := &cobra.Command{
-		Use:     "query",
-		Short:   "Querying subcommands",
+		Use:   "query [module] [method] [json-args]",
+		Short: "Querying subcommands",
+		Long: `Query any module of the node by calling a method of its Query gRPC service.
+
+The module is either a segment of the proto package of the service, like "bank",
+or the full proto package name, like "cosmos.bank.v1beta1". The method is the
+name of the RPC method or of its request message, and the arguments are the
+fields of the request message as a JSON object:
+
+  ignite node query bank balance '{"address":"cosmos1...","denom":"uatom"}'
+  ignite node query mars list-post
+
+The services are resolved using the gRPC reflection service of the node. When
+reflection is not available, the proto files of the app found in "--path" are
+used instead.
+`,
 		Aliases: []string{"q"},
+		Args:    cobra.MaximumNArgs(3),
+		RunE:    nodeQueryModuleHandler,
 	}
 
-	c.AddCommand(NewNodeQueryBank())
+	flagSetPath(c)
+
+	bank := NewNodeQueryBank()
+	bank.Args = cobra.MaximumNArgs(2)
+	bank.RunE = moduleHandler(nodeQueryModuleHandler, "bank")
+
+	c.AddCommand(bank)
 	c.AddCommand(NewNodeQueryTx())
 
 	return c
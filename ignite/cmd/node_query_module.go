@@ -0,0 +1,62 @@
+package ignitecmd
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/ignite/cli/ignite/pkg/cliui"
+	"github.com/ignite/cli/ignite/pkg/cosmosreflect"
+)
+
+// moduleHandler returns a handler that runs the module handler with the module name
+// prepended to the args, so the commands of a module can call any of its methods.
+func moduleHandler(handler func(*cobra.Command, []string) error, module string) func(*cobra.Command, []string) error {
+	return func(cmd *cobra.Command, args []string) error {
+		return handler(cmd, append([]string{module}, args...))
+	}
+}
+
+func nodeQueryModuleHandler(cmd *cobra.Command, args []string) error {
+	if len(args) < 2 {
+		return cmd.Help()
+	}
+
+	session := cliui.New(cliui.StartSpinnerWithText(statusQuerying))
+	defer session.End()
+
+	var (
+		module   = args[0]
+		name     = args[1]
+		jsonArgs string
+	)
+	if len(args) == 3 {
+		jsonArgs = args[2]
+	}
+
+	conn, err := newNodeGRPCConn(cmd)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	method, err := nodeModuleMethod(cmd, conn, module, cosmosreflect.ServiceQuery, name)
+	if err != nil {
+		return err
+	}
+
+	req, err := cosmosreflect.NewMessage(method.GetInputType(), jsonArgs)
+	if err != nil {
+		return err
+	}
+
+	res, err := cosmosreflect.Invoke(cmd.Context(), conn, method, req)
+	if err != nil {
+		return err
+	}
+
+	bz, err := cosmosreflect.MarshalJSON(res)
+	if err != nil {
+		return err
+	}
+
+	return session.Println(string(bz))
+}
@@ -9,6 +9,7 @@ import (
 
 const (
 	flagGenerateOnly = "generate-only"
+	flagFrom         = "from"
 
 	gasFlagAuto    = "auto"
 	flagGasPrices  = "gas-prices"
@@ -19,8 +20,25 @@ const (
 
 func NewNodeTx() *cobra.Command {
 	c := &cobra.Command{
-		Use:   "tx",
+		Use:   "tx [module] [msg] [json-args]",
 		Short: "Transactions subcommands",
+		Long: `Broadcast a transaction with a message of any module of the node.
+
+The module is either a segment of the proto package of its Msg gRPC service,
+like "bank", or the full proto package name, like "cosmos.bank.v1beta1". The
+message is the name of the message or of its RPC method, and the arguments are
+the fields of the message as a JSON object. The signer field of the message is
+set to the address of the "--from" account when it's not defined:
+
+  ignite node tx staking delegate '{"validator_address":"cosmosvaloper1...","amount":{"denom":"uatom","amount":"10"}}' --from alice
+  ignite node tx mars create-post '{"title":"hello"}' --from alice
+
+The services are resolved using the gRPC reflection service of the node. When
+reflection is not available, the proto files of the app found in "--path" are
+used instead.
+`,
+		Args: cobra.MaximumNArgs(3),
+		RunE: nodeTxModuleHandler,
 	}
 	c.PersistentFlags().AddFlagSet(flagSetHome())
 	c.PersistentFlags().AddFlagSet(flagSetKeyringBackend())
@@ -30,7 +48,18 @@ func NewNodeTx() *cobra.Command {
 	c.PersistentFlags().AddFlagSet(flagSetGasFlags())
 	c.PersistentFlags().String(flagFees, "", "fees to pay along with transaction; eg: 10uatom")
 
-	c.AddCommand(NewNodeTxBank())
+	c.PersistentFlags().String(flagFrom, "", "name or address of the account of the keyring that signs the transaction")
+	flagSetPath(c)
+
+	bank := NewNodeTxBank()
+	bank.Args = cobra.MaximumNArgs(2)
+	bank.RunE = moduleHandler(nodeTxModuleHandler, "bank")
+
+	c.AddCommand(bank)
+	c.AddCommand(NewNodeTxSign())
+	c.AddCommand(NewNodeTxMultisign())
+	c.AddCommand(NewNodeTxBroadcast())
+	c.AddCommand(NewNodeTxRun())
 
 	return c
 }
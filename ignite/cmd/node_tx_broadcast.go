@@ -0,0 +1,52 @@
+package ignitecmd
+
+import (
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ignite/cli/ignite/pkg/cliui"
+	"github.com/ignite/cli/ignite/pkg/cosmosclient"
+)
+
+func NewNodeTxBroadcast() *cobra.Command {
+	c := &cobra.Command{
+		Use:   "broadcast [file]",
+		Short: "Broadcast a signed transaction",
+		Args:  cobra.ExactArgs(1),
+		RunE:  nodeTxBroadcastHandler,
+	}
+
+	return c
+}
+
+func nodeTxBroadcastHandler(cmd *cobra.Command, args []string) error {
+	session := cliui.New()
+	defer session.End()
+
+	bz, err := os.ReadFile(args[0])
+	if err != nil {
+		return err
+	}
+
+	client, err := newNodeCosmosClient(cmd)
+	if err != nil {
+		return err
+	}
+
+	decodeMsg, release := newNodeMsgDecoder(cmd, false)
+	defer release()
+
+	txBuilder, err := client.DecodeTxJSON(bz, cosmosclient.DecodeTxMsgs(decodeMsg))
+	if err != nil {
+		return err
+	}
+
+	session.StartSpinner("Sending transaction...")
+	resp, err := client.BroadcastSignedTx(cmd.Context(), txBuilder)
+	if err != nil {
+		return err
+	}
+
+	return session.Printf("Transaction broadcast successful! (hash = %s)\n", resp.TxHash)
+}
@@ -0,0 +1,101 @@
+package ignitecmd
+
+import (
+	"errors"
+
+	"github.com/jhump/protoreflect/dynamic"
+	"github.com/spf13/cobra"
+
+	"github.com/ignite/cli/ignite/pkg/cliui"
+	"github.com/ignite/cli/ignite/pkg/cosmosreflect"
+)
+
+func nodeTxModuleHandler(cmd *cobra.Command, args []string) error {
+	if len(args) < 2 {
+		return cmd.Help()
+	}
+
+	session := cliui.New()
+	defer session.End()
+
+	var (
+		module       = args[0]
+		name         = args[1]
+		from, _      = cmd.Flags().GetString(flagFrom)
+		generateOnly = getGenerateOnly(cmd)
+		jsonArgs     string
+	)
+	if len(args) == 3 {
+		jsonArgs = args[2]
+	}
+
+	if from == "" {
+		return errors.New("the account that signs the transaction must be set with --from")
+	}
+
+	client, err := newNodeCosmosClient(cmd)
+	if err != nil {
+		return err
+	}
+
+	account, err := client.Account(from)
+	if err != nil {
+		return err
+	}
+
+	address, err := account.Address(getAddressPrefix(cmd))
+	if err != nil {
+		return err
+	}
+
+	conn, err := newNodeGRPCConn(cmd)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	method, err := nodeModuleMethod(cmd, conn, module, cosmosreflect.ServiceMsg, name)
+	if err != nil {
+		return err
+	}
+
+	msg, err := cosmosreflect.NewMsg(method.GetInputType(), jsonArgs, address)
+	if err != nil {
+		return err
+	}
+
+	tx, err := client.CreateTx(cmd.Context(), account, msg)
+	if err != nil {
+		return err
+	}
+
+	if generateOnly {
+		json, err := tx.EncodeJSON()
+		if err != nil {
+			return err
+		}
+
+		return session.Println(string(json))
+	}
+
+	session.StartSpinner("Sending transaction...")
+	resp, err := tx.Broadcast(cmd.Context())
+	if err != nil {
+		return err
+	}
+
+	session.Printf("Transaction broadcast successful! (hash = %s)\n", resp.TxHash)
+
+	// Print the response of the message when the node returns it
+	res := dynamic.NewMessage(method.GetOutputType())
+	if err := resp.Decode(res); err != nil || len(method.GetOutputType().GetFields()) == 0 {
+		return nil
+	}
+
+	bz, err := cosmosreflect.MarshalJSON(res)
+	if err != nil {
+		return err
+	}
+
+	return session.Println(string(bz))
+}
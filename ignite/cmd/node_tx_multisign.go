@@ -0,0 +1,78 @@
+package ignitecmd
+
+import (
+	"errors"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ignite/cli/ignite/pkg/cliui"
+)
+
+func NewNodeTxMultisign() *cobra.Command {
+	c := &cobra.Command{
+		Use:   "multisign [file] [signatures...]",
+		Short: "Combine the signatures of a multisig account transaction",
+		Long: `Combine the signatures of the keys of the "--from" multisig account, created
+with "ignite node tx sign --multisig", and write the signed transaction to STDOUT:
+
+  ignite node tx sign tx.json --from alice --multisig treasury > alice.json
+  ignite node tx sign tx.json --from bob --multisig treasury > bob.json
+  ignite node tx multisign tx.json alice.json bob.json --from treasury > signed.json
+  ignite node tx broadcast signed.json
+`,
+		Args: cobra.MinimumNArgs(2),
+		RunE: nodeTxMultisignHandler,
+	}
+
+	c.Flags().AddFlagSet(flagSetOfflineSign())
+
+	return c
+}
+
+func nodeTxMultisignHandler(cmd *cobra.Command, args []string) error {
+	session := cliui.New()
+	defer session.End()
+
+	from, _ := cmd.Flags().GetString(flagFrom)
+	if from == "" {
+		return errors.New("the multisig account must be set with --from")
+	}
+
+	client, txBuilder, release, err := newNodeOfflineTx(cmd, args[0])
+	if err != nil {
+		return err
+	}
+	defer release()
+
+	account, err := client.Account(from)
+	if err != nil {
+		return err
+	}
+
+	options, err := getOfflineSignOptions(cmd)
+	if err != nil {
+		return err
+	}
+
+	var signatures [][]byte
+	for _, file := range args[1:] {
+		bz, err := os.ReadFile(file)
+		if err != nil {
+			return err
+		}
+
+		signatures = append(signatures, bz)
+	}
+
+	if err := client.MultisignTx(account, txBuilder, signatures, options...); err != nil {
+		return err
+	}
+
+	json, err := client.EncodeTxJSON(txBuilder)
+	if err != nil {
+		return err
+	}
+
+	return session.Println(string(json))
+}
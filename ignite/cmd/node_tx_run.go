@@ -0,0 +1,220 @@
+package ignitecmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jhump/protoreflect/desc"
+	"github.com/jhump/protoreflect/dynamic"
+	"github.com/spf13/cobra"
+
+	"github.com/ignite/cli/ignite/pkg/cliui"
+	"github.com/ignite/cli/ignite/pkg/cliui/icons"
+	"github.com/ignite/cli/ignite/pkg/cosmosclient"
+	"github.com/ignite/cli/ignite/pkg/cosmosreflect"
+	"github.com/ignite/cli/ignite/pkg/cosmostxscript"
+)
+
+const flagVar = "var"
+
+func NewNodeTxRun() *cobra.Command {
+	c := &cobra.Command{
+		Use:   "run [script]",
+		Short: "Broadcast the transactions of a YAML or JSON script",
+		Long: `Broadcast the transactions of the steps of a YAML or JSON script in order.
+
+Each step broadcasts a message of a module, like "ignite node tx [module] [msg]",
+signed by the "from" account of the step or by the "--from" account. The steps
+can check the outcome of their transaction and save values of its result as
+variables, which are referenced by the next steps with ${name}:
+
+  vars:
+    title: hello
+  steps:
+    - name: create post
+      from: alice
+      module: mars
+      msg: create-post
+      args:
+        title: ${title}
+      expect:
+        events:
+          - type: mars.mars.EventPostCreated
+      save:
+        postID: events.mars.mars.EventPostCreated.id
+    - name: like post twice
+      from: bob
+      module: mars
+      msg: like-post
+      args:
+        id: ${postID}
+      expect:
+        fail: true
+        error: already liked
+
+The values saved are either "hash", "events.<type>.<attribute>" or
+"response.<field>" for a field of the response of the message. The variables
+of the script can be overridden with "--var name=value".
+
+The script stops at the first step that fails or that doesn't have the
+expected outcome.
+`,
+		Args: cobra.ExactArgs(1),
+		RunE: nodeTxRunHandler,
+	}
+
+	c.Flags().StringToString(flagVar, nil, "set a variable of the script (e.g. --var denom=token)")
+
+	return c
+}
+
+func nodeTxRunHandler(cmd *cobra.Command, args []string) error {
+	session := cliui.New()
+	defer session.End()
+
+	vars, _ := cmd.Flags().GetStringToString(flagVar)
+
+	if getGenerateOnly(cmd) {
+		return errors.New("--generate-only is not supported by scripts")
+	}
+
+	script, err := cosmostxscript.ParseFile(args[0])
+	if err != nil {
+		return err
+	}
+
+	client, err := newNodeCosmosClient(cmd)
+	if err != nil {
+		return err
+	}
+
+	conn, err := newNodeGRPCConn(cmd)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	resolvers, closeResolvers := newNodeResolvers(cmd, conn)
+	defer closeResolvers()
+
+	exec := &nodeTxExecutor{
+		cmd:       cmd,
+		session:   session,
+		client:    client,
+		resolvers: resolvers,
+		methods:   make(map[string]*desc.MethodDescriptor),
+	}
+
+	var executed int
+	err = script.Run(cmd.Context(), exec, vars, func(r cosmostxscript.StepResult) {
+		session.StopSpinner()
+
+		if r.Err != nil {
+			session.Printf("%s %s: %s\n", icons.NotOK, r.Step.Name, r.Err)
+			return
+		}
+
+		executed++
+		if r.Result.Err != nil {
+			session.Printf("%s %s: failed as expected\n", icons.OK, r.Step.Name)
+			return
+		}
+
+		session.Printf("%s %s (hash = %s)\n", icons.OK, r.Step.Name, r.Result.Hash)
+	})
+	if err != nil {
+		return err
+	}
+
+	return session.Printf("\n%d of %d steps executed successfully\n", executed, len(script.Steps))
+}
+
+// nodeTxExecutor broadcasts the messages of the steps of a script.
+type nodeTxExecutor struct {
+	cmd       *cobra.Command
+	session   *cliui.Session
+	client    cosmosclient.Client
+	resolvers []cosmosreflect.Resolver
+
+	// methods are the methods of the messages already resolved by module and message name.
+	methods map[string]*desc.MethodDescriptor
+}
+
+func (e *nodeTxExecutor) Execute(
+	ctx context.Context,
+	step cosmostxscript.Step,
+	args []byte,
+) (cosmostxscript.Result, error) {
+	e.session.StartSpinner(fmt.Sprintf("Running %s...", step.Name))
+
+	from := step.From
+	if from == "" {
+		from, _ = e.cmd.Flags().GetString(flagFrom)
+	}
+	if from == "" {
+		return cosmostxscript.Result{}, errors.New("the account that signs the transaction must be set with from or --from")
+	}
+
+	account, err := e.client.Account(from)
+	if err != nil {
+		return cosmostxscript.Result{}, err
+	}
+
+	address, err := account.Address(getAddressPrefix(e.cmd))
+	if err != nil {
+		return cosmostxscript.Result{}, err
+	}
+
+	method, err := e.method(step.Module, step.Msg)
+	if err != nil {
+		return cosmostxscript.Result{}, err
+	}
+
+	msg, err := cosmosreflect.NewMsg(method.GetInputType(), string(args), address)
+	if err != nil {
+		return cosmostxscript.Result{}, err
+	}
+
+	resp, err := e.client.BroadcastTx(ctx, account, msg)
+	if errors.As(err, &cosmosclient.TxError{}) || errors.As(err, &cosmosclient.SimulationError{}) {
+		// The failure of the transaction, or of its simulation when the gas is
+		// estimated, is checked against the expected outcome
+		return cosmostxscript.Result{Err: err}, nil
+	} else if err != nil {
+		return cosmostxscript.Result{}, err
+	}
+
+	r := cosmostxscript.Result{
+		Hash:   resp.TxHash,
+		Events: resp.Events,
+	}
+
+	// Add the response of the message when the node returns it
+	res := dynamic.NewMessage(method.GetOutputType())
+	if err := resp.Decode(res); err == nil {
+		if r.Response, err = cosmosreflect.MarshalJSON(res); err != nil {
+			return cosmostxscript.Result{}, err
+		}
+	}
+
+	return r, nil
+}
+
+// method returns the method of a message of a module, which is only resolved
+// the first time the message is used by a step.
+func (e *nodeTxExecutor) method(module, name string) (*desc.MethodDescriptor, error) {
+	key := module + "/" + name
+	if m, ok := e.methods[key]; ok {
+		return m, nil
+	}
+
+	m, err := findModuleMethod(e.resolvers, module, cosmosreflect.ServiceMsg, name)
+	if err != nil {
+		return nil, err
+	}
+
+	e.methods[key] = m
+
+	return m, nil
+}
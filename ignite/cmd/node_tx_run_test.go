@@ -0,0 +1,107 @@
+package ignitecmd
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jhump/protoreflect/desc"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/ignite/cli/ignite/pkg/cliui"
+	"github.com/ignite/cli/ignite/pkg/cosmosaccount"
+	"github.com/ignite/cli/ignite/pkg/cosmosclient"
+	"github.com/ignite/cli/ignite/pkg/cosmosclient/mocks"
+	"github.com/ignite/cli/ignite/pkg/cosmosreflect"
+	"github.com/ignite/cli/ignite/pkg/cosmostxscript"
+)
+
+func TestNodeTxExecutorExpectFail(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+
+	protoDir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(protoDir, "mars", "mars"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(protoDir, "mars", "mars", "tx.proto"), []byte(testTxProto), 0o644))
+
+	resolver, err := cosmosreflect.NewProtoResolver(ctx, protoDir)
+	require.NoError(t, err)
+
+	var (
+		accountRetriever = mocks.NewAccountRetriever(t)
+		gasometer        = mocks.NewGasometer(t)
+	)
+
+	c, err := cosmosclient.New(
+		ctx,
+		cosmosclient.WithChainID("mychain"),
+		cosmosclient.WithKeyringBackend(cosmosaccount.KeyringTest),
+		cosmosclient.WithKeyringDir(t.TempDir()),
+		cosmosclient.WithGas(cosmosclient.GasAuto),
+		cosmosclient.WithAccountRetriever(accountRetriever),
+		cosmosclient.WithGasometer(gasometer),
+	)
+	require.NoError(t, err)
+
+	alice, _, err := c.AccountRegistry.Create("alice")
+	require.NoError(t, err)
+
+	addr, err := alice.Record.GetAddress()
+	require.NoError(t, err)
+
+	// The message fails in its module handler while the gas is estimated
+	accountRetriever.EXPECT().
+		EnsureExists(mock.Anything, addr).
+		Return(nil)
+	accountRetriever.EXPECT().
+		GetAccountNumberSequence(mock.Anything, addr).
+		Return(1, 2, nil)
+	gasometer.EXPECT().
+		CalculateGas(mock.Anything, mock.Anything, mock.Anything).
+		Return(nil, 0, status.Error(codes.Unknown, "post 1 already liked"))
+
+	script, err := cosmostxscript.Parse([]byte(`
+steps:
+  - name: create post twice
+    from: alice
+    module: mars
+    msg: create-post
+    args:
+      title: hello
+    expect:
+      fail: true
+      error: already liked
+`))
+	require.NoError(t, err)
+
+	cmd := &cobra.Command{}
+	cmd.Flags().AddFlagSet(flagSetAccountPrefixes())
+
+	session := cliui.New()
+	defer session.End()
+
+	exec := &nodeTxExecutor{
+		cmd:       cmd,
+		session:   session,
+		client:    c,
+		resolvers: []cosmosreflect.Resolver{resolver},
+		methods:   make(map[string]*desc.MethodDescriptor),
+	}
+
+	// Act
+	var results []cosmostxscript.StepResult
+	err = script.Run(ctx, exec, nil, func(r cosmostxscript.StepResult) {
+		results = append(results, r)
+	})
+
+	// Assert
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	require.NoError(t, results[0].Err)
+	require.ErrorAs(t, results[0].Result.Err, &cosmosclient.SimulationError{})
+}
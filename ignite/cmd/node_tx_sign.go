@@ -0,0 +1,168 @@
+package ignitecmd
+
+import (
+	"errors"
+	"os"
+
+	sdkclient "github.com/cosmos/cosmos-sdk/client"
+	"github.com/spf13/cobra"
+	flag "github.com/spf13/pflag"
+
+	"github.com/ignite/cli/ignite/pkg/cliui"
+	"github.com/ignite/cli/ignite/pkg/cosmosclient"
+)
+
+const (
+	flagOffline       = "offline"
+	flagChainID       = "chain-id"
+	flagAccountNumber = "account-number"
+	flagSequence      = "sequence"
+)
+
+func NewNodeTxSign() *cobra.Command {
+	c := &cobra.Command{
+		Use:   "sign [file]",
+		Short: "Sign a transaction created with --generate-only",
+		Long: `Sign a transaction created with --generate-only using the "--from" account
+and write the signed transaction to STDOUT.
+
+When "--multisig" is set, the transaction is signed on behalf of the multisig
+account and only the signature is written. The signatures of the keys of the
+multisig account are combined with "ignite node tx multisign".
+
+Use "--offline" to sign without a connection to the node, for example on an
+air-gapped machine. The chain ID, account number and sequence of the signer
+must then be set with flags. The messages of the app modules are decoded using
+the proto files of the app found in "--path".
+`,
+		Args: cobra.ExactArgs(1),
+		RunE: nodeTxSignHandler,
+	}
+
+	c.Flags().String(flagMultisig, "", "name or address of the multisig account to sign on behalf of")
+	c.Flags().AddFlagSet(flagSetOfflineSign())
+
+	return c
+}
+
+func nodeTxSignHandler(cmd *cobra.Command, args []string) error {
+	session := cliui.New()
+	defer session.End()
+
+	var (
+		from, _     = cmd.Flags().GetString(flagFrom)
+		multisig, _ = cmd.Flags().GetString(flagMultisig)
+	)
+
+	if from == "" {
+		return errors.New("the account that signs the transaction must be set with --from")
+	}
+
+	client, txBuilder, release, err := newNodeOfflineTx(cmd, args[0])
+	if err != nil {
+		return err
+	}
+	defer release()
+
+	account, err := client.Account(from)
+	if err != nil {
+		return err
+	}
+
+	options, err := getOfflineSignOptions(cmd)
+	if err != nil {
+		return err
+	}
+
+	if multisig != "" {
+		multisigAccount, err := client.Account(multisig)
+		if err != nil {
+			return err
+		}
+
+		addr, err := multisigAccount.Record.GetAddress()
+		if err != nil {
+			return err
+		}
+
+		options = append(options, cosmosclient.SignTxMultisig(addr))
+	}
+
+	if err := client.SignTx(account, txBuilder, options...); err != nil {
+		return err
+	}
+
+	var json []byte
+	if multisig != "" {
+		json, err = client.EncodeSignatureJSON(account, txBuilder)
+	} else {
+		json, err = client.EncodeTxJSON(txBuilder)
+	}
+	if err != nil {
+		return err
+	}
+
+	return session.Println(string(json))
+}
+
+// newNodeOfflineTx creates a client and decodes the transaction of the file.
+// The client doesn't request the chain ID to the node when signing offline.
+// The returned function must be called to release the resolvers of the messages.
+func newNodeOfflineTx(cmd *cobra.Command, file string) (cosmosclient.Client, sdkclient.TxBuilder, func(), error) {
+	bz, err := os.ReadFile(file)
+	if err != nil {
+		return cosmosclient.Client{}, nil, nil, err
+	}
+
+	var (
+		options    []cosmosclient.Option
+		offline, _ = cmd.Flags().GetBool(flagOffline)
+	)
+	if offline {
+		chainID, _ := cmd.Flags().GetString(flagChainID)
+		if chainID == "" {
+			return cosmosclient.Client{}, nil, nil, errors.New("the chain ID must be set with --chain-id when signing offline")
+		}
+
+		options = append(options, cosmosclient.WithChainID(chainID))
+	}
+
+	client, err := newNodeCosmosClient(cmd, options...)
+	if err != nil {
+		return cosmosclient.Client{}, nil, nil, err
+	}
+
+	decodeMsg, release := newNodeMsgDecoder(cmd, offline)
+
+	txBuilder, err := client.DecodeTxJSON(bz, cosmosclient.DecodeTxMsgs(decodeMsg))
+	if err != nil {
+		release()
+		return cosmosclient.Client{}, nil, nil, err
+	}
+
+	return client, txBuilder, release, nil
+}
+
+func flagSetOfflineSign() *flag.FlagSet {
+	fs := flag.NewFlagSet("", flag.ContinueOnError)
+	fs.Bool(flagOffline, false, "sign without a connection to the node")
+	fs.String(flagChainID, "", "chain ID of the node, required with --offline")
+	fs.Uint64(flagAccountNumber, 0, "account number of the signer, required with --offline")
+	fs.Uint64(flagSequence, 0, "sequence of the signer, required with --offline")
+	return fs
+}
+
+func getOfflineSignOptions(cmd *cobra.Command) ([]cosmosclient.SignTxOption, error) {
+	if offline, _ := cmd.Flags().GetBool(flagOffline); !offline {
+		return nil, nil
+	}
+
+	if !cmd.Flags().Changed(flagAccountNumber) || !cmd.Flags().Changed(flagSequence) {
+		return nil, errors.New("the account number and sequence must be set with --account-number and --sequence when signing offline")
+	}
+
+	accountNumber, _ := cmd.Flags().GetUint64(flagAccountNumber)
+	sequence, _ := cmd.Flags().GetUint64(flagSequence)
+
+	return []cosmosclient.SignTxOption{cosmosclient.SignTxOffline(accountNumber, sequence)}, nil
+}
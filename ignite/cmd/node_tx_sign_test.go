@@ -0,0 +1,219 @@
+package ignitecmd
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
+	sdktypes "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/tx/signing"
+	authsigning "github.com/cosmos/cosmos-sdk/x/auth/signing"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ignite/cli/ignite/pkg/cosmosaccount"
+	"github.com/ignite/cli/ignite/pkg/cosmosclient"
+	"github.com/ignite/cli/ignite/pkg/cosmosreflect"
+)
+
+const testTxProto = `syntax = "proto3";
+
+package mars.mars;
+
+option go_package = "mars/x/mars/types";
+
+service Msg {
+  rpc CreatePost(MsgCreatePost) returns (MsgCreatePostResponse);
+}
+
+message MsgCreatePost {
+  string creator = 1;
+  string title = 2;
+}
+
+message MsgCreatePostResponse {}
+`
+
+func TestNodeTxSignOffline(t *testing.T) {
+	// appPath contains the proto files of an app module which messages
+	// are not registered in the client codec.
+	appPath := t.TempDir()
+	protoDir := filepath.Join(appPath, "proto")
+	require.NoError(t, os.MkdirAll(filepath.Join(protoDir, "mars", "mars"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(protoDir, "mars", "mars", "tx.proto"), []byte(testTxProto), 0o644))
+
+	resolver, err := cosmosreflect.NewProtoResolver(context.Background(), protoDir)
+	require.NoError(t, err)
+
+	resolvers := []cosmosreflect.Resolver{resolver}
+	decodeMsg := func(typeURL string, bz []byte) (sdktypes.Msg, error) {
+		md, err := cosmosreflect.FindMsg(resolvers, typeURL[1:])
+		if err != nil {
+			return nil, err
+		}
+
+		m, err := cosmosreflect.NewMessage(md, string(bz))
+		if err != nil {
+			return nil, err
+		}
+
+		return cosmosreflect.Msg{Message: m}, nil
+	}
+
+	tests := []struct {
+		name string
+		msg  func(t *testing.T, addr sdktypes.AccAddress) sdktypes.Msg
+	}{
+		{
+			name: "registered message",
+			msg: func(t *testing.T, addr sdktypes.AccAddress) sdktypes.Msg {
+				return banktypes.NewMsgSend(addr, addr, sdktypes.NewCoins(sdktypes.NewInt64Coin("token", 1)))
+			},
+		},
+		{
+			name: "app module message",
+			msg: func(t *testing.T, addr sdktypes.AccAddress) sdktypes.Msg {
+				md, err := cosmosreflect.FindMsg(resolvers, "mars.mars.MsgCreatePost")
+				require.NoError(t, err)
+
+				msg, err := cosmosreflect.NewMsg(md, `{"title":"hello"}`, addr.String())
+				require.NoError(t, err)
+
+				return msg
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			var (
+				ctx        = context.Background()
+				keyringDir = t.TempDir()
+				txPath     = filepath.Join(t.TempDir(), "tx.json")
+				// Nothing listens on this address so any request to the node fails
+				node = "http://127.0.0.1:1"
+			)
+
+			c, err := cosmosclient.New(
+				ctx,
+				cosmosclient.WithChainID("mychain"),
+				cosmosclient.WithKeyringBackend(cosmosaccount.KeyringTest),
+				cosmosclient.WithKeyringDir(keyringDir),
+			)
+			require.NoError(t, err)
+
+			alice, _, err := c.AccountRegistry.Create("alice")
+			require.NoError(t, err)
+
+			addr, err := alice.Record.GetAddress()
+			require.NoError(t, err)
+
+			pk, err := alice.Record.GetPubKey()
+			require.NoError(t, err)
+
+			unsignedTx, err := c.TxFactory.BuildUnsignedTx(tt.msg(t, addr))
+			require.NoError(t, err)
+
+			txJSON, err := c.EncodeTxJSON(unsignedTx)
+			require.NoError(t, err)
+			require.NoError(t, os.WriteFile(txPath, txJSON, 0o644))
+
+			sign := func(extraArgs ...string) error {
+				cmd := NewNode()
+				cmd.SilenceUsage = true
+				cmd.SilenceErrors = true
+				cmd.SetArgs(append([]string{
+					"tx", "sign", txPath,
+					"--node", node,
+					"--from", "alice",
+					"--keyring-backend", string(cosmosaccount.KeyringTest),
+					"--keyring-dir", keyringDir,
+					"--path", appPath,
+				}, extraArgs...))
+
+				return cmd.ExecuteContext(ctx)
+			}
+
+			// Act
+			var signErr error
+			out := captureStdout(t, func() {
+				signErr = sign("--offline", "--chain-id", "mychain", "--account-number", "5", "--sequence", "3")
+			})
+
+			// Assert
+			require.NoError(t, signErr)
+
+			txBuilder, err := c.DecodeTxJSON(out, cosmosclient.DecodeTxMsgs(decodeMsg))
+			require.NoError(t, err)
+			require.Equal(t, encodeMsgs(t, unsignedTx.GetTx().GetMsgs()), encodeMsgs(t, txBuilder.GetTx().GetMsgs()))
+
+			sigs, err := txBuilder.GetTx().GetSignaturesV2()
+			require.NoError(t, err)
+			require.Len(t, sigs, 1)
+			require.True(t, pk.Equals(sigs[0].PubKey))
+			require.EqualValues(t, 3, sigs[0].Sequence)
+
+			signerData := authsigning.SignerData{
+				Address:       addr.String(),
+				ChainID:       "mychain",
+				AccountNumber: 5,
+				Sequence:      3,
+				PubKey:        pk,
+			}
+			handler := c.Context().TxConfig.SignModeHandler()
+			err = authsigning.VerifySignature(pk, signerData, sigs[0].Data, handler, txBuilder.GetTx())
+			require.NoError(t, err)
+
+			// The signature is only valid for the account number used to sign
+			signerData.AccountNumber = 6
+			err = authsigning.VerifySignature(pk, signerData, sigs[0].Data, handler, txBuilder.GetTx())
+			require.Error(t, err)
+			require.IsType(t, &signing.SingleSignatureData{}, sigs[0].Data)
+
+			// The node is required to sign without --offline
+			require.Error(t, sign())
+		})
+	}
+}
+
+// encodeMsgs returns the type URL and the proto encoding of each message.
+func encodeMsgs(t *testing.T, msgs []sdktypes.Msg) (encoded []string) {
+	t.Helper()
+
+	for _, msg := range msgs {
+		a, err := codectypes.NewAnyWithValue(msg)
+		require.NoError(t, err)
+
+		encoded = append(encoded, a.TypeUrl+":"+string(a.Value))
+	}
+
+	return encoded
+}
+
+// captureStdout returns what fn writes to the standard output.
+func captureStdout(t *testing.T, fn func()) []byte {
+	t.Helper()
+
+	r, w, err := os.Pipe()
+	require.NoError(t, err)
+
+	stdout := os.Stdout
+	os.Stdout = w
+	defer func() { os.Stdout = stdout }()
+
+	out := make(chan []byte)
+	go func() {
+		var b bytes.Buffer
+		_, _ = io.Copy(&b, r)
+		out <- b.Bytes()
+	}()
+
+	fn()
+	w.Close()
+
+	return <-out
+}
@@ -17,11 +17,13 @@ import (
 	"github.com/ignite/cli/ignite/pkg/cliui/icons"
 	"github.com/ignite/cli/ignite/pkg/cosmosanalysis"
 	"github.com/ignite/cli/ignite/pkg/xgit"
+	"github.com/ignite/cli/ignite/services/chain"
 	"github.com/ignite/cli/ignite/services/plugin"
 )
 
 const (
 	flagPluginsGlobal = "global"
+	flagPluginsLock   = "lock"
 )
 
 // plugins hold the list of plugin declared in the config.
@@ -56,7 +58,7 @@ func LoadPlugins(ctx context.Context, cmd *cobra.Command) error {
 	defer session.End()
 
 	uniquePlugins := pluginsconfig.RemoveDuplicates(pluginsConfigs)
-	plugins, err = plugin.Load(ctx, uniquePlugins, plugin.CollectEvents(session.EventBus()))
+	plugins, err = loadPlugins(ctx, uniquePlugins, plugin.CollectEvents(session.EventBus()))
 	if err != nil {
 		return err
 	}
@@ -64,7 +66,70 @@ func LoadPlugins(ctx context.Context, cmd *cobra.Command) error {
 		return nil
 	}
 
-	return linkPlugins(rootCmd, plugins)
+	// Plugins that don't match the lock are not linked when a plugin command
+	// is executed, so they can be updated and locked again.
+	linkedPlugins := plugins
+	if isPluginCmd(rootCmd, os.Args[1:]) {
+		linkedPlugins = nil
+		for _, p := range plugins {
+			if !errors.Is(p.Error, plugin.ErrLockMismatch) {
+				linkedPlugins = append(linkedPlugins, p)
+			}
+		}
+	}
+	return linkPlugins(rootCmd, linkedPlugins)
+}
+
+// loadPlugins loads the plugins verifying that they match the versions
+// recorded in the lock of the plugins config where they are declared.
+func loadPlugins(ctx context.Context, configs []pluginsconfig.Plugin, options ...plugin.Option) ([]*plugin.Plugin, error) {
+	var (
+		loaded []*plugin.Plugin
+		locks  = make(map[bool]*pluginsconfig.Lock)
+	)
+	for _, cp := range configs {
+		lock, ok := locks[cp.Global]
+		if !ok {
+			var err error
+			if lock, err = parsePluginsLock(cp.Global); err != nil {
+				return nil, err
+			}
+			locks[cp.Global] = lock
+		}
+		pp, err := plugin.Load(ctx, []pluginsconfig.Plugin{cp}, append(options, plugin.WithLock(lock))...)
+		if err != nil {
+			return nil, err
+		}
+		loaded = append(loaded, pp...)
+	}
+	return loaded, nil
+}
+
+// parsePluginsLock parses the lock of the global or the local plugins config.
+func parsePluginsLock(global bool) (*pluginsconfig.Lock, error) {
+	var (
+		dir string
+		err error
+	)
+	if global {
+		dir, err = plugin.PluginsPath()
+	} else {
+		dir, err = os.Getwd()
+	}
+	if err != nil {
+		return nil, fmt.Errorf("parse plugins lock: %w", err)
+	}
+	return pluginsconfig.ParseLockDir(dir)
+}
+
+// isPluginCmd returns true when the command that the root command
+// executes with args is one of the plugin commands.
+func isPluginCmd(rootCmd *cobra.Command, args []string) bool {
+	cmd, _, err := rootCmd.Find(args)
+	if err != nil {
+		return false
+	}
+	return strings.HasPrefix(cmd.CommandPath(), rootCmd.CommandPath()+" plugin ")
 }
 
 func parseLocalPlugins(cmd *cobra.Command) (*pluginsconfig.Config, error) {
@@ -185,6 +250,7 @@ func linkPluginHook(rootCmd *cobra.Command, p *plugin.Plugin, hook plugin.Hook)
 			},
 		}
 		execHook.ExecutedCommand.SetFlags(cmd)
+		execHook.ExecutedCommand.SetClientAPI(newPluginClientAPI(cmd))
 		return execHook
 	}
 
@@ -311,6 +377,7 @@ func linkPluginCmd(rootCmd *cobra.Command, p *plugin.Plugin, pluginCmd plugin.Co
 					With:   p.With,
 				}
 				execCmd.SetFlags(cmd)
+				execCmd.SetClientAPI(newPluginClientAPI(cmd))
 				// Call the plugin Execute
 				err := p.Interface.Execute(execCmd)
 				// NOTE(tb): This pause gives enough time for go-plugin to sync the
@@ -331,6 +398,23 @@ func linkPluginCmd(rootCmd *cobra.Command, p *plugin.Plugin, pluginCmd plugin.Co
 	}
 }
 
+// newPluginClientAPI creates the API that gives plugins access to the chain.
+// The chain is located using the path flag of the executed command when
+// defined, otherwise the working directory is used.
+func newPluginClientAPI(cmd *cobra.Command) plugin.ClientAPI {
+	return plugin.NewClientAPI(func() (plugin.Chain, error) {
+		var chainOption []chain.Option
+		if config := getConfig(cmd); config != "" {
+			chainOption = append(chainOption, chain.ConfigFile(config))
+		}
+		c, err := newChainWithHomeFlags(cmd, chainOption...)
+		if err != nil {
+			return nil, err
+		}
+		return c, nil
+	})
+}
+
 func findCommandByPath(cmd *cobra.Command, cmdPath string) *cobra.Command {
 	if cmd.CommandPath() == cmdPath {
 		return cmd
@@ -374,15 +458,31 @@ func NewPluginList() *cobra.Command {
 }
 
 func NewPluginUpdate() *cobra.Command {
-	return &cobra.Command{
+	c := &cobra.Command{
 		Use:   "update [path]",
 		Short: "Update plugins",
-		Long:  "Updates a plugin specified by path. If no path is specified all declared plugins are updated",
-		Args:  cobra.MaximumNArgs(1),
+		Long: `Updates a plugin specified by path. If no path is specified all declared plugins are updated.
+
+Remote plugins that are locked in the "plugins.lock" file must resolve to the
+locked commit. Use the "--lock" flag to update the plugins to the commit that
+their path resolves to now and to record it in the lock, together with the
+checksum of the plugin source files:
+
+	ignite plugin update --lock
+`,
+		Args: cobra.MaximumNArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
+			lock, _ := cmd.Flags().GetBool(flagPluginsLock)
+			update := func(plugins ...*plugin.Plugin) error {
+				if lock {
+					return plugin.UpdateLock(cmd.Context(), plugins...)
+				}
+				return plugin.Update(plugins...)
+			}
+
 			if len(args) == 0 {
 				// update all plugins
-				err := plugin.Update(plugins...)
+				err := update(plugins...)
 				if err != nil {
 					return err
 				}
@@ -392,7 +492,7 @@ func NewPluginUpdate() *cobra.Command {
 			// find the plugin to update
 			for _, p := range plugins {
 				if p.Path == args[0] {
-					err := plugin.Update(p)
+					err := update(p)
 					if err != nil {
 						return err
 					}
@@ -403,6 +503,10 @@ func NewPluginUpdate() *cobra.Command {
 			return errors.Errorf("Plugin %q not found", args[0])
 		},
 	}
+
+	c.Flags().Bool(flagPluginsLock, false, "record the resolved commit and source checksum of remote plugins in plugins.lock")
+
+	return c
 }
 
 func NewPluginAdd() *cobra.Command {
@@ -532,6 +636,17 @@ func NewPluginRemove() *cobra.Command {
 				return err
 			}
 
+			lock, err := parsePluginsLock(global)
+			if err != nil {
+				return err
+			}
+			if _, ok := lock.Get(args[0]); ok {
+				lock.Remove(args[0])
+				if err := lock.Save(); err != nil {
+					return err
+				}
+			}
+
 			s.Printf("%s %s removed\n", icons.OK, args[0])
 			s.Printf("\t%s updated\n", conf.Path())
 
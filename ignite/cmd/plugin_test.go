@@ -586,3 +586,45 @@ func execCmd(t *testing.T, c *cobra.Command, args []string) {
 		execCmd(t, c, args)
 	}
 }
+
+func TestIsPluginCmd(t *testing.T) {
+	rootCmd := buildRootCmd()
+	rootCmd.AddCommand(NewPlugin())
+
+	tests := []struct {
+		name     string
+		args     []string
+		expected bool
+	}{
+		{
+			name:     "plugin command",
+			args:     []string{"plugin", "update", "github.com/foo/bar"},
+			expected: true,
+		},
+		{
+			name:     "plugin command with flags",
+			args:     []string{"plugin", "list", "--help"},
+			expected: true,
+		},
+		{
+			name: "plugin group command",
+			args: []string{"plugin"},
+		},
+		{
+			name: "other command",
+			args: []string{"scaffold", "chain", "plugin"},
+		},
+		{
+			name: "unknown command",
+			args: []string{"foo"},
+		},
+		{
+			name: "no args",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.expected, isPluginCmd(rootCmd, tt.args))
+		})
+	}
+}
@@ -20,6 +20,7 @@ const (
 	flagNoSimulation = "no-simulation"
 	flagResponse     = "response"
 	flagDescription  = "desc"
+	flagDryRun       = "dry-run"
 
 	msgCommitPrefix = "Your saved project changes have not been committed.\nTo enable reverting to your current state, commit your saved changes."
 	msgCommitPrompt = "Do you want to proceed without committing your saved changes"
@@ -44,6 +45,9 @@ system before running scaffolding commands. This will make it easier to see the
 changes to the source code as well as undo the command if you've decided to roll
 back the changes.
 
+To preview the changes without modifying any file use the "--dry-run" flag. The
+changes are printed as a unified diff.
+
 This blockchain you create with the chain scaffolding command uses the modular
 Cosmos SDK framework and imports many standard modules for functionality like
 proof of stake, token transfer, inter-blockchain connectivity, governance, and
@@ -144,7 +148,7 @@ func scaffoldType(
 	session := cliui.New(cliui.StartSpinnerWithText(statusScaffolding))
 	defer session.End()
 
-	sc, err := scaffolder.New(appPath)
+	sc, err := scaffolder.New(appPath, scaffolderOptions(cmd)...)
 	if err != nil {
 		return err
 	}
@@ -159,6 +163,10 @@ func scaffoldType(
 		return err
 	}
 
+	if flagGetDryRun(cmd) {
+		return session.Print(sm.Diff())
+	}
+
 	modificationsStr, err := sourceModificationToString(sm)
 	if err != nil {
 		return err
@@ -171,8 +179,9 @@ func scaffoldType(
 }
 
 func gitChangesConfirmPreRunHandler(cmd *cobra.Command, _ []string) error {
-	// Don't confirm when the "--yes" flag is present
-	if getYes(cmd) {
+	// Don't confirm when the "--yes" flag is present or when the app is not
+	// modified because of the "--dry-run" flag
+	if getYes(cmd) || flagGetDryRun(cmd) {
 		return nil
 	}
 
@@ -232,3 +241,22 @@ func flagGetSigner(cmd *cobra.Command) string {
 	signer, _ := cmd.Flags().GetString(flagSigner)
 	return signer
 }
+
+func flagSetDryRun() *flag.FlagSet {
+	fs := flag.NewFlagSet("", flag.ContinueOnError)
+	fs.Bool(flagDryRun, false, "print the changes as a unified diff without modifying any file")
+	return fs
+}
+
+func flagGetDryRun(cmd *cobra.Command) bool {
+	dryRun, _ := cmd.Flags().GetBool(flagDryRun)
+	return dryRun
+}
+
+// scaffolderOptions returns the scaffolder options set by the command flags.
+func scaffolderOptions(cmd *cobra.Command) (options []scaffolder.Option) {
+	if flagGetDryRun(cmd) {
+		options = append(options, scaffolder.WithDryRun())
+	}
+	return options
+}
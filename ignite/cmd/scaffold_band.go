@@ -34,6 +34,7 @@ func NewScaffoldBandchain() *cobra.Command {
 	flagSetClearCache(c)
 
 	c.Flags().AddFlagSet(flagSetYes())
+	c.Flags().AddFlagSet(flagSetDryRun())
 	c.Flags().String(flagModule, "", "IBC module to add the packet into")
 	c.Flags().String(flagSigner, "", "label for the message signer (default: creator)")
 
@@ -68,7 +69,7 @@ func createBandchainHandler(cmd *cobra.Command, args []string) error {
 		options = append(options, scaffolder.OracleWithSigner(signer)) //nolint: staticcheck
 	}
 
-	sc, err := scaffolder.New(appPath)
+	sc, err := scaffolder.New(appPath, scaffolderOptions(cmd)...)
 	if err != nil {
 		return err
 	}
@@ -79,6 +80,10 @@ func createBandchainHandler(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
+	if flagGetDryRun(cmd) {
+		return session.Print(sm.Diff())
+	}
+
 	modificationsStr, err := sourceModificationToString(sm)
 	if err != nil {
 		return err
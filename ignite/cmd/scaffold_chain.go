@@ -78,6 +78,7 @@ about Cosmos SDK on https://docs.cosmos.network
 	c.Flags().StringP(flagPath, "p", "", "create a project in a specific path")
 	c.Flags().Bool(flagNoDefaultModule, false, "create a project without a default module")
 	c.Flags().Bool(flagSkipGit, false, "skip Git repository initialization")
+	c.Flags().AddFlagSet(flagSetDryRun())
 
 	return c
 }
@@ -94,6 +95,21 @@ func scaffoldChainHandler(cmd *cobra.Command, args []string) error {
 		skipGit, _         = cmd.Flags().GetBool(flagSkipGit)
 	)
 
+	if flagGetDryRun(cmd) {
+		sm, err := scaffolder.InitDryRun(
+			cmd.Context(),
+			placeholder.New(),
+			appPath,
+			name,
+			addressPrefix,
+			noDefaultModule,
+		)
+		if err != nil {
+			return err
+		}
+		return session.Print(sm.Diff())
+	}
+
 	cacheStorage, err := newCache(cmd)
 	if err != nil {
 		return err
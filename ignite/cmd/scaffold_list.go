@@ -50,8 +50,9 @@ business logic you will implement next.
 
 By default, all fields are assumed to be strings. If you want a field of a
 different type, you can specify it after a colon ":". The following types are
-supported: string, bool, int, uint, coin, array.string, array.int, array.uint,
-array.coin. An example of using field types:
+supported: string, bool, int, uint, coin, address, decimal, bytes, timestamp,
+enum, array.string, array.int, array.uint, array.coin. An example of using field
+types:
 
 	ignite scaffold list pool amount:coin tags:array.string height:int
 
@@ -68,6 +69,11 @@ Supported types:
 | array.uint   | uints   | no    | []uint64  | List of unsigned integers types |
 | coin         | -       | no    | sdk.Coin  | Cosmos SDK coin type            |
 | array.coin   | coins   | no    | sdk.Coins | List of Cosmos SDK coin types   |
+| address      | -       | no    | string    | Bech32 account address type     |
+| decimal      | -       | no    | sdk.Dec   | Cosmos SDK decimal type         |
+| bytes        | -       | no    | []byte    | Bytes type, hex encoded in CLI  |
+| timestamp    | -       | no    | time.Time | Timestamp type, RFC3339 in CLI  |
+| enum.<Name>  | -       | no    | <Name>    | Proto enum type                 |
 
 "Index" indicates whether the type can be used as an index in
 "ignite scaffold map".
@@ -81,6 +87,14 @@ In the example above the "ProductDetails" type was defined first, and then used
 as a custom type for the "details" field. Ignite doesn't support arrays of
 custom types yet.
 
+Enum fields reference an enum defined in the module proto files, in a file named
+after the enum, like custom types. The enum name follows the "enum." prefix:
+
+	ignite scaffold list order price:decimal status:enum.OrderStatus
+
+Address and enum fields are validated in the "ValidateBasic" method of the
+messages.
+
 Your chain will accept custom types in JSON-notation:
 
 	exampled tx example create-product 100coin '{"name": "x", "desc": "y"}' --from alice
@@ -115,6 +129,7 @@ The "creator" field is not generated if a list is scaffolded with the
 	flagSetClearCache(c)
 
 	c.Flags().AddFlagSet(flagSetYes())
+	c.Flags().AddFlagSet(flagSetDryRun())
 	c.Flags().AddFlagSet(flagSetScaffoldType())
 
 	return c
@@ -68,6 +68,7 @@ for details.
 	flagSetClearCache(c)
 
 	c.Flags().AddFlagSet(flagSetYes())
+	c.Flags().AddFlagSet(flagSetDryRun())
 	c.Flags().String(flagModule, "", "module to add the message into. Default: app's main module")
 	c.Flags().StringSliceP(flagResponse, "r", []string{}, "response fields")
 	c.Flags().Bool(flagNoSimulation, false, "disable CRUD simulation scaffolding")
@@ -112,7 +113,7 @@ func messageHandler(cmd *cobra.Command, args []string) error {
 		options = append(options, scaffolder.WithoutSimulation())
 	}
 
-	sc, err := scaffolder.New(appPath)
+	sc, err := scaffolder.New(appPath, scaffolderOptions(cmd)...)
 	if err != nil {
 		return err
 	}
@@ -122,6 +123,10 @@ func messageHandler(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
+	if flagGetDryRun(cmd) {
+		return session.Print(sm.Diff())
+	}
+
 	modificationsStr, err := sourceModificationToString(sm)
 	if err != nil {
 		return err
@@ -104,6 +104,7 @@ params.
 	flagSetClearCache(c)
 
 	c.Flags().AddFlagSet(flagSetYes())
+	c.Flags().AddFlagSet(flagSetDryRun())
 	c.Flags().StringSlice(flagDep, []string{}, "add a dependency on another module")
 	c.Flags().Bool(flagIBC, false, "add IBC functionality")
 	c.Flags().String(flagIBCOrdering, "none", "channel ordering of the IBC module [none|ordered|unordered]")
@@ -179,20 +180,37 @@ func scaffoldModuleHandler(cmd *cobra.Command, args []string) error {
 	var msg bytes.Buffer
 	fmt.Fprintf(&msg, "\n🎉 Module created %s.\n\n", name)
 
-	sc, err := scaffolder.New(appPath)
+	sc, err := scaffolder.New(appPath, scaffolderOptions(cmd)...)
 	if err != nil {
 		return err
 	}
 
+	var validationInfo string
 	sm, err := sc.CreateModule(cmd.Context(), cacheStorage, placeholder.New(), name, options...)
 	if err != nil {
 		var validationErr validation.Error
-		if !requireRegistration && errors.As(err, &validationErr) {
-			fmt.Fprintf(&msg, "Can't register module '%s'.\n", name)
-			fmt.Fprintln(&msg, validationErr.ValidationInfo())
-		} else {
+		if requireRegistration || !errors.As(err, &validationErr) {
 			return err
 		}
+
+		validationInfo = fmt.Sprintf("Can't register module '%s'.\n%s\n", name, validationErr.ValidationInfo())
+	}
+
+	// Print the planned changes even when the module can't be registered
+	if flagGetDryRun(cmd) {
+		if err := session.Print(sm.Diff()); err != nil {
+			return err
+		}
+
+		if validationInfo != "" {
+			return session.Print(validationInfo)
+		}
+
+		return nil
+	}
+
+	if validationInfo != "" {
+		msg.WriteString(validationInfo)
 	} else {
 		modificationsStr, err := sourceModificationToString(sm)
 		if err != nil {
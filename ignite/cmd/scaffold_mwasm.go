@@ -18,6 +18,7 @@ func NewScaffoldWasm() *cobra.Command {
 	}
 
 	flagSetPath(c)
+	c.Flags().AddFlagSet(flagSetDryRun())
 
 	return c
 }
@@ -33,7 +34,7 @@ func scaffoldWasmHandler(cmd *cobra.Command, _ []string) error {
 		return err
 	}
 
-	sc, err := scaffolder.New(appPath)
+	sc, err := scaffolder.New(appPath, scaffolderOptions(cmd)...)
 	if err != nil {
 		return err
 	}
@@ -43,6 +44,10 @@ func scaffoldWasmHandler(cmd *cobra.Command, _ []string) error {
 		return err
 	}
 
+	if flagGetDryRun(cmd) {
+		return session.Print(sm.Diff())
+	}
+
 	modificationsStr, err := sourceModificationToString(sm)
 	if err != nil {
 		return err
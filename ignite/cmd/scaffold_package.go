@@ -29,6 +29,7 @@ func NewScaffoldPacket() *cobra.Command {
 	flagSetClearCache(c)
 
 	c.Flags().AddFlagSet(flagSetYes())
+	c.Flags().AddFlagSet(flagSetDryRun())
 	c.Flags().StringSlice(flagAck, []string{}, "custom acknowledgment type (field1,field2,...)")
 	c.Flags().String(flagModule, "", "IBC Module to add the packet into")
 	c.Flags().String(flagSigner, "", "label for the message signer (default: creator)")
@@ -78,7 +79,7 @@ func createPacketHandler(cmd *cobra.Command, args []string) error {
 		options = append(options, scaffolder.PacketWithSigner(signer))
 	}
 
-	sc, err := scaffolder.New(appPath)
+	sc, err := scaffolder.New(appPath, scaffolderOptions(cmd)...)
 	if err != nil {
 		return err
 	}
@@ -88,6 +89,10 @@ func createPacketHandler(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
+	if flagGetDryRun(cmd) {
+		return session.Print(sm.Diff())
+	}
+
 	modificationsStr, err := sourceModificationToString(sm)
 	if err != nil {
 		return err
@@ -19,6 +19,7 @@ func NewScaffoldReact() *cobra.Command {
 	}
 
 	c.Flags().AddFlagSet(flagSetYes())
+	c.Flags().AddFlagSet(flagSetDryRun())
 	c.Flags().StringP(flagPath, "p", "./"+chainconfig.DefaultReactPath, "path to scaffold content of the React app")
 
 	return c
@@ -29,6 +30,14 @@ func scaffoldReactHandler(cmd *cobra.Command, _ []string) error {
 	defer session.End()
 
 	path := flagGetPath(cmd)
+	if flagGetDryRun(cmd) {
+		sm, err := cosmosgen.ReactDryRun(cmd.Context(), path)
+		if err != nil {
+			return err
+		}
+		return session.Print(sm.Diff())
+	}
+
 	if err := cosmosgen.React(path); err != nil {
 		return err
 	}
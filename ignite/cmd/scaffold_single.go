@@ -20,6 +20,7 @@ func NewScaffoldSingle() *cobra.Command {
 	flagSetClearCache(c)
 
 	c.Flags().AddFlagSet(flagSetYes())
+	c.Flags().AddFlagSet(flagSetDryRun())
 	c.Flags().AddFlagSet(flagSetScaffoldType())
 
 	return c
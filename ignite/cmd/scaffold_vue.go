@@ -19,6 +19,7 @@ func NewScaffoldVue() *cobra.Command {
 	}
 
 	c.Flags().AddFlagSet(flagSetYes())
+	c.Flags().AddFlagSet(flagSetDryRun())
 	c.Flags().StringP(flagPath, "p", "./"+chainconfig.DefaultVuePath, "path to scaffold content of the Vue.js app")
 
 	return c
@@ -29,6 +30,14 @@ func scaffoldVueHandler(cmd *cobra.Command, _ []string) error {
 	defer session.End()
 
 	path := flagGetPath(cmd)
+	if flagGetDryRun(cmd) {
+		sm, err := cosmosgen.VueDryRun(cmd.Context(), path)
+		if err != nil {
+			return err
+		}
+		return session.Print(sm.Diff())
+	}
+
 	if err := cosmosgen.Vue(path); err != nil {
 		return err
 	}
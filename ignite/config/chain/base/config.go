@@ -122,6 +122,10 @@ type Faucet struct {
 	// LimitRefreshTime sets the timeframe at the end of which the limit will be refreshed
 	RateLimitWindow string `yaml:"rate_limit_window,omitempty"`
 
+	// ChallengeDifficulty enables a proof-of-work challenge that clients must solve
+	// before requesting tokens. It is the number of leading zero bits of the solution's hash.
+	ChallengeDifficulty uint `yaml:"challenge_difficulty,omitempty"`
+
 	// Host is the host of the faucet server
 	Host string `yaml:"host,omitempty"`
 
@@ -129,6 +133,130 @@ type Faucet struct {
 	Port int `yaml:"port,omitempty"`
 }
 
+// GenesisPatches defines changes to apply to the genesis of the chain.
+type GenesisPatches struct {
+	// Operations are JSON patch operations (RFC 6902) applied to the genesis.
+	Operations []GenesisPatchOperation `yaml:"operations,omitempty"`
+
+	// Bank changes the genesis state of the bank module.
+	Bank BankPatch `yaml:"bank,omitempty"`
+
+	// Gov changes the params of the gov module.
+	Gov GovPatch `yaml:"gov,omitempty"`
+
+	// Staking changes the params of the staking module.
+	Staking StakingPatch `yaml:"staking,omitempty"`
+}
+
+// GenesisPatchOperation is a JSON patch operation applied to the genesis.
+type GenesisPatchOperation struct {
+	// Op is the operation name: add, remove, replace, move, copy or test.
+	Op string `yaml:"op"`
+
+	// Path is the JSON pointer of the genesis location to change.
+	Path string `yaml:"path"`
+
+	// From is the JSON pointer of the genesis location to move or copy.
+	From string `yaml:"from,omitempty"`
+
+	// Value is the value to add, replace or test.
+	Value interface{} `yaml:"value,omitempty"`
+}
+
+func (o *GenesisPatchOperation) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	type operation GenesisPatchOperation
+
+	var op operation
+	if err := unmarshal(&op); err != nil {
+		return err
+	}
+
+	// Make sure the value can be encoded as JSON
+	op.Value = xyaml.ConvertValue(op.Value)
+	*o = GenesisPatchOperation(op)
+
+	return nil
+}
+
+// BankPatch changes the genesis state of the bank module.
+type BankPatch struct {
+	// DenomMetadata is added to the metadata of the genesis denoms.
+	DenomMetadata []DenomMetadata `yaml:"denom_metadata,omitempty"`
+}
+
+// DenomMetadata describes a denom of the chain.
+type DenomMetadata struct {
+	Description string      `yaml:"description,omitempty"`
+	Base        string      `yaml:"base"`
+	Display     string      `yaml:"display"`
+	Name        string      `yaml:"name"`
+	Symbol      string      `yaml:"symbol"`
+	URI         string      `yaml:"uri,omitempty"`
+	DenomUnits  []DenomUnit `yaml:"denom_units"`
+}
+
+// DenomUnit is a unit of a denom with its exponent relative to the base unit.
+type DenomUnit struct {
+	Denom    string   `yaml:"denom"`
+	Exponent uint32   `yaml:"exponent"`
+	Aliases  []string `yaml:"aliases,omitempty"`
+}
+
+// GovPatch changes the params of the gov module.
+type GovPatch struct {
+	// MinDeposit is the minimum deposit of a proposal, for example "10000000stake".
+	MinDeposit []string `yaml:"min_deposit,omitempty"`
+
+	// MaxDepositPeriod is the maximum period to reach the minimum deposit, for example "60s".
+	MaxDepositPeriod string `yaml:"max_deposit_period,omitempty"`
+
+	// VotingPeriod is the duration of the voting period, for example "60s".
+	VotingPeriod string `yaml:"voting_period,omitempty"`
+
+	// Quorum is the minimum percentage of the voting power that needs to vote.
+	Quorum string `yaml:"quorum,omitempty"`
+
+	// Threshold is the minimum proportion of yes votes to pass a proposal.
+	Threshold string `yaml:"threshold,omitempty"`
+
+	// VetoThreshold is the minimum proportion of veto votes to reject a proposal.
+	VetoThreshold string `yaml:"veto_threshold,omitempty"`
+}
+
+// StakingPatch changes the params of the staking module.
+type StakingPatch struct {
+	// BondDenom is the denom of the staking token.
+	BondDenom string `yaml:"bond_denom,omitempty"`
+
+	// UnbondingTime is the duration of the unbonding, for example "60s".
+	UnbondingTime string `yaml:"unbonding_time,omitempty"`
+
+	// MaxValidators is the maximum number of validators.
+	MaxValidators uint32 `yaml:"max_validators,omitempty"`
+}
+
+// Start configures the command that starts the nodes of the chain.
+type Start struct {
+	// Flags are additional flags of the start command, for example "--trace".
+	Flags []string `yaml:"flags,omitempty"`
+
+	// Env are environment variables set for the start command, for example "GODEBUG".
+	Env map[string]string `yaml:"env,omitempty"`
+}
+
+// NodeHooks defines shell commands executed while the chain is initialized and started.
+// Commands are Go templates rendered with the chain info, for example "{{.Home}}".
+type NodeHooks struct {
+	// PostInit commands are executed after the chain is initialized.
+	PostInit []string `yaml:"post_init,omitempty"`
+
+	// PreStart commands are executed before the nodes are started.
+	PreStart []string `yaml:"pre_start,omitempty"`
+
+	// PostStart commands are executed once the first block is committed.
+	PostStart []string `yaml:"post_start,omitempty"`
+}
+
 // Init overwrites sdk configurations with given values.
 type Init struct {
 	// App overwrites appd's config/app.toml configs.
@@ -162,6 +290,16 @@ type Config struct {
 	Faucet   Faucet          `yaml:"faucet,omitempty"`
 	Client   Client          `yaml:"client,omitempty"`
 	Genesis  xyaml.Map       `yaml:"genesis,omitempty"`
+
+	// GenesisPatches defines changes applied to the genesis after the
+	// values of Genesis are merged.
+	GenesisPatches GenesisPatches `yaml:"genesis_patches,omitempty"`
+
+	// Start configures the command that starts the nodes.
+	Start Start `yaml:"start,omitempty"`
+
+	// Hooks are commands executed after init and before or after the nodes start.
+	Hooks NodeHooks `yaml:"hooks,omitempty"`
 }
 
 // GetVersion returns the config version.
@@ -0,0 +1,262 @@
+package chain
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+
+	"github.com/ignite/cli/ignite/config/chain/base"
+	"github.com/ignite/cli/ignite/pkg/jsonpatch"
+)
+
+const (
+	genesisPatchesField = "genesis_patches"
+
+	bankGenesisPath    = "/app_state/bank"
+	govParamsPath      = "/app_state/gov/params"
+	stakingParamsPath  = "/app_state/staking/params"
+	denomMetadataField = "denom_metadata"
+)
+
+// GenesisPatch is a JSON patch operation to apply to the genesis
+// together with the config field that defines it.
+type GenesisPatch struct {
+	jsonpatch.Operation
+
+	// Field is the path of the config field that defines the patch,
+	// for example "genesis_patches.operations[0]".
+	Field string
+}
+
+// GenesisPatches returns the JSON patch operations to apply to the genesis for
+// the genesis patches defined in the config.
+// The operations of the module helpers are returned first so they can be
+// refined by the operations defined in the config.
+func GenesisPatches(c *Config) ([]GenesisPatch, error) {
+	var (
+		p       = c.GenesisPatches
+		patches []GenesisPatch
+	)
+
+	for i, m := range p.Bank.DenomMetadata {
+		field := fmt.Sprintf("%s.bank.denom_metadata[%d]", genesisPatchesField, i)
+
+		metadata, err := denomMetadata(m)
+		if err != nil {
+			return nil, &ValidationError{fmt.Sprintf("%s: %s", field, err)}
+		}
+
+		patches = append(patches, GenesisPatch{
+			Field: field,
+			Operation: jsonpatch.Operation{
+				Op:    jsonpatch.OpAdd,
+				Path:  fmt.Sprintf("%s/%s/-", bankGenesisPath, denomMetadataField),
+				Value: metadata,
+			},
+		})
+	}
+
+	govPatches, err := govParamsPatches(p.Gov)
+	if err != nil {
+		return nil, err
+	}
+
+	stakingPatches, err := stakingParamsPatches(p.Staking)
+	if err != nil {
+		return nil, err
+	}
+
+	patches = append(patches, govPatches...)
+	patches = append(patches, stakingPatches...)
+
+	for i, o := range p.Operations {
+		field := fmt.Sprintf("%s.operations[%d]", genesisPatchesField, i)
+		op := jsonpatch.Operation{
+			Op:    o.Op,
+			Path:  o.Path,
+			From:  o.From,
+			Value: o.Value,
+		}
+
+		if err := op.Validate(); err != nil {
+			return nil, &ValidationError{fmt.Sprintf("%s: %s", field, err)}
+		}
+
+		patches = append(patches, GenesisPatch{Field: field, Operation: op})
+	}
+
+	return patches, nil
+}
+
+func denomMetadata(m base.DenomMetadata) (map[string]interface{}, error) {
+	metadata := banktypes.Metadata{
+		Description: m.Description,
+		Base:        m.Base,
+		Display:     m.Display,
+		Name:        m.Name,
+		Symbol:      m.Symbol,
+		URI:         m.URI,
+	}
+
+	units := make([]interface{}, len(m.DenomUnits))
+	for i, u := range m.DenomUnits {
+		metadata.DenomUnits = append(metadata.DenomUnits, &banktypes.DenomUnit{
+			Denom:    u.Denom,
+			Exponent: u.Exponent,
+			Aliases:  u.Aliases,
+		})
+
+		aliases := make([]interface{}, len(u.Aliases))
+		for j, a := range u.Aliases {
+			aliases[j] = a
+		}
+
+		units[i] = map[string]interface{}{
+			"denom":    u.Denom,
+			"exponent": u.Exponent,
+			"aliases":  aliases,
+		}
+	}
+
+	if err := metadata.Validate(); err != nil {
+		return nil, err
+	}
+
+	return map[string]interface{}{
+		"description": m.Description,
+		"denom_units": units,
+		"base":        m.Base,
+		"display":     m.Display,
+		"name":        m.Name,
+		"symbol":      m.Symbol,
+		"uri":         m.URI,
+		"uri_hash":    "",
+	}, nil
+}
+
+func govParamsPatches(p base.GovPatch) ([]GenesisPatch, error) {
+	var patches []GenesisPatch
+
+	add := func(name string, value interface{}) {
+		patches = append(patches, GenesisPatch{
+			Field: fmt.Sprintf("%s.gov.%s", genesisPatchesField, name),
+			Operation: jsonpatch.Operation{
+				Op:    jsonpatch.OpReplace,
+				Path:  fmt.Sprintf("%s/%s", govParamsPath, name),
+				Value: value,
+			},
+		})
+	}
+
+	if len(p.MinDeposit) > 0 {
+		coins, err := sdk.ParseCoinsNormalized(strings.Join(p.MinDeposit, ","))
+		if err != nil {
+			return nil, &ValidationError{fmt.Sprintf("%s.gov.min_deposit: %s", genesisPatchesField, err)}
+		}
+
+		value := make([]interface{}, len(coins))
+		for i, c := range coins {
+			value[i] = map[string]interface{}{"denom": c.Denom, "amount": c.Amount.String()}
+		}
+
+		add("min_deposit", value)
+	}
+
+	durations := []struct{ name, value string }{
+		{"max_deposit_period", p.MaxDepositPeriod},
+		{"voting_period", p.VotingPeriod},
+	}
+	for _, d := range durations {
+		if d.value == "" {
+			continue
+		}
+
+		value, err := durationJSON(d.value)
+		if err != nil {
+			return nil, &ValidationError{fmt.Sprintf("%s.gov.%s: %s", genesisPatchesField, d.name, err)}
+		}
+
+		add(d.name, value)
+	}
+
+	decs := []struct{ name, value string }{
+		{"quorum", p.Quorum},
+		{"threshold", p.Threshold},
+		{"veto_threshold", p.VetoThreshold},
+	}
+	for _, d := range decs {
+		if d.value == "" {
+			continue
+		}
+
+		value, err := sdk.NewDecFromStr(d.value)
+		if err != nil || value.IsNegative() || value.GT(sdk.OneDec()) {
+			return nil, &ValidationError{fmt.Sprintf(
+				"%s.gov.%s: %q must be a decimal between 0 and 1",
+				genesisPatchesField,
+				d.name,
+				d.value,
+			)}
+		}
+
+		add(d.name, value.String())
+	}
+
+	return patches, nil
+}
+
+func stakingParamsPatches(p base.StakingPatch) ([]GenesisPatch, error) {
+	var patches []GenesisPatch
+
+	add := func(name string, value interface{}) {
+		patches = append(patches, GenesisPatch{
+			Field: fmt.Sprintf("%s.staking.%s", genesisPatchesField, name),
+			Operation: jsonpatch.Operation{
+				Op:    jsonpatch.OpReplace,
+				Path:  fmt.Sprintf("%s/%s", stakingParamsPath, name),
+				Value: value,
+			},
+		})
+	}
+
+	if p.BondDenom != "" {
+		if err := sdk.ValidateDenom(p.BondDenom); err != nil {
+			return nil, &ValidationError{fmt.Sprintf("%s.staking.bond_denom: %s", genesisPatchesField, err)}
+		}
+
+		add("bond_denom", p.BondDenom)
+	}
+
+	if p.UnbondingTime != "" {
+		value, err := durationJSON(p.UnbondingTime)
+		if err != nil {
+			return nil, &ValidationError{fmt.Sprintf("%s.staking.unbonding_time: %s", genesisPatchesField, err)}
+		}
+
+		add("unbonding_time", value)
+	}
+
+	if p.MaxValidators > 0 {
+		add("max_validators", p.MaxValidators)
+	}
+
+	return patches, nil
+}
+
+// durationJSON returns the JSON representation of a protobuf duration.
+func durationJSON(s string) (string, error) {
+	d, err := time.ParseDuration(s)
+	if err != nil {
+		return "", err
+	}
+
+	if d <= 0 {
+		return "", fmt.Errorf("duration %s must be positive", s)
+	}
+
+	return strconv.FormatFloat(d.Seconds(), 'f', -1, 64) + "s", nil
+}
@@ -0,0 +1,200 @@
+package chain_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	chainconfig "github.com/ignite/cli/ignite/config/chain"
+	"github.com/ignite/cli/ignite/pkg/jsonpatch"
+)
+
+const genesisPatchesConfig = `
+version: 1
+accounts:
+  - name: alice
+    coins: ["100000000stake"]
+validators:
+  - name: alice
+    bonded: 100000000stake
+genesis_patches:
+`
+
+func TestGenesisPatches(t *testing.T) {
+	// Arrange
+	r := strings.NewReader(genesisPatchesConfig + `
+  bank:
+    denom_metadata:
+      - base: uatom
+        display: atom
+        name: Atom
+        symbol: ATOM
+        denom_units:
+          - denom: uatom
+            exponent: 0
+          - denom: atom
+            exponent: 6
+  gov:
+    min_deposit: ["10stake"]
+    voting_period: 1m
+    quorum: "0.2"
+  staking:
+    unbonding_time: 90s
+    max_validators: 10
+  operations:
+    - op: add
+      path: /app_state/crisis/constant_fee
+      value:
+        denom: stake
+        amount: "1000"
+    - op: remove
+      path: /app_state/bank/denom_metadata/0
+`)
+	cfg, err := chainconfig.Parse(r)
+	require.NoError(t, err)
+
+	// Act
+	patches, err := chainconfig.GenesisPatches(cfg)
+
+	// Assert
+	require.NoError(t, err)
+	require.Equal(t, []chainconfig.GenesisPatch{
+		{
+			Field: "genesis_patches.bank.denom_metadata[0]",
+			Operation: jsonpatch.Operation{
+				Op:   jsonpatch.OpAdd,
+				Path: "/app_state/bank/denom_metadata/-",
+				Value: map[string]interface{}{
+					"description": "",
+					"denom_units": []interface{}{
+						map[string]interface{}{"denom": "uatom", "exponent": uint32(0), "aliases": []interface{}{}},
+						map[string]interface{}{"denom": "atom", "exponent": uint32(6), "aliases": []interface{}{}},
+					},
+					"base":     "uatom",
+					"display":  "atom",
+					"name":     "Atom",
+					"symbol":   "ATOM",
+					"uri":      "",
+					"uri_hash": "",
+				},
+			},
+		},
+		{
+			Field: "genesis_patches.gov.min_deposit",
+			Operation: jsonpatch.Operation{
+				Op:    jsonpatch.OpReplace,
+				Path:  "/app_state/gov/params/min_deposit",
+				Value: []interface{}{map[string]interface{}{"denom": "stake", "amount": "10"}},
+			},
+		},
+		{
+			Field: "genesis_patches.gov.voting_period",
+			Operation: jsonpatch.Operation{
+				Op:    jsonpatch.OpReplace,
+				Path:  "/app_state/gov/params/voting_period",
+				Value: "60s",
+			},
+		},
+		{
+			Field: "genesis_patches.gov.quorum",
+			Operation: jsonpatch.Operation{
+				Op:    jsonpatch.OpReplace,
+				Path:  "/app_state/gov/params/quorum",
+				Value: "0.200000000000000000",
+			},
+		},
+		{
+			Field: "genesis_patches.staking.unbonding_time",
+			Operation: jsonpatch.Operation{
+				Op:    jsonpatch.OpReplace,
+				Path:  "/app_state/staking/params/unbonding_time",
+				Value: "90s",
+			},
+		},
+		{
+			Field: "genesis_patches.staking.max_validators",
+			Operation: jsonpatch.Operation{
+				Op:    jsonpatch.OpReplace,
+				Path:  "/app_state/staking/params/max_validators",
+				Value: uint32(10),
+			},
+		},
+		{
+			Field: "genesis_patches.operations[0]",
+			Operation: jsonpatch.Operation{
+				Op:    jsonpatch.OpAdd,
+				Path:  "/app_state/crisis/constant_fee",
+				Value: map[string]interface{}{"denom": "stake", "amount": "1000"},
+			},
+		},
+		{
+			Field: "genesis_patches.operations[1]",
+			Operation: jsonpatch.Operation{
+				Op:   jsonpatch.OpRemove,
+				Path: "/app_state/bank/denom_metadata/0",
+			},
+		},
+	}, patches)
+}
+
+func TestParseWithInvalidGenesisPatches(t *testing.T) {
+	cases := []struct {
+		name    string
+		patches string
+		err     string
+	}{
+		{
+			name: "invalid denom metadata",
+			patches: `
+  bank:
+    denom_metadata:
+      - base: uatom
+        display: atom
+        name: Atom
+        symbol: ATOM
+        denom_units:
+          - denom: uatom
+            exponent: 0`,
+			err: "config is not valid: genesis_patches.bank.denom_metadata[0]: metadata must contain a denomination unit with display denom 'atom'",
+		},
+		{
+			name: "invalid duration",
+			patches: `
+  gov:
+    voting_period: 2 days`,
+			err: `config is not valid: genesis_patches.gov.voting_period: time: unknown unit " days" in duration "2 days"`,
+		},
+		{
+			name: "invalid decimal",
+			patches: `
+  gov:
+    threshold: "1.5"`,
+			err: `config is not valid: genesis_patches.gov.threshold: "1.5" must be a decimal between 0 and 1`,
+		},
+		{
+			name: "unknown operation",
+			patches: `
+  operations:
+    - op: append
+      path: /app_state/bank/denom_metadata`,
+			err: `config is not valid: genesis_patches.operations[0]: unknown op "append"`,
+		},
+		{
+			name: "invalid operation path",
+			patches: `
+  operations:
+    - op: remove
+      path: app_state/bank`,
+			err: `config is not valid: genesis_patches.operations[0]: invalid path: "app_state/bank" must start with '/'`,
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := chainconfig.Parse(strings.NewReader(genesisPatchesConfig + tt.patches))
+
+			require.EqualError(t, err, tt.err)
+		})
+	}
+}
@@ -0,0 +1,103 @@
+package chain
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"text/template"
+)
+
+const (
+	hooksField = "hooks"
+
+	// HookPostInit is the name of the hooks executed after the chain is initialized.
+	HookPostInit = "post_init"
+
+	// HookPreStart is the name of the hooks executed before the nodes are started.
+	HookPreStart = "pre_start"
+
+	// HookPostStart is the name of the hooks executed once the first block is committed.
+	HookPostStart = "post_start"
+)
+
+// Hook is a command executed while the chain is initialized or started.
+type Hook struct {
+	// Field is the path of the config field that defines the hook,
+	// for example "hooks.pre_start[0]".
+	Field string
+
+	// Command is the template of the shell command.
+	Command *template.Template
+}
+
+// Hooks returns the hooks with the given name defined in the config,
+// for example HookPreStart.
+func Hooks(c *Config, name string) ([]Hook, error) {
+	var commands []string
+	switch name {
+	case HookPostInit:
+		commands = c.Hooks.PostInit
+	case HookPreStart:
+		commands = c.Hooks.PreStart
+	case HookPostStart:
+		commands = c.Hooks.PostStart
+	default:
+		return nil, fmt.Errorf("unknown hook: %s", name)
+	}
+
+	hooks := make([]Hook, len(commands))
+	for i, command := range commands {
+		field := fmt.Sprintf("%s.%s[%d]", hooksField, name, i)
+		if strings.TrimSpace(command) == "" {
+			return nil, &ValidationError{fmt.Sprintf("%s: command is empty", field)}
+		}
+
+		tpl, err := template.New(field).Option("missingkey=error").Parse(command)
+		if err != nil {
+			return nil, &ValidationError{fmt.Sprintf("%s: %s", field, err)}
+		}
+
+		hooks[i] = Hook{Field: field, Command: tpl}
+	}
+
+	return hooks, nil
+}
+
+// StartEnv returns the environment variables of the start command
+// as "KEY=value" pairs sorted by key.
+func StartEnv(c *Config) []string {
+	env := make([]string, 0, len(c.Start.Env))
+	for k, v := range c.Start.Env {
+		env = append(env, k+"="+v)
+	}
+
+	sort.Strings(env)
+
+	return env
+}
+
+func validateStart(c *Config) error {
+	for i, flag := range c.Start.Flags {
+		if !strings.HasPrefix(flag, "-") {
+			return &ValidationError{fmt.Sprintf("start.flags[%d]: '%s' is not a flag", i, flag)}
+		}
+	}
+
+	for k := range c.Start.Env {
+		if k == "" || strings.ContainsAny(k, "= ") {
+			return &ValidationError{fmt.Sprintf("start.env: '%s' is not a valid variable name", k)}
+		}
+	}
+
+	return nil
+}
+
+func validateHooks(c *Config) error {
+	for _, name := range []string{HookPostInit, HookPreStart, HookPostStart} {
+		if _, err := Hooks(c, name); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,105 @@
+package chain_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	chainconfig "github.com/ignite/cli/ignite/config/chain"
+)
+
+const hooksConfig = `
+version: 1
+accounts:
+  - name: alice
+    coins: ["100000000stake"]
+validators:
+  - name: alice
+    bonded: 100000000stake
+`
+
+func TestHooks(t *testing.T) {
+	// Arrange
+	cfg, err := chainconfig.Parse(strings.NewReader(hooksConfig + `
+start:
+  flags: ["--trace", "--inv-check-period=1"]
+  env:
+    GODEBUG: gctrace=1
+    FOO: bar
+hooks:
+  post_init:
+    - echo init
+  pre_start:
+    - cp genesis.json {{.Home}}/config/genesis.json
+`))
+	require.NoError(t, err)
+
+	// Act
+	hooks, err := chainconfig.Hooks(cfg, chainconfig.HookPreStart)
+	require.NoError(t, err)
+
+	postStart, err := chainconfig.Hooks(cfg, chainconfig.HookPostStart)
+	require.NoError(t, err)
+
+	var cmd bytes.Buffer
+	err = hooks[0].Command.Execute(&cmd, struct{ Home string }{"/mars"})
+
+	// Assert
+	require.NoError(t, err)
+	require.Equal(t, []string{"--trace", "--inv-check-period=1"}, cfg.Start.Flags)
+	require.Equal(t, []string{"FOO=bar", "GODEBUG=gctrace=1"}, chainconfig.StartEnv(cfg))
+	require.Len(t, hooks, 1)
+	require.Equal(t, "hooks.pre_start[0]", hooks[0].Field)
+	require.Equal(t, "cp genesis.json /mars/config/genesis.json", cmd.String())
+	require.Empty(t, postStart)
+}
+
+func TestParseWithInvalidHooks(t *testing.T) {
+	cases := []struct {
+		name   string
+		config string
+		err    string
+	}{
+		{
+			name: "invalid flag",
+			config: `
+start:
+  flags: ["trace"]`,
+			err: "config is not valid: start.flags[0]: 'trace' is not a flag",
+		},
+		{
+			name: "invalid env name",
+			config: `
+start:
+  env:
+    "FOO BAR": baz`,
+			err: "config is not valid: start.env: 'FOO BAR' is not a valid variable name",
+		},
+		{
+			name: "empty command",
+			config: `
+hooks:
+  post_start:
+    - " "`,
+			err: "config is not valid: hooks.post_start[0]: command is empty",
+		},
+		{
+			name: "invalid template",
+			config: `
+hooks:
+  post_init:
+    - echo {{.Home`,
+			err: `config is not valid: hooks.post_init[0]: template: hooks.post_init[0]:1: unclosed action`,
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := chainconfig.Parse(strings.NewReader(hooksConfig + tt.config))
+
+			require.EqualError(t, err, tt.err)
+		})
+	}
+}
@@ -120,12 +120,25 @@ func decodeConfig(r io.Reader, version version.Version) (version.Converter, erro
 	return cfg, nil
 }
 
+// MaxFaucetChallengeDifficulty is the highest challenge difficulty supported by the faucet.
+const MaxFaucetChallengeDifficulty = 32
+
 func validateConfig(c *Config) error {
 	if len(c.Accounts) == 0 {
 		return &ValidationError{"at least one account is required"}
 	}
 
-	for _, validator := range c.Validators {
+	// Accounts without address have their key created so it can be
+	// recovered in the keyring of the node of other validators
+	keys := make(map[string]struct{}, len(c.Accounts))
+	for _, account := range c.Accounts {
+		if account.Address == "" {
+			keys[account.Name] = struct{}{}
+		}
+	}
+
+	names := make(map[string]struct{}, len(c.Validators))
+	for i, validator := range c.Validators {
 		if validator.Name == "" {
 			return &ValidationError{"validator 'name' is required"}
 		}
@@ -133,9 +146,37 @@ func validateConfig(c *Config) error {
 		if validator.Bonded == "" {
 			return &ValidationError{"validator 'bonded' is required"}
 		}
+
+		// Each validator runs its own node so names must be unique
+		if _, ok := names[validator.Name]; ok {
+			return &ValidationError{fmt.Sprintf("validator name '%s' is duplicated", validator.Name)}
+		}
+
+		names[validator.Name] = struct{}{}
+
+		// Validators other than the first one run their node with their own keyring
+		if _, ok := keys[validator.Name]; i > 0 && !ok {
+			return &ValidationError{fmt.Sprintf(
+				"validator '%s' requires an account with the same name and without address", validator.Name,
+			)}
+		}
 	}
 
-	return nil
+	if c.Faucet.ChallengeDifficulty > MaxFaucetChallengeDifficulty {
+		return &ValidationError{fmt.Sprintf(
+			"faucet 'challenge_difficulty' can't be higher than %d", MaxFaucetChallengeDifficulty,
+		)}
+	}
+
+	if _, err := GenesisPatches(c); err != nil {
+		return err
+	}
+
+	if err := validateStart(c); err != nil {
+		return err
+	}
+
+	return validateHooks(c)
 }
 
 func validateNetworkConfig(c *Config) error {
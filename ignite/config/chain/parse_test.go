@@ -103,3 +103,70 @@ func TestParseNetworkWithInvalidData(t *testing.T) {
 		),
 	)
 }
+
+func TestParseWithDuplicatedValidators(t *testing.T) {
+	// Arrange
+	r := strings.NewReader(`
+version: 1
+accounts:
+  - name: alice
+    coins: ["100000000stake"]
+validators:
+  - name: alice
+    bonded: 100000000stake
+  - name: alice
+    bonded: 100000000stake
+`)
+
+	// Act
+	_, err := chainconfig.Parse(r)
+
+	// Assert
+	require.EqualError(t, err, "config is not valid: validator name 'alice' is duplicated")
+}
+
+func TestParseWithValidatorWithoutAccount(t *testing.T) {
+	// Arrange
+	r := strings.NewReader(`
+version: 1
+accounts:
+  - name: alice
+    coins: ["100000000stake"]
+  - name: bob
+    coins: ["100000000stake"]
+    address: cosmos1adn9gxjmrc3hrsdx5zpc9sj2ra7kgqkmphf8yw
+validators:
+  - name: alice
+    bonded: 100000000stake
+  - name: bob
+    bonded: 100000000stake
+`)
+
+	// Act
+	_, err := chainconfig.Parse(r)
+
+	// Assert
+	require.EqualError(t, err, "config is not valid: validator 'bob' requires an account with the same name and without address")
+}
+
+func TestParseWithFaucetChallengeDifficultyTooHigh(t *testing.T) {
+	// Arrange
+	r := strings.NewReader(`
+version: 1
+accounts:
+  - name: alice
+    coins: ["100000000stake"]
+validators:
+  - name: alice
+    bonded: 100000000stake
+faucet:
+  name: alice
+  challenge_difficulty: 33
+`)
+
+	// Act
+	_, err := chainconfig.Parse(r)
+
+	// Assert
+	require.EqualError(t, err, "config is not valid: faucet 'challenge_difficulty' can't be higher than 32")
+}
@@ -0,0 +1,134 @@
+package plugins
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"golang.org/x/exp/slices"
+	"gopkg.in/yaml.v2"
+)
+
+// LockFilename is the name of the file that locks the versions of the plugins
+// declared in a plugins config. The lock file is stored next to the config.
+const LockFilename = "plugins.lock"
+
+// Lock keeps the resolved versions of the plugins declared in a plugins config.
+type Lock struct {
+	// path to the lock file
+	path string
+
+	Plugins []LockedPlugin `yaml:"plugins"`
+}
+
+// LockedPlugin keeps the resolved version of a remote plugin.
+type LockedPlugin struct {
+	// Path is the plugin path as declared in the plugins config, including the
+	// reference when present, for example github.com/foo/bar@v42.
+	Path string `yaml:"path"`
+	// Commit is the hash of the commit that the plugin path resolved to.
+	Commit string `yaml:"commit"`
+	// Checksum is the SHA256 checksum of the plugin source files in Commit.
+	// The source is used instead of the plugin binary because the checksum of
+	// the binary depends on the platform and the Go version used to build it.
+	Checksum string `yaml:"checksum"`
+}
+
+// ParseLockDir parses the plugins lock file found in dir.
+// An empty lock is returned, w/o errors, when dir doesn't contain a lock file.
+func ParseLockDir(dir string) (*Lock, error) {
+	errf := func(err error) (*Lock, error) {
+		return nil, fmt.Errorf("plugin lock parse: %w", err)
+	}
+	fi, err := os.Stat(dir)
+	if err != nil {
+		return errf(err)
+	}
+	if !fi.IsDir() {
+		return errf(fmt.Errorf("path %s is not a dir", dir))
+	}
+
+	l := Lock{
+		path: filepath.Join(dir, LockFilename),
+	}
+
+	f, err := os.Open(l.path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return &l, nil
+		}
+		return errf(err)
+	}
+	defer f.Close()
+
+	if err := yaml.NewDecoder(f).Decode(&l); err != nil && !errors.Is(err, io.EOF) {
+		return errf(err)
+	}
+	return &l, nil
+}
+
+// Path return the path of the lock file.
+func (l Lock) Path() string {
+	return l.path
+}
+
+// Get returns the locked version of the plugin with the given path.
+// The path is compared including the reference.
+func (l Lock) Get(path string) (LockedPlugin, bool) {
+	i := slices.IndexFunc(l.Plugins, func(lp LockedPlugin) bool {
+		return lp.Path == path
+	})
+	if i == -1 {
+		return LockedPlugin{}, false
+	}
+	return l.Plugins[i], true
+}
+
+// Set adds the locked version of a plugin or replaces it when the plugin is
+// already locked.
+func (l *Lock) Set(lp LockedPlugin) {
+	for i, p := range l.Plugins {
+		if p.Path == lp.Path {
+			l.Plugins[i] = lp
+			return
+		}
+	}
+	l.Plugins = append(l.Plugins, lp)
+}
+
+// Remove removes the locked version of the plugin with the given path.
+func (l *Lock) Remove(path string) {
+	if i := slices.IndexFunc(l.Plugins, func(lp LockedPlugin) bool {
+		return lp.Path == path
+	}); i != -1 {
+		l.Plugins = slices.Delete(l.Plugins, i, i+1)
+	}
+}
+
+// Save persists the lock to disk.
+// An empty lock removes the lock file.
+func (l *Lock) Save() error {
+	errf := func(err error) error {
+		return fmt.Errorf("plugin lock save: %w", err)
+	}
+	if l.path == "" {
+		return errf(errors.New("empty path"))
+	}
+	if len(l.Plugins) == 0 {
+		if err := os.Remove(l.path); err != nil && !os.IsNotExist(err) {
+			return errf(err)
+		}
+		return nil
+	}
+	file, err := os.Create(l.path)
+	if err != nil {
+		return errf(err)
+	}
+	defer file.Close()
+	if err := yaml.NewEncoder(file).Encode(l); err != nil {
+		return errf(err)
+	}
+	return nil
+}
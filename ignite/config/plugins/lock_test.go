@@ -0,0 +1,107 @@
+package plugins_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	pluginsconfig "github.com/ignite/cli/ignite/config/plugins"
+)
+
+func TestParseLockDir(t *testing.T) {
+	tests := []struct {
+		name            string
+		content         string
+		expectedError   string
+		expectedPlugins []pluginsconfig.LockedPlugin
+	}{
+		{
+			name: "ok: dir doesn't contain any lock",
+		},
+		{
+			name: "ok: dir contains a lock",
+			content: `plugins:
+- path: github.com/foo/bar@v1
+  commit: 4d6e5bc1c5a7c6f0de4b1a1e48b1e0c3d0e3a5b2
+  checksum: 9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08
+`,
+			expectedPlugins: []pluginsconfig.LockedPlugin{
+				{
+					Path:     "github.com/foo/bar@v1",
+					Commit:   "4d6e5bc1c5a7c6f0de4b1a1e48b1e0c3d0e3a5b2",
+					Checksum: "9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08",
+				},
+			},
+		},
+		{
+			name:          "fail: dir contains an invalid lock",
+			content:       "not yaml !",
+			expectedError: "plugin lock parse: yaml: unmarshal errors:\n  line 1: cannot unmarshal !!str `not yaml !` into plugins.Lock",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			if tt.content != "" {
+				err := os.WriteFile(filepath.Join(dir, pluginsconfig.LockFilename), []byte(tt.content), 0o644)
+				require.NoError(t, err)
+			}
+
+			lock, err := pluginsconfig.ParseLockDir(dir)
+
+			if tt.expectedError != "" {
+				require.EqualError(t, err, tt.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.expectedPlugins, lock.Plugins)
+			require.Equal(t, filepath.Join(dir, pluginsconfig.LockFilename), lock.Path())
+		})
+	}
+}
+
+func TestLockSave(t *testing.T) {
+	// Arrange
+	dir := t.TempDir()
+	lock, err := pluginsconfig.ParseLockDir(dir)
+	require.NoError(t, err)
+	foo := pluginsconfig.LockedPlugin{Path: "github.com/foo/foo", Commit: "a", Checksum: "b"}
+	bar := pluginsconfig.LockedPlugin{Path: "github.com/foo/bar@v1", Commit: "c", Checksum: "d"}
+
+	// Act
+	lock.Set(foo)
+	lock.Set(bar)
+	foo.Commit = "e"
+	lock.Set(foo)
+	err = lock.Save()
+
+	// Assert
+	require.NoError(t, err)
+	saved, err := pluginsconfig.ParseLockDir(dir)
+	require.NoError(t, err)
+	require.Equal(t, []pluginsconfig.LockedPlugin{foo, bar}, saved.Plugins)
+	lp, ok := saved.Get("github.com/foo/bar@v1")
+	require.True(t, ok)
+	require.Equal(t, bar, lp)
+	_, ok = saved.Get("github.com/foo/bar")
+	require.False(t, ok)
+}
+
+func TestLockSaveEmpty(t *testing.T) {
+	// Arrange
+	dir := t.TempDir()
+	lock, err := pluginsconfig.ParseLockDir(dir)
+	require.NoError(t, err)
+	lock.Set(pluginsconfig.LockedPlugin{Path: "github.com/foo/foo"})
+	require.NoError(t, lock.Save())
+
+	// Act
+	lock.Remove("github.com/foo/foo")
+	err = lock.Save()
+
+	// Assert
+	require.NoError(t, err)
+	require.NoFileExists(t, lock.Path())
+}
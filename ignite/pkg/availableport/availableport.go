@@ -19,11 +19,7 @@ func Find(n int) (ports []int, err error) {
 			rand.Seed(time.Now().UnixNano())
 			port := rand.Intn(max-min+1) + min
 
-			conn, err := net.Dial("tcp", fmt.Sprintf(":%d", port))
-			// if there is an error, this might mean that no one is listening from this port
-			// which is what we need.
-			if err == nil {
-				conn.Close()
+			if !IsAvailable(port) {
 				continue
 			}
 			ports = append(ports, port)
@@ -32,3 +28,15 @@ func Find(n int) (ports []int, err error) {
 	}
 	return ports, nil
 }
+
+// IsAvailable checks if a port is not being used by another program.
+func IsAvailable(port int) bool {
+	conn, err := net.Dial("tcp", fmt.Sprintf(":%d", port))
+	// if there is an error, this might mean that no one is listening from this port
+	// which is what we need.
+	if err != nil {
+		return true
+	}
+	conn.Close()
+	return false
+}
@@ -14,6 +14,12 @@ import (
 
 var ErrorNotFound = errors.New("no value was found with the provided key")
 
+// ErrStorageLocked is returned when the storage is being used by another process.
+var ErrStorageLocked = errors.New("cache storage is locked by another process")
+
+// checkTimeout is the time to wait for the database lock when checking the storage.
+const checkTimeout = 3 * time.Second
+
 // Storage is meant to be passed around and used by the New function (which provides namespacing and type-safety).
 type Storage struct {
 	storagePath string
@@ -64,6 +70,45 @@ func (s Storage) Clear() error {
 	})
 }
 
+// Check verifies that the storage database is not corrupted.
+// No error is returned when the database file doesn't exist yet.
+func (s Storage) Check() error {
+	if _, err := os.Stat(s.storagePath); os.IsNotExist(err) {
+		return nil
+	}
+
+	db, err := bolt.Open(s.storagePath, 0o640, &bolt.Options{Timeout: checkTimeout, ReadOnly: true})
+	if errors.Is(err, bolt.ErrTimeout) {
+		return ErrStorageLocked
+	}
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	return db.View(func(tx *bolt.Tx) error {
+		// Read all the errors to finish the check
+		var checkErr error
+		for err := range tx.Check() {
+			if checkErr == nil {
+				checkErr = err
+			}
+		}
+
+		return checkErr
+	})
+}
+
+// Remove deletes the storage database file.
+// It can be used to recover from a corrupted database.
+func (s Storage) Remove() error {
+	if err := os.Remove(s.storagePath); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+
+	return nil
+}
+
 // Put sets key to value within the namespace
 // If the key already exists, it will be overwritten.
 func (c Cache[T]) Put(key string, value T) error {
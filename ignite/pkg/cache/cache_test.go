@@ -1,6 +1,7 @@
 package cache_test
 
 import (
+	"os"
 	"path/filepath"
 	"testing"
 
@@ -161,6 +162,29 @@ func TestClearStorage(t *testing.T) {
 	require.Equal(t, cache.ErrorNotFound, err)
 }
 
+func TestCheckStorage(t *testing.T) {
+	tmpDir := t.TempDir()
+	path := filepath.Join(tmpDir, "testdbfile.db")
+	cacheStorage, err := cache.NewStorage(path)
+	require.NoError(t, err)
+
+	// A missing database is not corrupted
+	require.NoError(t, cacheStorage.Check())
+
+	err = cache.New[string](cacheStorage, "myNameSpace").Put("myKey", "myValue")
+	require.NoError(t, err)
+	require.NoError(t, cacheStorage.Check())
+
+	err = os.WriteFile(path, []byte("corrupted"), 0o640)
+	require.NoError(t, err)
+	require.Error(t, cacheStorage.Check())
+
+	err = cacheStorage.Remove()
+	require.NoError(t, err)
+	require.NoError(t, cacheStorage.Check())
+	require.NoFileExists(t, path)
+}
+
 func TestKey(t *testing.T) {
 	singleKey := cache.Key("test1")
 	require.Equal(t, "test1", singleKey)
@@ -28,6 +28,17 @@ func (r Runner) Start(ctx context.Context, args ...string) error {
 	)
 }
 
+// StartWithEnv starts the blockchain with additional environment variables
+// formatted as "KEY=value".
+func (r Runner) StartWithEnv(ctx context.Context, env []string, args ...string) error {
+	return r.run(
+		ctx,
+		runOptions{wrappedStdErrMaxLen: 50000},
+		r.chainCmd.StartCommand(args...),
+		step.Env(env...),
+	)
+}
+
 // Init inits the blockchain.
 func (r Runner) Init(ctx context.Context, moniker string) error {
 	return r.run(ctx, runOptions{}, r.chainCmd.InitCommand(moniker))
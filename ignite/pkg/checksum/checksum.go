@@ -1,12 +1,14 @@
 package checksum
 
 import (
+	"bufio"
 	"bytes"
 	"crypto/sha256"
 	"fmt"
 	"io"
 	"os"
 	"path/filepath"
+	"strings"
 
 	"github.com/ignite/cli/ignite/pkg/xexec"
 )
@@ -22,6 +24,10 @@ func Sum(dirPath, outPath string) error {
 	}
 
 	for _, info := range files {
+		if info.IsDir() {
+			continue
+		}
+
 		path := filepath.Join(dirPath, info.Name())
 		f, err := os.Open(path)
 		if err != nil {
@@ -45,6 +51,28 @@ func Sum(dirPath, outPath string) error {
 	return os.WriteFile(outPath, b.Bytes(), 0o666)
 }
 
+// Parse reads a checksum file created by Sum and returns
+// the checksums indexed by file name.
+func Parse(r io.Reader) (map[string]string, error) {
+	checksums := make(map[string]string)
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+
+		fields := strings.Fields(line)
+		if len(fields) != 2 {
+			return nil, fmt.Errorf("invalid checksum line: %q", line)
+		}
+
+		checksums[strings.TrimPrefix(fields[1], "*")] = fields[0]
+	}
+
+	return checksums, scanner.Err()
+}
+
 // Binary returns SHA256 hash of executable file, file is searched by name in PATH.
 func Binary(binaryName string) (string, error) {
 	// get binary path
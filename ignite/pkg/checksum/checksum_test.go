@@ -0,0 +1,37 @@
+package checksum_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ignite/cli/ignite/pkg/checksum"
+)
+
+func TestSumAndParse(t *testing.T) {
+	// Arrange
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "mars_linux_amd64.tar.gz"), []byte("linux"), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "mars_darwin_arm64.tar.gz"), []byte("darwin"), 0o644))
+	require.NoError(t, os.Mkdir(filepath.Join(dir, "cosmovisor"), 0o755))
+	checksumPath := filepath.Join(t.TempDir(), "checksum.txt")
+
+	// Act
+	err := checksum.Sum(dir, checksumPath)
+	require.NoError(t, err)
+
+	f, err := os.Open(checksumPath)
+	require.NoError(t, err)
+	defer f.Close()
+
+	checksums, err := checksum.Parse(f)
+
+	// Assert
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{
+		"mars_darwin_arm64.tar.gz": checksum.Strings("darwin"),
+		"mars_linux_amd64.tar.gz":  checksum.Strings("linux"),
+	}, checksums)
+}
@@ -0,0 +1,80 @@
+package checksum
+
+import (
+	"crypto/ed25519"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// SignatureExt is the extension of the signature files.
+const SignatureExt = ".sig"
+
+// ErrInvalidSignature is returned when a signature doesn't match the signed file.
+var ErrInvalidSignature = errors.New("invalid signature")
+
+// ParsePrivateKey parses an ed25519 private key in PEM format (PKCS #8),
+// like the ones created with "openssl genpkey -algorithm ed25519".
+func ParsePrivateKey(data []byte) (ed25519.PrivateKey, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New("private key is not in PEM format")
+	}
+
+	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+
+	edKey, ok := key.(ed25519.PrivateKey)
+	if !ok {
+		return nil, fmt.Errorf("private key type %T is not ed25519", key)
+	}
+
+	return edKey, nil
+}
+
+// Sign signs the file in path with an ed25519 private key and writes the
+// signature encoded in base64 to a file with the same path and the signature
+// extension. The signature can be verified with the public key, for example with
+// "openssl pkeyutl -verify -pubin -inkey key.pub -rawin -in file -sigfile sig".
+// Returns the path of the signature file.
+func Sign(path string, key ed25519.PrivateKey) (string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+
+	sig := base64.StdEncoding.EncodeToString(ed25519.Sign(key, data))
+	sigPath := path + SignatureExt
+
+	return sigPath, os.WriteFile(sigPath, []byte(sig+"\n"), 0o644)
+}
+
+// Verify checks the signature of the file in path created by Sign.
+func Verify(path string, key ed25519.PublicKey) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	encodedSig, err := os.ReadFile(path + SignatureExt)
+	if err != nil {
+		return err
+	}
+
+	sig, err := base64.StdEncoding.DecodeString(strings.TrimSpace(string(encodedSig)))
+	if err != nil {
+		return fmt.Errorf("%w: %s", ErrInvalidSignature, err)
+	}
+
+	if !ed25519.Verify(key, data, sig) {
+		return ErrInvalidSignature
+	}
+
+	return nil
+}
@@ -0,0 +1,45 @@
+package checksum_test
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ignite/cli/ignite/pkg/checksum"
+)
+
+func TestSign(t *testing.T) {
+	// Arrange
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	der, err := x509.MarshalPKCS8PrivateKey(priv)
+	require.NoError(t, err)
+	key, err := checksum.ParsePrivateKey(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}))
+	require.NoError(t, err)
+
+	path := filepath.Join(t.TempDir(), "checksum.txt")
+	require.NoError(t, os.WriteFile(path, []byte("abc  mars.tar.gz\n"), 0o644))
+
+	// Act
+	sigPath, err := checksum.Sign(path, key)
+
+	// Assert
+	require.NoError(t, err)
+	require.Equal(t, path+".sig", sigPath)
+	require.NoError(t, checksum.Verify(path, pub))
+
+	// Assert: the signature doesn't match a modified file
+	require.NoError(t, os.WriteFile(path, []byte("def  mars.tar.gz\n"), 0o644))
+	require.ErrorIs(t, checksum.Verify(path, pub), checksum.ErrInvalidSignature)
+}
+
+func TestParsePrivateKeyErrors(t *testing.T) {
+	_, err := checksum.ParsePrivateKey([]byte("not a key"))
+	require.EqualError(t, err, "private key is not in PEM format")
+}
@@ -230,11 +230,13 @@ func (m Events) View() string {
 
 		// Add an empty line when the event group changes but omit it
 		// for the first event to avoid adding an initial empty line.
-		if group != evt.Group && e.Prev() != nil {
+		if group != evt.Group {
+			if e.Prev() != nil {
+				view.WriteRune('\n')
+			}
+
 			// Update the group being displayed
 			group = evt.Group
-
-			view.WriteRune('\n')
 		}
 
 		if e.Next() == nil && evt.InProgress() {
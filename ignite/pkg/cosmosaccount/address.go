@@ -0,0 +1,53 @@
+package cosmosaccount
+
+import (
+	"fmt"
+
+	sdktypes "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/bech32"
+)
+
+// AddressCodec encodes and decodes the bech32 account addresses of a chain
+// using its own prefix, without relying on the prefix of the SDK global config.
+// This allows to work with chains that use different prefixes in the same process.
+type AddressCodec struct {
+	prefix string
+}
+
+// NewAddressCodec returns a codec for the addresses with the account prefix.
+// The Cosmos prefix is used when the prefix is empty.
+func NewAddressCodec(prefix string) AddressCodec {
+	if prefix == "" {
+		prefix = AccountPrefixCosmos
+	}
+
+	return AddressCodec{prefix: prefix}
+}
+
+// Prefix returns the account address prefix.
+func (c AddressCodec) Prefix() string {
+	return c.prefix
+}
+
+// BytesToString encodes an address to its bech32 representation.
+func (c AddressCodec) BytesToString(addr []byte) (string, error) {
+	return bech32.ConvertAndEncode(c.prefix, addr)
+}
+
+// StringToBytes decodes a bech32 address, which must use the account prefix.
+func (c AddressCodec) StringToBytes(address string) (sdktypes.AccAddress, error) {
+	prefix, bz, err := bech32.DecodeAndConvert(address)
+	if err != nil {
+		return nil, err
+	}
+
+	if prefix != c.prefix {
+		return nil, fmt.Errorf("invalid bech32 prefix: expected %s, got %s", c.prefix, prefix)
+	}
+
+	if err := sdktypes.VerifyAddressFormat(bz); err != nil {
+		return nil, err
+	}
+
+	return bz, nil
+}
@@ -2,10 +2,12 @@ package cosmosaccount
 
 import (
 	"bufio"
+	"bytes"
 	"encoding/hex"
 	"errors"
 	"fmt"
 	"os"
+	"sort"
 
 	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
 
@@ -15,6 +17,8 @@ import (
 	cryptocodec "github.com/cosmos/cosmos-sdk/crypto/codec"
 	"github.com/cosmos/cosmos-sdk/crypto/hd"
 	"github.com/cosmos/cosmos-sdk/crypto/keyring"
+	"github.com/cosmos/cosmos-sdk/crypto/keys/multisig"
+	cryptotypes "github.com/cosmos/cosmos-sdk/crypto/types"
 	sdktypes "github.com/cosmos/cosmos-sdk/types"
 	"github.com/cosmos/cosmos-sdk/types/bech32"
 	"github.com/cosmos/go-bip39"
@@ -136,16 +140,12 @@ type Account struct {
 
 // Address returns the address of the account from given prefix.
 func (a Account) Address(accPrefix string) (string, error) {
-	if accPrefix == "" {
-		accPrefix = AccountPrefixCosmos
-	}
-
 	pk, err := a.Record.GetPubKey()
 	if err != nil {
 		return "", err
 	}
 
-	return toBech32(accPrefix, pk.Address())
+	return NewAddressCodec(accPrefix).BytesToString(pk.Address())
 }
 
 // PubKey returns a public key for account.
@@ -158,14 +158,6 @@ func (a Account) PubKey() (string, error) {
 	return pk.String(), nil
 }
 
-func toBech32(prefix string, addr []byte) (string, error) {
-	bech32Addr, err := bech32.ConvertAndEncode(prefix, addr)
-	if err != nil {
-		return "", err
-	}
-	return bech32Addr, nil
-}
-
 // EnsureDefaultAccount ensures that default account exists.
 func (r Registry) EnsureDefaultAccount() error {
 	_, err := r.GetByName(DefaultAccount)
@@ -241,6 +233,54 @@ func (r Registry) Import(name, secret, passphrase string) (Account, error) {
 	return r.GetByName(name)
 }
 
+// CreateMultisig creates a new multisig account with name from the public keys
+// of the accounts. The keys are the names or addresses of the accounts and the
+// threshold is the number of signatures required to sign a transaction.
+func (r Registry) CreateMultisig(name string, keys []string, threshold int) (Account, error) {
+	_, err := r.GetByName(name)
+	if err == nil {
+		return Account{}, ErrAccountExists
+	}
+	var accErr *AccountDoesNotExistError
+	if !errors.As(err, &accErr) {
+		return Account{}, err
+	}
+
+	if threshold <= 0 || threshold > len(keys) {
+		return Account{}, fmt.Errorf("threshold must be between 1 and %d, got %d", len(keys), threshold)
+	}
+
+	pks := make([]cryptotypes.PubKey, len(keys))
+	for i, key := range keys {
+		acc, err := r.GetByName(key)
+		if _, _, decodeErr := bech32.DecodeAndConvert(key); errors.As(err, &accErr) && decodeErr == nil {
+			acc, err = r.GetByAddress(key)
+		}
+		if err != nil {
+			return Account{}, err
+		}
+
+		if pks[i], err = acc.Record.GetPubKey(); err != nil {
+			return Account{}, err
+		}
+	}
+
+	// Sort the keys by address so the multisig address doesn't depend on their order
+	sort.Slice(pks, func(i, j int) bool {
+		return bytes.Compare(pks[i].Address(), pks[j].Address()) < 0
+	})
+
+	record, err := r.Keyring.SaveMultisig(name, multisig.NewLegacyAminoPubKey(threshold, pks))
+	if err != nil {
+		return Account{}, err
+	}
+
+	return Account{
+		Name:   name,
+		Record: record,
+	}, nil
+}
+
 // Export exports an account as a private key.
 func (r Registry) Export(name, passphrase string) (key string, err error) {
 	if _, err = r.GetByName(name); err != nil {
@@ -287,12 +327,13 @@ func (r Registry) GetByName(name string) (Account, error) {
 }
 
 // GetByAddress returns an account by its address.
+// The address can use any prefix because the accounts are the same for all the chains.
 func (r Registry) GetByAddress(address string) (Account, error) {
-	sdkAddr, err := sdktypes.AccAddressFromBech32(address)
+	_, bz, err := bech32.DecodeAndConvert(address)
 	if err != nil {
 		return Account{}, err
 	}
-	record, err := r.Keyring.KeyByAddress(sdkAddr)
+	record, err := r.Keyring.KeyByAddress(sdktypes.AccAddress(bz))
 	if errors.Is(err, dkeyring.ErrKeyNotFound) || errors.Is(err, sdkerrors.ErrKeyNotFound) {
 		return Account{}, &AccountDoesNotExistError{address}
 	}
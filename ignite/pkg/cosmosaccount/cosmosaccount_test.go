@@ -1,6 +1,7 @@
 package cosmosaccount_test
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -42,6 +43,12 @@ func TestRegistry(t *testing.T) {
 	require.Equal(t, getAccount.Name, account.Name)
 	require.Equal(t, getAccount.Name, account.Record.Name)
 
+	marsAddr, err := account.Address("mars")
+	require.NoError(t, err)
+	getAccount, err = registry.GetByAddress(marsAddr)
+	require.NoError(t, err)
+	require.Equal(t, getAccount.Record.PubKey, account.Record.PubKey)
+
 	secondTmpDir := t.TempDir()
 	secondRegistry, err := cosmosaccount.New(cosmosaccount.WithHome(secondTmpDir))
 	require.NoError(t, err)
@@ -70,3 +77,63 @@ func TestRegistry(t *testing.T) {
 	_, err = registry.GetByAddress(addr)
 	require.ErrorAs(t, err, &expectedErr)
 }
+
+func TestAddressCodec(t *testing.T) {
+	addr := []byte("address_____________")
+
+	cosmos := cosmosaccount.NewAddressCodec("")
+	require.Equal(t, cosmosaccount.AccountPrefixCosmos, cosmos.Prefix())
+
+	mars := cosmosaccount.NewAddressCodec("mars")
+	marsAddr, err := mars.BytesToString(addr)
+	require.NoError(t, err)
+	require.True(t, strings.HasPrefix(marsAddr, "mars1"))
+
+	bz, err := mars.StringToBytes(marsAddr)
+	require.NoError(t, err)
+	require.Equal(t, addr, []byte(bz))
+
+	_, err = cosmos.StringToBytes(marsAddr)
+	require.EqualError(t, err, "invalid bech32 prefix: expected cosmos, got mars")
+}
+
+func TestRegistryCreateMultisig(t *testing.T) {
+	registry, err := cosmosaccount.NewInMemory()
+	require.NoError(t, err)
+
+	alice, _, err := registry.Create("alice")
+	require.NoError(t, err)
+	bob, _, err := registry.Create("bob")
+	require.NoError(t, err)
+
+	bobAddr, err := bob.Address("mars")
+	require.NoError(t, err)
+
+	_, err = registry.CreateMultisig("multisig", []string{"alice", bobAddr}, 3)
+	require.EqualError(t, err, "threshold must be between 1 and 2, got 3")
+
+	_, err = registry.CreateMultisig("multisig", []string{"alice", "carol"}, 1)
+	var accErr *cosmosaccount.AccountDoesNotExistError
+	require.ErrorAs(t, err, &accErr)
+
+	account, err := registry.CreateMultisig("multisig", []string{"alice", bobAddr}, 2)
+	require.NoError(t, err)
+	require.Equal(t, "multisig", account.Name)
+
+	// The multisig address doesn't depend on the order of the keys
+	reversed, err := registry.CreateMultisig("reversed", []string{"bob", "alice"}, 2)
+	require.NoError(t, err)
+
+	addr, err := account.Address(cosmosaccount.AccountPrefixCosmos)
+	require.NoError(t, err)
+	reversedAddr, err := reversed.Address(cosmosaccount.AccountPrefixCosmos)
+	require.NoError(t, err)
+	require.Equal(t, addr, reversedAddr)
+
+	aliceAddr, err := alice.Address(cosmosaccount.AccountPrefixCosmos)
+	require.NoError(t, err)
+	require.NotEqual(t, aliceAddr, addr)
+
+	_, err = registry.CreateMultisig("multisig", []string{"alice", "bob"}, 2)
+	require.ErrorIs(t, err, cosmosaccount.ErrAccountExists)
+}
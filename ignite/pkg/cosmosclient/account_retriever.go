@@ -0,0 +1,83 @@
+package cosmosclient
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/cosmos/cosmos-sdk/client"
+	sdktypes "github.com/cosmos/cosmos-sdk/types"
+	grpctypes "github.com/cosmos/cosmos-sdk/types/grpc"
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+
+	"github.com/ignite/cli/ignite/pkg/cosmosaccount"
+)
+
+var _ client.AccountRetriever = accountRetriever{}
+
+// accountRetriever queries the accounts of the auth module like the Cosmos SDK
+// account retriever does, but encodes the addresses with the codec of the client
+// instead of the prefix of the SDK global config.
+type accountRetriever struct {
+	addressCodec cosmosaccount.AddressCodec
+}
+
+// GetAccount queries for an account given an address.
+func (r accountRetriever) GetAccount(clientCtx client.Context, addr sdktypes.AccAddress) (client.Account, error) {
+	acc, _, err := r.GetAccountWithHeight(clientCtx, addr)
+	return acc, err
+}
+
+// GetAccountWithHeight queries for an account given an address.
+// Returns the height of the query with the account.
+func (r accountRetriever) GetAccountWithHeight(clientCtx client.Context, addr sdktypes.AccAddress) (client.Account, int64, error) {
+	address, err := r.addressCodec.BytesToString(addr)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var header metadata.MD
+	res, err := authtypes.NewQueryClient(clientCtx).Account(
+		context.Background(),
+		&authtypes.QueryAccountRequest{Address: address},
+		grpc.Header(&header),
+	)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	blockHeight := header.Get(grpctypes.GRPCBlockHeightHeader)
+	if l := len(blockHeight); l != 1 {
+		return nil, 0, fmt.Errorf("unexpected '%s' header length; got %d, expected: %d", grpctypes.GRPCBlockHeightHeader, l, 1)
+	}
+
+	height, err := strconv.ParseInt(blockHeight[0], 10, 64)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to parse block height: %w", err)
+	}
+
+	var acc authtypes.AccountI
+	if err := clientCtx.InterfaceRegistry.UnpackAny(res.Account, &acc); err != nil {
+		return nil, 0, err
+	}
+
+	return acc, height, nil
+}
+
+// EnsureExists returns an error if no account exists for the given address.
+func (r accountRetriever) EnsureExists(clientCtx client.Context, addr sdktypes.AccAddress) error {
+	_, err := r.GetAccount(clientCtx, addr)
+	return err
+}
+
+// GetAccountNumberSequence returns the account number and sequence of an account.
+func (r accountRetriever) GetAccountNumberSequence(clientCtx client.Context, addr sdktypes.AccAddress) (uint64, uint64, error) {
+	acc, err := r.GetAccount(clientCtx, addr)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	return acc.GetAccountNumber(), acc.GetSequence(), nil
+}
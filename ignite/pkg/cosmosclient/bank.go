@@ -11,8 +11,6 @@ import (
 )
 
 func (c Client) BankBalances(ctx context.Context, address string, pagination *query.PageRequest) (sdk.Coins, error) {
-	defer c.lockBech32Prefix()()
-
 	req := &banktypes.QueryAllBalancesRequest{
 		Address:    address,
 		Pagination: pagination,
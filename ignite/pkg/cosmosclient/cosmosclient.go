@@ -35,6 +35,8 @@ import (
 	"github.com/cosmos/gogoproto/proto"
 	prototypes "github.com/cosmos/gogoproto/types"
 	"github.com/pkg/errors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 
 	"github.com/ignite/cli/ignite/pkg/cosmosaccount"
 	"github.com/ignite/cli/ignite/pkg/cosmosfaucet"
@@ -110,8 +112,10 @@ type Client struct {
 	faucetClient     FaucetClient
 	gasometer        Gasometer
 	signer           Signer
+	sequences        *SequenceManager
 
 	addressPrefix string
+	addressCodec  cosmosaccount.AddressCodec
 
 	nodeAddress string
 	out         io.Writer
@@ -176,6 +180,15 @@ func WithNodeAddress(addr string) Option {
 	}
 }
 
+// WithChainID sets the chain ID of your chain. When this option is provided the
+// client doesn't request the chain ID to the node, which allows to create a client
+// without a connection to the node, for example to sign transactions offline.
+func WithChainID(chainID string) Option {
+	return func(c *Client) {
+		c.chainID = chainID
+	}
+}
+
 func WithAddressPrefix(prefix string) Option {
 	return func(c *Client) {
 		c.addressPrefix = prefix
@@ -279,6 +292,17 @@ func WithSigner(signer Signer) Option {
 	}
 }
 
+// WithSequenceManager sets the manager that hands out the account sequences locally
+// instead of fetching them from the node for each transaction. The transactions
+// rejected because of an account sequence mismatch are signed again with the
+// sequence expected by the node and broadcasted again.
+// Use it to broadcast many transactions concurrently from the same account.
+func WithSequenceManager(m *SequenceManager) Option {
+	return func(c *Client) {
+		c.sequences = m
+	}
+}
+
 // New creates a new client with given options.
 func New(ctx context.Context, options ...Option) (Client, error) {
 	c := Client{
@@ -298,6 +322,8 @@ func New(ctx context.Context, options ...Option) (Client, error) {
 		apply(&c)
 	}
 
+	c.addressCodec = cosmosaccount.NewAddressCodec(c.addressPrefix)
+
 	if c.RPC == nil {
 		if c.RPC, err = rpchttp.New(c.nodeAddress, "/websocket"); err != nil {
 			return Client{}, err
@@ -309,12 +335,14 @@ func New(ctx context.Context, options ...Option) (Client, error) {
 		nodeAddress: c.nodeAddress,
 	}
 
-	statusResp, err := c.RPC.Status(ctx)
-	if err != nil {
-		return Client{}, err
-	}
+	if c.chainID == "" {
+		statusResp, err := c.RPC.Status(ctx)
+		if err != nil {
+			return Client{}, err
+		}
 
-	c.chainID = statusResp.NodeInfo.Network
+		c.chainID = statusResp.NodeInfo.Network
+	}
 
 	if c.homePath == "" {
 		home, err := os.UserHomeDir()
@@ -341,7 +369,7 @@ func New(ctx context.Context, options ...Option) (Client, error) {
 	c.TxFactory = newFactory(c.context)
 
 	if c.accountRetriever == nil {
-		c.accountRetriever = authtypes.AccountRetriever{}
+		c.accountRetriever = accountRetriever{addressCodec: c.addressCodec}
 	}
 	if c.bankQueryClient == nil {
 		c.bankQueryClient = banktypes.NewQueryClient(c.context)
@@ -355,9 +383,6 @@ func New(ctx context.Context, options ...Option) (Client, error) {
 	if c.signer == nil {
 		c.signer = signer{}
 	}
-	// set address prefix in SDK global config
-	c.SetConfigAddressPrefix()
-
 	return c, nil
 }
 
@@ -434,10 +459,72 @@ func (c Client) WaitForTx(ctx context.Context, hash string) (*ctypes.ResultTx, e
 	}
 }
 
+// waitForTxResponse waits for the tx to be committed and returns its response.
+func (c Client) waitForTxResponse(ctx context.Context, hash string) (Response, error) {
+	res, err := c.WaitForTx(ctx, hash)
+	if err != nil {
+		return Response{}, err
+	}
+	// NOTE(tb) second and third parameters are omitted:
+	// - second parameter represents the tx and should be of type sdktypes.Any,
+	// but it is very ugly to decode, not sure if it's worth it (see sdk code
+	// x/auth/query.go method makeTxResult)
+	// - third parameter represents the timestamp of the tx, which must be
+	// fetched from the block itself. So it requires another API call to
+	// fetch the block from res.Height, not sure if it's worth it too.
+	resp := sdktypes.NewResponseResultTx(res, nil, "")
+
+	return Response{
+		Codec:      c.context.Codec,
+		TxResponse: resp,
+	}, handleBroadcastResult(resp, err)
+}
+
+// WaitForTxs requests the txs from hashes and waits for the next block until
+// all of them are found, or returns an error if ctx is canceled.
+// The responses are returned in the order of the hashes, the txs that failed
+// have a response with a code greater than 0.
+func (c Client) WaitForTxs(ctx context.Context, hashes ...string) ([]Response, error) {
+	pending := make(map[int][]byte, len(hashes))
+	for i, hash := range hashes {
+		bz, err := hex.DecodeString(hash)
+		if err != nil {
+			return nil, errors.Wrapf(err, "unable to decode tx hash '%s'", hash)
+		}
+		pending[i] = bz
+	}
+
+	responses := make([]Response, len(hashes))
+	for {
+		for i, bz := range pending {
+			res, err := c.RPC.Tx(ctx, bz, false)
+			if err != nil {
+				if strings.Contains(err.Error(), "not found") {
+					continue
+				}
+				return nil, errors.Wrapf(err, "fetching tx '%s'", hashes[i])
+			}
+
+			responses[i] = Response{
+				Codec:      c.context.Codec,
+				TxResponse: sdktypes.NewResponseResultTx(res, nil, ""),
+			}
+			delete(pending, i)
+		}
+
+		if len(pending) == 0 {
+			return responses, nil
+		}
+
+		// Some txs are not found, wait for next block and try again
+		if err := c.WaitForNextBlock(ctx); err != nil {
+			return nil, errors.Wrap(err, "waiting for next block")
+		}
+	}
+}
+
 // Account returns the account with name or address equal to nameOrAddress.
 func (c Client) Account(nameOrAddress string) (cosmosaccount.Account, error) {
-	defer c.lockBech32Prefix()()
-
 	acc, err := c.AccountRegistry.GetByName(nameOrAddress)
 	if err == nil {
 		return acc, nil
@@ -459,16 +546,50 @@ func (c Client) Context() client.Context {
 	return c.context
 }
 
+// protects sdktypes.Config.
+var mconf sync.Mutex
+
+// AddressCodec returns the codec of the account addresses of the chain.
+func (c Client) AddressCodec() cosmosaccount.AddressCodec {
+	return c.addressCodec
+}
+
 // SetConfigAddressPrefix sets the account prefix in the SDK global config.
+// The client doesn't rely on the global config, it's kept for the code that
+// uses the prefix of the global config with the addresses of the chain, like
+// the SDK msgs GetSigners method.
 func (c Client) SetConfigAddressPrefix() {
-	// TODO find a better way if possible.
-	// https://github.com/ignite/cli/issues/2744
 	mconf.Lock()
 	defer mconf.Unlock()
 	config := sdktypes.GetConfig()
 	config.SetBech32PrefixForAccount(c.addressPrefix, c.addressPrefix+"pub")
 }
 
+// validateMsgs validates the msgs before they are signed.
+// The SDK msgs decode their addresses with the prefix of the SDK global config,
+// so the account prefix of the client is set in the global config while the msgs
+// are validated and the previous prefix is restored right after. The config is
+// only locked during the validation, which doesn't make any request to the node.
+func (c Client) validateMsgs(msgs ...sdktypes.Msg) error {
+	mconf.Lock()
+	defer mconf.Unlock()
+
+	config := sdktypes.GetConfig()
+	prefix, pubPrefix := config.GetBech32AccountAddrPrefix(), config.GetBech32AccountPubPrefix()
+	if prefix != c.addressPrefix {
+		config.SetBech32PrefixForAccount(c.addressPrefix, c.addressPrefix+"pub")
+		defer config.SetBech32PrefixForAccount(prefix, pubPrefix)
+	}
+
+	for _, msg := range msgs {
+		if err := msg.ValidateBasic(); err != nil {
+			return errors.WithStack(err)
+		}
+	}
+
+	return nil
+}
+
 // Response of your broadcasted transaction.
 type Response struct {
 	Codec codec.Codec
@@ -526,16 +647,6 @@ func (c Client) Status(ctx context.Context) (*ctypes.ResultStatus, error) {
 	return c.RPC.Status(ctx)
 }
 
-// protects sdktypes.Config.
-var mconf sync.Mutex
-
-func (c Client) lockBech32Prefix() (unlockFn func()) {
-	mconf.Lock()
-	config := sdktypes.GetConfig()
-	config.SetBech32PrefixForAccount(c.addressPrefix, c.addressPrefix+"pub")
-	return mconf.Unlock
-}
-
 func (c Client) BroadcastTx(ctx context.Context, account cosmosaccount.Account, msgs ...sdktypes.Msg) (Response, error) {
 	txService, err := c.CreateTx(ctx, account, msgs...)
 	if err != nil {
@@ -545,9 +656,7 @@ func (c Client) BroadcastTx(ctx context.Context, account cosmosaccount.Account,
 	return txService.Broadcast(ctx)
 }
 
-func (c Client) CreateTx(goCtx context.Context, account cosmosaccount.Account, msgs ...sdktypes.Msg) (TxService, error) {
-	defer c.lockBech32Prefix()()
-
+func (c Client) CreateTx(goCtx context.Context, account cosmosaccount.Account, msgs ...sdktypes.Msg) (_ TxService, err error) {
 	if c.useFaucet && !c.generateOnly {
 		addr, err := account.Address(c.addressPrefix)
 		if err != nil {
@@ -571,6 +680,12 @@ func (c Client) CreateTx(goCtx context.Context, account cosmosaccount.Account, m
 	if err != nil {
 		return TxService{}, err
 	}
+	defer func() {
+		// Give back the sequence when the tx can't be created
+		if err != nil {
+			c.releaseSequence(sdkaddr, txf)
+		}
+	}()
 
 	var gas uint64
 	if c.gas != "" && c.gas != GasAuto {
@@ -581,7 +696,7 @@ func (c Client) CreateTx(goCtx context.Context, account cosmosaccount.Account, m
 	} else {
 		_, gas, err = c.gasometer.CalculateGas(ctx, txf, msgs...)
 		if err != nil {
-			return TxService{}, errors.WithStack(err)
+			return TxService{}, errors.WithStack(newSimulationError(err))
 		}
 		// the simulated gas can vary from the actual gas needed for a real transaction
 		// we add an amount to ensure sufficient gas is provided
@@ -658,46 +773,134 @@ func (c Client) GetBlockTXs(ctx context.Context, height int64) (txs []TX, err er
 	return txs, nil
 }
 
+// DefaultCollectPollInterval is the default time to wait before checking for new blocks
+// when transactions are collected in follow mode.
+const DefaultCollectPollInterval = time.Second
+
+type collectTXsOptions struct {
+	follow       bool
+	pollInterval time.Duration
+}
+
+// CollectTXsOption configures the collection of transactions.
+type CollectTXsOption func(*collectTXsOptions)
+
+// CollectFollow keeps collecting the transactions of the new blocks once
+// the latest block height is reached, until the context is canceled.
+func CollectFollow() CollectTXsOption {
+	return func(o *collectTXsOptions) {
+		o.follow = true
+	}
+}
+
+// CollectPollInterval sets the time to wait before checking for new blocks in follow mode.
+func CollectPollInterval(d time.Duration) CollectTXsOption {
+	return func(o *collectTXsOptions) {
+		o.pollInterval = d
+	}
+}
+
+// BlockTXs contains the transactions of a block.
+type BlockTXs struct {
+	// Height is the height of the block.
+	Height int64
+
+	// TXs are the transactions of the block.
+	TXs []TX
+}
+
 // CollectTXs collects transactions from multiple consecutive blocks.
 // Transactions from a single block are send to the channel only if all transactions
 // from that block are collected successfully.
 // Blocks are traversed sequentially starting from a height until the latest block height
 // available at the moment this method is called.
+// When the follow mode is enabled the new blocks are polled after the latest block height
+// is reached and their transactions are sent to the channel until the context is canceled.
 // The channel might contain the transactions collected successfully up until that point
 // when an error is returned.
-func (c Client) CollectTXs(ctx context.Context, fromHeight int64, tc chan<- []TX) error {
+func (c Client) CollectTXs(ctx context.Context, fromHeight int64, tc chan<- []TX, options ...CollectTXsOption) error {
 	defer close(tc)
 
-	latestHeight, err := c.LatestBlockHeight(ctx)
-	if err != nil {
-		return fmt.Errorf("failed to fetch latest block height: %w", err)
+	return c.collectBlockTXs(ctx, fromHeight, func(b BlockTXs) error {
+		// Ignore blocks without transactions
+		if b.TXs == nil {
+			return nil
+		}
+
+		// Make sure that collection finishes if the context
+		// is done when the transactions channel is full
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case tc <- b.TXs:
+			return nil
+		}
+	}, options...)
+}
+
+// CollectBlockTXs collects the transactions of multiple consecutive blocks.
+// It works like CollectTXs but every block is sent to the channel with its height,
+// including the blocks without transactions, so the collection can be resumed
+// after the latest collected block.
+func (c Client) CollectBlockTXs(ctx context.Context, fromHeight int64, bc chan<- BlockTXs, options ...CollectTXsOption) error {
+	defer close(bc)
+
+	return c.collectBlockTXs(ctx, fromHeight, func(b BlockTXs) error {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case bc <- b:
+			return nil
+		}
+	}, options...)
+}
+
+func (c Client) collectBlockTXs(
+	ctx context.Context,
+	fromHeight int64,
+	send func(BlockTXs) error,
+	options ...CollectTXsOption,
+) error {
+	o := collectTXsOptions{
+		pollInterval: DefaultCollectPollInterval,
+	}
+
+	for _, apply := range options {
+		apply(&o)
 	}
 
 	if fromHeight == 0 {
 		fromHeight = 1
 	}
 
-	for height := fromHeight; height <= latestHeight; height++ {
-		txs, err := c.GetBlockTXs(ctx, height)
+	for {
+		latestHeight, err := c.LatestBlockHeight(ctx)
 		if err != nil {
-			return err
+			return fmt.Errorf("failed to fetch latest block height: %w", err)
 		}
 
-		// Ignore blocks without transactions
-		if txs == nil {
-			continue
+		for ; fromHeight <= latestHeight; fromHeight++ {
+			txs, err := c.GetBlockTXs(ctx, fromHeight)
+			if err != nil {
+				return err
+			}
+
+			if err := send(BlockTXs{Height: fromHeight, TXs: txs}); err != nil {
+				return err
+			}
 		}
 
-		// Make sure that collection finishes if the context
-		// is done when the transactions channel is full
+		if !o.follow {
+			return nil
+		}
+
+		// Wait for new blocks
 		select {
 		case <-ctx.Done():
 			return ctx.Err()
-		case tc <- txs:
+		case <-time.After(o.pollInterval):
 		}
 	}
-
-	return nil
 }
 
 // makeSureAccountHasTokens makes sure the address has a positive balance.
@@ -751,17 +954,85 @@ func handleBroadcastResult(resp *sdktypes.TxResponse, err error) error {
 	}
 
 	if resp.Code > 0 {
-		return errors.Errorf("error code: '%d' msg: '%s'", resp.Code, resp.RawLog)
+		return errors.WithStack(TxError{Code: resp.Code, Codespace: resp.Codespace, RawLog: resp.RawLog})
 	}
 	return nil
 }
 
+// TxError is returned when a transaction is rejected by the node or fails to execute.
+// Other errors, like the network ones, are returned when the node doesn't return
+// a response for the transaction.
+type TxError struct {
+	// Code is the error code of the transaction response.
+	Code uint32
+
+	// Codespace is the namespace of the error code.
+	Codespace string
+
+	// RawLog is the log of the transaction response, which contains the error.
+	RawLog string
+}
+
+func (e TxError) Error() string {
+	return fmt.Sprintf("error code: '%d' msg: '%s'", e.Code, e.RawLog)
+}
+
+// SimulationError is returned when the node fails to simulate a transaction to
+// estimate its gas, for example when one of its messages fails to execute.
+// Like TxError, it means that the transaction would fail if it was broadcasted.
+type SimulationError struct {
+	// Err is the error returned by the node.
+	Err error
+}
+
+func (e SimulationError) Error() string {
+	return fmt.Sprintf("transaction simulation failed: %s", e.Err)
+}
+
+func (e SimulationError) Unwrap() error {
+	return e.Err
+}
+
+// newSimulationError returns a SimulationError when the error is returned by the
+// node for the simulation. The other errors, like the network ones, are returned
+// unchanged because they don't tell anything about the transaction.
+func newSimulationError(err error) error {
+	s, ok := status.FromError(err)
+	if !ok {
+		return err
+	}
+
+	switch s.Code() {
+	case codes.Unavailable, codes.Canceled, codes.DeadlineExceeded:
+		return err
+	}
+
+	return SimulationError{Err: err}
+}
+
+// releaseSequence gives back the sequence of the tx factory to the sequence
+// manager, when the client has one, for a tx that didn't enter the mempool.
+func (c Client) releaseSequence(addr sdktypes.AccAddress, txf tx.Factory) {
+	if c.sequences != nil {
+		c.sequences.Release(addr, txf.Sequence())
+	}
+}
+
 func (c *Client) prepareFactory(clientCtx client.Context) (tx.Factory, error) {
 	var (
 		from = clientCtx.GetFromAddress()
 		txf  = c.TxFactory
 	)
 
+	if c.sequences != nil {
+		num, seq, err := c.sequences.Next(clientCtx, c.accountRetriever, from)
+		if err != nil {
+			return txf, err
+		}
+
+		return txf.WithAccountNumber(num).WithSequence(seq), nil
+	}
+
 	if err := c.accountRetriever.EnsureExists(clientCtx, from); err != nil {
 		return txf, errors.WithStack(err)
 	}
@@ -4,12 +4,14 @@ import (
 	"bufio"
 	"context"
 	"encoding/hex"
+	"encoding/json"
 	"fmt"
 	"io"
 	"os"
 	"testing"
 	"time"
 
+	abci "github.com/cometbft/cometbft/abci/types"
 	"github.com/cometbft/cometbft/p2p"
 	ctypes "github.com/cometbft/cometbft/rpc/core/types"
 	tmtypes "github.com/cometbft/cometbft/types"
@@ -21,6 +23,8 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 
 	"github.com/ignite/cli/ignite/pkg/cosmosaccount"
 	"github.com/ignite/cli/ignite/pkg/cosmosclient"
@@ -235,6 +239,48 @@ func TestClientWaitForTx(t *testing.T) {
 	}
 }
 
+func TestClientWaitForTxs(t *testing.T) {
+	var (
+		ctx            = context.Background()
+		hashes         = []string{"abcd", "ef01"}
+		hashBytes1, _  = hex.DecodeString(hashes[0])
+		hashBytes2, _  = hex.DecodeString(hashes[1])
+		notFound       = errors.New("tx not found")
+		currentHeight  = &ctypes.ResultStatus{SyncInfo: ctypes.SyncInfo{LatestBlockHeight: 1}}
+		nextHeight     = &ctypes.ResultStatus{SyncInfo: ctypes.SyncInfo{LatestBlockHeight: 2}}
+		expectedResult = []*sdktypes.TxResponse{
+			{TxHash: "ABCD", Height: 1},
+			{TxHash: "EF01", Height: 2, Code: 42},
+		}
+	)
+
+	// Arrange
+	c := newClient(t, func(s suite) {
+		// The first tx is found immediately, the second one after 1 block
+		s.rpcClient.EXPECT().Tx(ctx, hashBytes1, false).
+			Return(&ctypes.ResultTx{Hash: hashBytes1, Height: 1}, nil).Once()
+		s.rpcClient.EXPECT().Tx(ctx, hashBytes2, false).Return(nil, notFound).Once()
+		s.rpcClient.EXPECT().Status(ctx).Return(currentHeight, nil).Once()
+		s.rpcClient.EXPECT().Status(ctx).Return(nextHeight, nil).Once()
+		s.rpcClient.EXPECT().Tx(ctx, hashBytes2, false).
+			Return(&ctypes.ResultTx{
+				Hash:     hashBytes2,
+				Height:   2,
+				TxResult: abci.ResponseDeliverTx{Code: 42},
+			}, nil).Once()
+	})
+
+	// Act
+	res, err := c.WaitForTxs(ctx, hashes...)
+
+	// Assert
+	require.NoError(t, err)
+	require.Len(t, res, len(expectedResult))
+	for i, r := range res {
+		require.Equal(t, expectedResult[i], r.TxResponse)
+	}
+}
+
 func TestClientAccount(t *testing.T) {
 	var (
 		accountName = "bob"
@@ -439,6 +485,16 @@ func TestClientCreateTx(t *testing.T) {
 				s.expectPrepareFactory(sdkaddr)
 			},
 		},
+		{
+			name: "ok: with unregistered message type",
+			msg: &unregisteredMsg{
+				MsgSend: banktypes.MsgSend{FromAddress: "from"},
+			},
+			expectedJSONTx: `{"body":{"messages":[{"@type":"/test.MsgUnregistered","from_address":"from"}],"memo":"","timeout_height":"0","extension_options":[],"non_critical_extension_options":[]},"auth_info":{"signer_infos":[],"fee":{"amount":[],"gas_limit":"300000","payer":"","granter":""},"tip":null},"signatures":[]}`,
+			setup: func(s suite) {
+				s.expectPrepareFactory(sdkaddr)
+			},
+		},
 		{
 			name: "ok: with faucet enabled, account balance is high enough",
 			opts: []cosmosclient.Option{
@@ -570,6 +626,34 @@ func TestClientCreateTx(t *testing.T) {
 					Return(nil, 42, nil)
 			},
 		},
+		{
+			name: "fail: simulation error from the node",
+			opts: []cosmosclient.Option{
+				cosmosclient.WithGas("auto"),
+			},
+			msg:           &banktypes.MsgSend{FromAddress: "from"},
+			expectedError: "transaction simulation failed: rpc error: code = Unknown desc = already liked",
+			setup: func(s suite) {
+				s.expectPrepareFactory(sdkaddr)
+				s.gasometer.EXPECT().
+					CalculateGas(mock.Anything, mock.Anything, mock.Anything).
+					Return(nil, 0, status.Error(codes.Unknown, "already liked"))
+			},
+		},
+		{
+			name: "fail: node unavailable during simulation",
+			opts: []cosmosclient.Option{
+				cosmosclient.WithGas("auto"),
+			},
+			msg:           &banktypes.MsgSend{FromAddress: "from"},
+			expectedError: "rpc error: code = Unavailable desc = connection refused",
+			setup: func(s suite) {
+				s.expectPrepareFactory(sdkaddr)
+				s.gasometer.EXPECT().
+					CalculateGas(mock.Anything, mock.Anything, mock.Anything).
+					Return(nil, 0, status.Error(codes.Unavailable, "connection refused"))
+			},
+		},
 		{
 			name: "ok: with gas adjustment",
 			opts: []cosmosclient.Option{
@@ -631,6 +715,19 @@ func TestClientCreateTx(t *testing.T) {
 	}
 }
 
+// unregisteredMsg is a message with a type that is not registered in the client codec.
+type unregisteredMsg struct {
+	banktypes.MsgSend
+}
+
+func (*unregisteredMsg) XXX_MessageName() string {
+	return "test.MsgUnregistered"
+}
+
+func (m *unregisteredMsg) MarshalJSON() ([]byte, error) {
+	return json.Marshal(map[string]string{"from_address": m.FromAddress})
+}
+
 func TestGetBlockTXs(t *testing.T) {
 	m := testutil.NewTendermintClientMock(t)
 	ctx := context.Background()
@@ -862,6 +959,60 @@ func TestCollectTXs(t *testing.T) {
 	require.False(t, open, "expected transaction channel to be closed")
 }
 
+func TestCollectBlockTXs(t *testing.T) {
+	m := testutil.NewTendermintClientMock(t)
+	ctx := context.Background()
+
+	// Mock the Status RPC endpoint to report that only two blocks exists
+	m.On("Status", ctx).Return(&ctypes.ResultStatus{
+		SyncInfo: ctypes.SyncInfo{LatestBlockHeight: 2},
+	}, nil)
+
+	// Mock the Block RPC endpoint to return two blocks
+	b1 := createTestBlock(1)
+	b2 := createTestBlock(2)
+
+	m.On("Block", ctx, &b1.Height).Return(&ctypes.ResultBlock{Block: &b1}, nil)
+	m.On("Block", ctx, &b2.Height).Return(&ctypes.ResultBlock{Block: &b2}, nil)
+
+	// Mock the TxSearch RPC endpoint to return a transaction
+	// for the first block and none for the second one
+	page := 1
+	perPage := 30
+	r1 := ctypes.ResultTxSearch{
+		Txs:        []*ctypes.ResultTx{{}},
+		TotalCount: 1,
+	}
+
+	m.On("TxSearch", ctx, "tx.height=1", false, &page, &perPage, "asc").Return(&r1, nil)
+	m.On("TxSearch", ctx, "tx.height=2", false, &page, &perPage, "asc").Return(&ctypes.ResultTxSearch{}, nil)
+
+	// Create a cosmos client that uses the RPC mock
+	client := cosmosclient.Client{RPC: m}
+
+	// Collect all blocks
+	bc := make(chan cosmosclient.BlockTXs)
+	blocks := make(chan []cosmosclient.BlockTXs)
+
+	go func() {
+		var collected []cosmosclient.BlockTXs
+		for b := range bc {
+			collected = append(collected, b)
+		}
+
+		blocks <- collected
+	}()
+
+	err := client.CollectBlockTXs(ctx, 1, bc)
+
+	// Assert
+	require.NoError(t, err)
+	require.Equal(t, []cosmosclient.BlockTXs{
+		{Height: 1, TXs: []cosmosclient.TX{{BlockTime: b1.Time, Raw: r1.Txs[0]}}},
+		{Height: 2},
+	}, <-blocks)
+}
+
 func TestCollectTXsWithStatusError(t *testing.T) {
 	m := testutil.NewTendermintClientMock(t)
 
@@ -1035,3 +1186,60 @@ func createTestBlock(height int64) tmtypes.Block {
 		},
 	}
 }
+
+func TestCollectTXsFollow(t *testing.T) {
+	m := testutil.NewTendermintClientMock(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// Mock the Status RPC endpoint to report a new block after the first call
+	m.On("Status", ctx).Return(&ctypes.ResultStatus{
+		SyncInfo: ctypes.SyncInfo{LatestBlockHeight: 1},
+	}, nil).Once()
+	m.On("Status", ctx).Return(&ctypes.ResultStatus{
+		SyncInfo: ctypes.SyncInfo{LatestBlockHeight: 2},
+	}, nil)
+
+	// Mock the Block RPC endpoint to return two blocks
+	b1 := createTestBlock(1)
+	b2 := createTestBlock(2)
+
+	m.On("Block", ctx, &b1.Height).Return(&ctypes.ResultBlock{Block: &b1}, nil)
+	m.On("Block", ctx, &b2.Height).Return(&ctypes.ResultBlock{Block: &b2}, nil)
+
+	// Mock the TxSearch RPC endpoint to return a transaction for each block
+	page := 1
+	perPage := 30
+	r := ctypes.ResultTxSearch{
+		Txs:        []*ctypes.ResultTx{{}},
+		TotalCount: 1,
+	}
+
+	m.On("TxSearch", ctx, "tx.height=1", false, &page, &perPage, "asc").Return(&r, nil)
+	m.On("TxSearch", ctx, "tx.height=2", false, &page, &perPage, "asc").Return(&r, nil)
+
+	// Create a cosmos client that uses the RPC mock
+	client := cosmosclient.Client{RPC: m}
+
+	// Collect transactions until the transactions from the new block are received
+	tc := make(chan []cosmosclient.TX)
+	blockTimes := make(chan []time.Time)
+
+	go func() {
+		var times []time.Time
+		for txs := range tc {
+			times = append(times, txs[0].BlockTime)
+			if len(times) == 2 {
+				cancel()
+			}
+		}
+
+		blockTimes <- times
+	}()
+
+	err := client.CollectTXs(ctx, 1, tc, cosmosclient.CollectFollow(), cosmosclient.CollectPollInterval(time.Millisecond))
+
+	// Assert
+	require.ErrorIs(t, err, context.Canceled)
+	require.Equal(t, []time.Time{b1.Time, b2.Time}, <-blockTimes)
+}
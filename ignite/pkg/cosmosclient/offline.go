@@ -0,0 +1,461 @@
+package cosmosclient
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/cosmos/cosmos-sdk/client/tx"
+	kmultisig "github.com/cosmos/cosmos-sdk/crypto/keys/multisig"
+	"github.com/cosmos/cosmos-sdk/crypto/types/multisig"
+	sdktypes "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/tx/signing"
+	authsigning "github.com/cosmos/cosmos-sdk/x/auth/signing"
+	"github.com/pkg/errors"
+
+	"github.com/ignite/cli/ignite/pkg/cosmosaccount"
+)
+
+type signTxOptions struct {
+	offline       bool
+	accountNumber uint64
+	sequence      uint64
+	multisig      sdktypes.AccAddress
+}
+
+// SignTxOption configures how a transaction is signed.
+type SignTxOption func(*signTxOptions)
+
+// SignTxOffline signs the transaction with the account number and sequence
+// instead of requesting them to the node.
+func SignTxOffline(accountNumber, sequence uint64) SignTxOption {
+	return func(o *signTxOptions) {
+		o.offline = true
+		o.accountNumber = accountNumber
+		o.sequence = sequence
+	}
+}
+
+// SignTxMultisig signs the transaction on behalf of the multisig account with
+// the address. The account number and sequence of the multisig account are
+// used, and the transaction is signed with the legacy amino JSON sign mode
+// which is the only one supported by multisig accounts.
+func SignTxMultisig(addr sdktypes.AccAddress) SignTxOption {
+	return func(o *signTxOptions) {
+		o.multisig = addr
+	}
+}
+
+// MsgJSONDecoder decodes a message, encoded as JSON, which type is not registered
+// in the client codec. The JSON object of the message doesn't contain the "@type" field.
+type MsgJSONDecoder func(typeURL string, bz []byte) (sdktypes.Msg, error)
+
+type decodeTxOptions struct {
+	decodeMsg MsgJSONDecoder
+}
+
+// DecodeTxOption configures how a transaction is decoded.
+type DecodeTxOption func(*decodeTxOptions)
+
+// DecodeTxMsgs decodes the messages which type is not registered in the client
+// codec with the decoder, like the dynamic messages of the modules of an app.
+func DecodeTxMsgs(decodeMsg MsgJSONDecoder) DecodeTxOption {
+	return func(o *decodeTxOptions) {
+		o.decodeMsg = decodeMsg
+	}
+}
+
+// DecodeTxJSON decodes a transaction encoded as JSON, like the ones created
+// with the generate only option, so it can be signed and broadcasted.
+// The messages of the transaction must be registered in the client codec,
+// unless they are decoded with the DecodeTxMsgs option.
+func (c Client) DecodeTxJSON(bz []byte, options ...DecodeTxOption) (client.TxBuilder, error) {
+	var o decodeTxOptions
+	for _, apply := range options {
+		apply(&o)
+	}
+
+	tx, msgs, err := c.splitUnregisteredMsgs(bz, o.decodeMsg)
+	if err != nil {
+		return nil, err
+	}
+
+	decodedTx, err := c.context.TxConfig.TxJSONDecoder()(tx)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	txBuilder, err := c.context.TxConfig.WrapTxBuilder(decodedTx)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	if msgs != nil {
+		if err := txBuilder.SetMsgs(msgs...); err != nil {
+			return nil, errors.WithStack(err)
+		}
+	}
+
+	return txBuilder, nil
+}
+
+// splitUnregisteredMsgs decodes the messages of the transaction when some of them
+// are not registered in the client codec and returns the transaction without them.
+// The transaction is returned unchanged, with nil messages, when all the messages
+// are registered, so the codec decodes them.
+func (c Client) splitUnregisteredMsgs(bz []byte, decodeMsg MsgJSONDecoder) ([]byte, []sdktypes.Msg, error) {
+	var (
+		tx   map[string]json.RawMessage
+		body map[string]json.RawMessage
+		raws []json.RawMessage
+	)
+	if err := json.Unmarshal(bz, &tx); err != nil {
+		return nil, nil, errors.WithStack(err)
+	}
+
+	if err := json.Unmarshal(tx["body"], &body); err != nil {
+		return nil, nil, errors.Wrap(err, "invalid transaction body")
+	}
+
+	if err := json.Unmarshal(body["messages"], &raws); err != nil {
+		return nil, nil, errors.Wrap(err, "invalid transaction messages")
+	}
+
+	var (
+		msgs         = make([]sdktypes.Msg, len(raws))
+		unregistered bool
+	)
+	for i, raw := range raws {
+		var fields map[string]json.RawMessage
+		if err := json.Unmarshal(raw, &fields); err != nil {
+			return nil, nil, errors.Wrap(err, "invalid transaction message")
+		}
+
+		var typeURL string
+		if err := json.Unmarshal(fields["@type"], &typeURL); err != nil {
+			return nil, nil, errors.Wrap(err, "invalid transaction message type")
+		}
+
+		if _, err := c.context.InterfaceRegistry.Resolve(typeURL); err == nil {
+			if err := c.context.Codec.UnmarshalInterfaceJSON(raw, &msgs[i]); err != nil {
+				return nil, nil, errors.WithStack(err)
+			}
+
+			continue
+		}
+
+		if decodeMsg == nil {
+			return nil, nil, errors.Errorf("cannot decode the message %s, its type is not registered", typeURL)
+		}
+
+		delete(fields, "@type")
+
+		msgJSON, err := json.Marshal(fields)
+		if err != nil {
+			return nil, nil, errors.WithStack(err)
+		}
+
+		if msgs[i], err = decodeMsg(typeURL, msgJSON); err != nil {
+			return nil, nil, err
+		}
+
+		unregistered = true
+	}
+
+	if !unregistered {
+		return bz, nil, nil
+	}
+
+	// The messages are set after the transaction is decoded
+	body["messages"] = json.RawMessage("[]")
+
+	var err error
+	if tx["body"], err = json.Marshal(body); err != nil {
+		return nil, nil, errors.WithStack(err)
+	}
+
+	if bz, err = json.Marshal(tx); err != nil {
+		return nil, nil, errors.WithStack(err)
+	}
+
+	return bz, msgs, nil
+}
+
+// EncodeTxJSON encodes a transaction as JSON.
+// The messages with types that are not registered in the client codec, like
+// dynamic messages, are encoded using their own JSON encoding.
+func (c Client) EncodeTxJSON(txBuilder client.TxBuilder) ([]byte, error) {
+	encode := c.context.TxConfig.TxJSONEncoder()
+	bz, err := encode(txBuilder.GetTx())
+	if err == nil {
+		return bz, nil
+	}
+
+	msgs := txBuilder.GetTx().GetMsgs()
+	msgsJSON := make([]json.RawMessage, len(msgs))
+	for i, msg := range msgs {
+		if msgsJSON[i], err = c.encodeMsgJSON(msg); err != nil {
+			return nil, err
+		}
+	}
+
+	// Encode the transaction without messages and add the encoded messages to its body
+	if err := txBuilder.SetMsgs(); err != nil {
+		return nil, errors.WithStack(err)
+	}
+	defer txBuilder.SetMsgs(msgs...) //nolint:errcheck
+
+	if bz, err = encode(txBuilder.GetTx()); err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	var tx map[string]json.RawMessage
+	if err := json.Unmarshal(bz, &tx); err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	var body map[string]json.RawMessage
+	if err := json.Unmarshal(tx["body"], &body); err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	if body["messages"], err = json.Marshal(msgsJSON); err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	if tx["body"], err = json.Marshal(body); err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	return json.Marshal(tx)
+}
+
+func (c Client) encodeMsgJSON(msg sdktypes.Msg) (json.RawMessage, error) {
+	if bz, err := c.context.Codec.MarshalInterfaceJSON(msg); err == nil {
+		return bz, nil
+	}
+
+	m, ok := msg.(json.Marshaler)
+	if !ok {
+		return nil, errors.Errorf("cannot encode %s message to JSON", sdktypes.MsgTypeURL(msg))
+	}
+
+	bz, err := m.MarshalJSON()
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(bz, &fields); err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	if fields["@type"], err = json.Marshal(sdktypes.MsgTypeURL(msg)); err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	return json.Marshal(fields)
+}
+
+// SignTx signs a transaction with the account and adds the signature to the
+// signatures of the transaction.
+func (c Client) SignTx(account cosmosaccount.Account, txBuilder client.TxBuilder, options ...SignTxOption) error {
+	var o signTxOptions
+	for _, apply := range options {
+		apply(&o)
+	}
+
+	addr := o.multisig
+	if addr == nil {
+		var err error
+		if addr, err = account.Record.GetAddress(); err != nil {
+			return errors.WithStack(err)
+		}
+	}
+
+	txf, err := c.signTxFactory(addr, o)
+	if err != nil {
+		return err
+	}
+
+	if o.multisig != nil {
+		txf = txf.WithSignMode(signing.SignMode_SIGN_MODE_LEGACY_AMINO_JSON)
+	}
+
+	if err := c.signer.Sign(txf, account.Name, txBuilder, false); err != nil {
+		return errors.WithStack(err)
+	}
+
+	return nil
+}
+
+// EncodeSignatureJSON encodes the signature of the account in the transaction as JSON.
+// It's used to share the signatures of a multisig account transaction, which
+// are combined with MultisignTx.
+func (c Client) EncodeSignatureJSON(account cosmosaccount.Account, txBuilder client.TxBuilder) ([]byte, error) {
+	pk, err := account.Record.GetPubKey()
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	sigs, err := txBuilder.GetTx().GetSignaturesV2()
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	for _, sig := range sigs {
+		if sig.PubKey != nil && sig.PubKey.Equals(pk) {
+			bz, err := c.context.TxConfig.MarshalSignatureJSON([]signing.SignatureV2{sig})
+			if err != nil {
+				return nil, errors.WithStack(err)
+			}
+
+			return bz, nil
+		}
+	}
+
+	return nil, errors.Errorf("transaction is not signed by %q", account.Name)
+}
+
+// MultisignTx combines the signatures of the keys of a multisig account and sets
+// them as the signature of the transaction. The signatures are encoded as JSON,
+// like the ones returned by EncodeSignatureJSON, and they are verified before
+// being combined.
+func (c Client) MultisignTx(
+	account cosmosaccount.Account,
+	txBuilder client.TxBuilder,
+	signatures [][]byte,
+	options ...SignTxOption,
+) error {
+	var o signTxOptions
+	for _, apply := range options {
+		apply(&o)
+	}
+
+	pk, err := account.Record.GetPubKey()
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	multisigPK, ok := pk.(*kmultisig.LegacyAminoPubKey)
+	if !ok {
+		return errors.Errorf("%q is not a multisig account", account.Name)
+	}
+
+	txf, err := c.signTxFactory(sdktypes.AccAddress(pk.Address()), o)
+	if err != nil {
+		return err
+	}
+
+	var (
+		signModeHandler = c.context.TxConfig.SignModeHandler()
+		multisigSig     = multisig.NewMultisig(len(multisigPK.PubKeys))
+	)
+	for _, bz := range signatures {
+		sigs, err := c.unmarshalSignaturesJSON(bz)
+		if err != nil {
+			return err
+		}
+
+		for _, sig := range sigs {
+			if sig.PubKey == nil {
+				return errors.New("invalid signature: the public key of the signer is missing")
+			}
+
+			addr, err := c.addressCodec.BytesToString(sig.PubKey.Address())
+			if err != nil {
+				return errors.WithStack(err)
+			}
+
+			signerData := authsigning.SignerData{
+				Address:       addr,
+				ChainID:       txf.ChainID(),
+				AccountNumber: txf.AccountNumber(),
+				Sequence:      txf.Sequence(),
+				PubKey:        sig.PubKey,
+			}
+			err = authsigning.VerifySignature(sig.PubKey, signerData, sig.Data, signModeHandler, txBuilder.GetTx())
+			if err != nil {
+				return errors.Errorf("invalid signature of %s", addr)
+			}
+
+			if err := multisig.AddSignatureV2(multisigSig, sig, multisigPK.GetPubKeys()); err != nil {
+				return errors.WithStack(err)
+			}
+		}
+	}
+
+	if n := len(multisigSig.Signatures); n < int(multisigPK.Threshold) {
+		return errors.Errorf("not enough signatures: %d of %d required", n, multisigPK.Threshold)
+	}
+
+	err = txBuilder.SetSignatures(signing.SignatureV2{
+		PubKey:   multisigPK,
+		Data:     multisigSig,
+		Sequence: txf.Sequence(),
+	})
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	return nil
+}
+
+// unmarshalSignaturesJSON decodes signatures encoded as JSON. The signatures are
+// checked first because the SDK decoder panics when the public key or the data of
+// a signature is missing, like in a hand-edited signature file.
+func (c Client) unmarshalSignaturesJSON(bz []byte) ([]signing.SignatureV2, error) {
+	var descs signing.SignatureDescriptors
+	if err := c.context.Codec.UnmarshalJSON(bz, &descs); err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	for _, d := range descs.Signatures {
+		if d.PublicKey == nil {
+			return nil, errors.New("invalid signature: the public key of the signer is missing")
+		}
+
+		if d.Data == nil {
+			return nil, errors.New("invalid signature: the signature data is missing")
+		}
+	}
+
+	sigs, err := c.context.TxConfig.UnmarshalSignatureJSON(bz)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	return sigs, nil
+}
+
+// BroadcastSignedTx broadcasts a signed transaction and waits for it to be committed.
+func (c Client) BroadcastSignedTx(ctx context.Context, txBuilder client.TxBuilder) (Response, error) {
+	txBytes, err := c.context.TxConfig.TxEncoder()(txBuilder.GetTx())
+	if err != nil {
+		return Response{}, errors.WithStack(err)
+	}
+
+	resp, err := c.context.BroadcastTx(txBytes)
+	if err := handleBroadcastResult(resp, err); err != nil {
+		return Response{}, err
+	}
+
+	return c.waitForTxResponse(ctx, resp.TxHash)
+}
+
+// signTxFactory returns a tx factory with the account number and sequence of
+// the account that signs, which are requested to the node unless they are
+// set with the options.
+func (c Client) signTxFactory(addr sdktypes.AccAddress, o signTxOptions) (txf tx.Factory, err error) {
+	txf = c.TxFactory
+	if o.offline {
+		return txf.WithAccountNumber(o.accountNumber).WithSequence(o.sequence), nil
+	}
+
+	num, seq, err := c.accountRetriever.GetAccountNumberSequence(c.context, addr)
+	if err != nil {
+		return txf, errors.WithStack(err)
+	}
+
+	return txf.WithAccountNumber(num).WithSequence(seq), nil
+}
@@ -0,0 +1,170 @@
+package cosmosclient_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+
+	sdktypes "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/tx/signing"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ignite/cli/ignite/pkg/cosmosaccount"
+	"github.com/ignite/cli/ignite/pkg/cosmosclient"
+)
+
+func TestClientOfflineMultisign(t *testing.T) {
+	// Arrange
+	c, err := cosmosclient.New(
+		context.Background(),
+		cosmosclient.WithChainID("mychain"),
+		cosmosclient.WithKeyringBackend(cosmosaccount.KeyringMemory),
+	)
+	require.NoError(t, err)
+	require.Equal(t, "mychain", c.Context().ChainID)
+
+	var accounts []cosmosaccount.Account
+	for _, name := range []string{"alice", "bob", "carol"} {
+		acc, _, err := c.AccountRegistry.Create(name)
+		require.NoError(t, err)
+		accounts = append(accounts, acc)
+	}
+
+	multisig, err := c.AccountRegistry.CreateMultisig("treasury", []string{"alice", "bob", "carol"}, 2)
+	require.NoError(t, err)
+
+	multisigAddr, err := multisig.Record.GetAddress()
+	require.NoError(t, err)
+
+	msg := banktypes.NewMsgSend(multisigAddr, multisigAddr, sdktypes.NewCoins(sdktypes.NewInt64Coin("token", 1)))
+	unsignedTx, err := c.TxFactory.BuildUnsignedTx(msg)
+	require.NoError(t, err)
+
+	txJSON, err := c.EncodeTxJSON(unsignedTx)
+	require.NoError(t, err)
+
+	sign := func(acc cosmosaccount.Account, sequence uint64) []byte {
+		txBuilder, err := c.DecodeTxJSON(txJSON)
+		require.NoError(t, err)
+
+		err = c.SignTx(acc, txBuilder, cosmosclient.SignTxOffline(5, sequence), cosmosclient.SignTxMultisig(multisigAddr))
+		require.NoError(t, err)
+
+		sig, err := c.EncodeSignatureJSON(acc, txBuilder)
+		require.NoError(t, err)
+
+		return sig
+	}
+
+	aliceSig := sign(accounts[0], 1)
+	carolSig := sign(accounts[2], 1)
+	bobWrongSig := sign(accounts[1], 2)
+
+	// Remove the public key like in a hand-edited signature file
+	var carolSigJSON map[string][]map[string]interface{}
+	require.NoError(t, json.Unmarshal(carolSig, &carolSigJSON))
+	delete(carolSigJSON["signatures"][0], "public_key")
+	carolSigWithoutPubKey, err := json.Marshal(carolSigJSON)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name          string
+		signatures    [][]byte
+		expectedError string
+	}{
+		{
+			name:       "ok",
+			signatures: [][]byte{aliceSig, carolSig},
+		},
+		{
+			name:          "fail: not enough signatures",
+			signatures:    [][]byte{aliceSig},
+			expectedError: "not enough signatures: 1 of 2 required",
+		},
+		{
+			name:          "fail: signature without public key",
+			signatures:    [][]byte{aliceSig, carolSigWithoutPubKey},
+			expectedError: "invalid signature: the public key of the signer is missing",
+		},
+		{
+			name:          "fail: signature with wrong sequence",
+			signatures:    [][]byte{aliceSig, bobWrongSig},
+			expectedError: "invalid signature of",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			txBuilder, err := c.DecodeTxJSON(txJSON)
+			require.NoError(t, err)
+
+			// Act
+			err = c.MultisignTx(multisig, txBuilder, tt.signatures, cosmosclient.SignTxOffline(5, 1))
+
+			// Assert
+			if tt.expectedError != "" {
+				require.ErrorContains(t, err, tt.expectedError)
+				return
+			}
+			require.NoError(t, err)
+
+			sigs, err := txBuilder.GetTx().GetSignaturesV2()
+			require.NoError(t, err)
+			require.Len(t, sigs, 1)
+			require.Equal(t, multisigAddr, sdktypes.AccAddress(sigs[0].PubKey.Address()))
+			require.EqualValues(t, 1, sigs[0].Sequence)
+			require.IsType(t, &signing.MultiSignatureData{}, sigs[0].Data)
+		})
+	}
+}
+
+func TestClientDecodeTxJSONUnregisteredMsg(t *testing.T) {
+	// Arrange
+	c, err := cosmosclient.New(
+		context.Background(),
+		cosmosclient.WithChainID("mychain"),
+		cosmosclient.WithKeyringBackend(cosmosaccount.KeyringMemory),
+	)
+	require.NoError(t, err)
+
+	alice, _, err := c.AccountRegistry.Create("alice")
+	require.NoError(t, err)
+
+	addr, err := alice.Record.GetAddress()
+	require.NoError(t, err)
+
+	msg := banktypes.NewMsgSend(addr, addr, sdktypes.NewCoins(sdktypes.NewInt64Coin("token", 1)))
+	unsignedTx, err := c.TxFactory.BuildUnsignedTx(msg)
+	require.NoError(t, err)
+	unsignedTx.SetMemo("hello")
+
+	txJSON, err := c.EncodeTxJSON(unsignedTx)
+	require.NoError(t, err)
+
+	// Use a message type that is not registered in the client codec
+	txJSON = bytes.Replace(txJSON, []byte(sdktypes.MsgTypeURL(msg)), []byte("/mars.mars.MsgSend"), 1)
+
+	var decodedTypeURL string
+	decodeMsg := func(typeURL string, bz []byte) (sdktypes.Msg, error) {
+		decodedTypeURL = typeURL
+
+		var m banktypes.MsgSend
+		if err := c.Context().Codec.UnmarshalJSON(bz, &m); err != nil {
+			return nil, err
+		}
+
+		return &m, nil
+	}
+
+	// Act
+	_, err = c.DecodeTxJSON(txJSON)
+	txBuilder, decodeErr := c.DecodeTxJSON(txJSON, cosmosclient.DecodeTxMsgs(decodeMsg))
+
+	// Assert
+	require.EqualError(t, err, "cannot decode the message /mars.mars.MsgSend, its type is not registered")
+	require.NoError(t, decodeErr)
+	require.Equal(t, "/mars.mars.MsgSend", decodedTypeURL)
+	require.Equal(t, []sdktypes.Msg{msg}, txBuilder.GetTx().GetMsgs())
+	require.Equal(t, "hello", txBuilder.GetTx().GetMemo())
+}
@@ -0,0 +1,154 @@
+package cosmosclient
+
+import (
+	"regexp"
+	"sort"
+	"strconv"
+	"sync"
+
+	"github.com/cosmos/cosmos-sdk/client"
+	sdktypes "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/pkg/errors"
+)
+
+// maxSequenceRetries is the maximum number of times a transaction is signed
+// again and broadcasted after an account sequence mismatch.
+const maxSequenceRetries = 5
+
+// sequenceMismatchRe matches the sequence expected by the node in the log of a
+// transaction rejected because of an account sequence mismatch.
+var sequenceMismatchRe = regexp.MustCompile(`account sequence mismatch, expected (\d+)`)
+
+// SequenceManager hands out the sequences of the accounts locally so many
+// transactions can be created for the same account without waiting for the
+// previous ones to be committed. The account number and sequence are fetched
+// from the node the first time an account is used and after each resync.
+// A SequenceManager is safe for concurrent use and can be shared between clients.
+type SequenceManager struct {
+	mu       sync.Mutex
+	accounts map[string]*accountSequence
+}
+
+type accountSequence struct {
+	number   uint64
+	sequence uint64
+
+	// released are the sequences given back before the next sequence, they
+	// are handed out first to fill the gaps.
+	released []uint64
+}
+
+// NewSequenceManager creates a new sequence manager.
+func NewSequenceManager() *SequenceManager {
+	return &SequenceManager{
+		accounts: make(map[string]*accountSequence),
+	}
+}
+
+// Next returns the account number and the next sequence to use for the account.
+func (m *SequenceManager) Next(
+	clientCtx client.Context,
+	retriever client.AccountRetriever,
+	addr sdktypes.AccAddress,
+) (number, sequence uint64, err error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	acc, ok := m.accounts[string(addr)]
+	if !ok {
+		number, sequence, err := retriever.GetAccountNumberSequence(clientCtx, addr)
+		if err != nil {
+			return 0, 0, errors.WithStack(err)
+		}
+
+		acc = &accountSequence{number: number, sequence: sequence}
+		m.accounts[string(addr)] = acc
+	}
+
+	if len(acc.released) > 0 {
+		sequence, acc.released = acc.released[0], acc.released[1:]
+		return acc.number, sequence, nil
+	}
+
+	sequence = acc.sequence
+	acc.sequence++
+
+	return acc.number, sequence, nil
+}
+
+// Release gives back a sequence of the account that was handed out for a
+// transaction that didn't enter the mempool, like a transaction that failed to
+// be built, signed or checked by the node. The sequence is handed out again by
+// the next call to Next so the following transactions of the account don't
+// fail because of a gap in the sequences.
+func (m *SequenceManager) Release(addr sdktypes.AccAddress, sequence uint64) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	acc, ok := m.accounts[string(addr)]
+	if !ok || sequence >= acc.sequence {
+		return
+	}
+
+	if sequence == acc.sequence-1 {
+		acc.sequence--
+
+		// The released sequences that precede it are now the next ones too
+		for n := len(acc.released); n > 0 && acc.released[n-1] == acc.sequence-1; n-- {
+			acc.sequence--
+			acc.released = acc.released[:n-1]
+		}
+
+		return
+	}
+
+	i := sort.Search(len(acc.released), func(i int) bool { return acc.released[i] >= sequence })
+	if i < len(acc.released) && acc.released[i] == sequence {
+		return
+	}
+
+	acc.released = append(acc.released, 0)
+	copy(acc.released[i+1:], acc.released[i:])
+	acc.released[i] = sequence
+}
+
+// Resync sets the next sequence of the account to the sequence expected by the
+// node. The sequence is fetched again from the node on the next use of the
+// account when the expected sequence is unknown.
+func (m *SequenceManager) Resync(addr sdktypes.AccAddress, expected uint64, known bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	acc, ok := m.accounts[string(addr)]
+	if !ok || !known {
+		delete(m.accounts, string(addr))
+		return
+	}
+
+	acc.sequence = expected
+	acc.released = nil
+}
+
+// isSequenceMismatch checks if a transaction was rejected because of an account sequence mismatch.
+func isSequenceMismatch(resp *sdktypes.TxResponse) bool {
+	return resp != nil &&
+		resp.Codespace == sdkerrors.ErrWrongSequence.Codespace() &&
+		resp.Code == sdkerrors.ErrWrongSequence.ABCICode()
+}
+
+// expectedSequence returns the sequence expected by the node from the log of a
+// transaction rejected because of an account sequence mismatch.
+func expectedSequence(log string) (uint64, bool) {
+	m := sequenceMismatchRe.FindStringSubmatch(log)
+	if m == nil {
+		return 0, false
+	}
+
+	sequence, err := strconv.ParseUint(m[1], 10, 64)
+	if err != nil {
+		return 0, false
+	}
+
+	return sequence, true
+}
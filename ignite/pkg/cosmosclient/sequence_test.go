@@ -0,0 +1,52 @@
+package cosmosclient_test
+
+import (
+	"testing"
+
+	sdktypes "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ignite/cli/ignite/pkg/cosmosclient"
+	"github.com/ignite/cli/ignite/pkg/cosmosclient/mocks"
+)
+
+func TestSequenceManagerRelease(t *testing.T) {
+	// Arrange
+	addr := sdktypes.AccAddress("address_____________")
+	retriever := mocks.NewAccountRetriever(t)
+	retriever.EXPECT().
+		GetAccountNumberSequence(mock.Anything, addr).
+		Return(1, 10, nil).Once()
+
+	c := newClient(t, nil)
+	m := cosmosclient.NewSequenceManager()
+	next := func() uint64 {
+		_, seq, err := m.Next(c.Context(), retriever, addr)
+		require.NoError(t, err)
+		return seq
+	}
+
+	for i := uint64(10); i < 15; i++ {
+		require.Equal(t, i, next())
+	}
+
+	// Act
+	m.Release(addr, 11)
+	m.Release(addr, 12)
+	m.Release(addr, 14)
+	m.Release(addr, 20)
+
+	// Assert
+	require.Equal(t, uint64(11), next())
+	require.Equal(t, uint64(12), next())
+	require.Equal(t, uint64(14), next())
+	require.Equal(t, uint64(15), next())
+
+	// Releasing the last sequences gives back the ones released before
+	m.Release(addr, 13)
+	m.Release(addr, 15)
+	m.Release(addr, 14)
+	require.Equal(t, uint64(13), next())
+	require.Equal(t, uint64(14), next())
+}
@@ -28,50 +28,93 @@ func (s TxService) Gas() uint64 {
 // again. Note that this may still end with the same error if the amount is
 // greater than the amount dumped by the faucet.
 func (s TxService) Broadcast(ctx context.Context) (Response, error) {
-	defer s.client.lockBech32Prefix()()
+	resp, err := s.broadcast()
+	if err != nil {
+		return Response{}, err
+	}
 
-	// validate msgs.
-	for _, msg := range s.txBuilder.GetTx().GetMsgs() {
-		if err := msg.ValidateBasic(); err != nil {
-			return Response{}, errors.WithStack(err)
-		}
+	return s.client.waitForTxResponse(ctx, resp.TxHash)
+}
+
+// BroadcastAsync signs and broadcasts this tx and returns as soon as the tx
+// enters the mempool of the node, without waiting for it to be committed.
+// The response only contains the result of the tx check, use Client.WaitForTxs
+// to wait for the results of many txs at once.
+func (s TxService) BroadcastAsync() (Response, error) {
+	resp, err := s.broadcast()
+	if err != nil {
+		return Response{}, err
 	}
 
-	accountName := s.clientContext.GetFromName()
-	if err := s.client.signer.Sign(s.txFactory, accountName, s.txBuilder, true); err != nil {
-		return Response{}, errors.WithStack(err)
+	return Response{
+		Codec:      s.clientContext.Codec,
+		TxResponse: resp,
+	}, nil
+}
+
+// broadcast signs and broadcasts this tx and returns when the tx is checked by the node.
+// When the client has a sequence manager, the tx is signed again with a new sequence
+// and broadcasted again when it's rejected because of an account sequence mismatch,
+// and its sequence is given back to the manager when it doesn't enter the mempool.
+func (s TxService) broadcast() (*sdktypes.TxResponse, error) {
+	var (
+		from = s.clientContext.GetFromAddress()
+		txf  = s.txFactory
+	)
+
+	if err := s.client.validateMsgs(s.txBuilder.GetTx().GetMsgs()...); err != nil {
+		s.client.releaseSequence(from, txf)
+		return nil, err
 	}
 
-	txBytes, err := s.clientContext.TxConfig.TxEncoder()(s.txBuilder.GetTx())
-	if err != nil {
-		return Response{}, errors.WithStack(err)
+	for i := 0; ; i++ {
+		resp, err := s.signAndBroadcast(txf)
+		mismatch := isSequenceMismatch(resp)
+		if s.client.sequences == nil || !mismatch || i == maxSequenceRetries {
+			if err := handleBroadcastResult(resp, err); err != nil {
+				if mismatch && s.client.sequences != nil {
+					expected, ok := expectedSequence(resp.RawLog)
+					s.client.sequences.Resync(from, expected, ok)
+				} else {
+					s.client.releaseSequence(from, txf)
+				}
+
+				return nil, err
+			}
+
+			return resp, nil
+		}
+
+		// Resync the account sequence and use the next one
+		expected, ok := expectedSequence(resp.RawLog)
+		s.client.sequences.Resync(from, expected, ok)
+
+		num, seq, err := s.client.sequences.Next(s.clientContext, s.client.accountRetriever, from)
+		if err != nil {
+			return nil, err
+		}
+
+		txf = txf.WithAccountNumber(num).WithSequence(seq)
 	}
+}
 
-	resp, err := s.clientContext.BroadcastTx(txBytes)
-	if err := handleBroadcastResult(resp, err); err != nil {
-		return Response{}, err
+func (s TxService) signAndBroadcast(txf tx.Factory) (*sdktypes.TxResponse, error) {
+	accountName := s.clientContext.GetFromName()
+	if err := s.client.signer.Sign(txf, accountName, s.txBuilder, true); err != nil {
+		return nil, errors.WithStack(err)
 	}
 
-	res, err := s.client.WaitForTx(ctx, resp.TxHash)
+	txBytes, err := s.clientContext.TxConfig.TxEncoder()(s.txBuilder.GetTx())
 	if err != nil {
-		return Response{}, err
+		return nil, errors.WithStack(err)
 	}
-	// NOTE(tb) second and third parameters are omitted:
-	// - second parameter represents the tx and should be of type sdktypes.Any,
-	// but it is very ugly to decode, not sure if it's worth it (see sdk code
-	// x/auth/query.go method makeTxResult)
-	// - third parameter represents the timestamp of the tx, which must be
-	// fetched from the block itself. So it requires another API call to
-	// fetch the block from res.Height, not sure if it's worth it too.
-	resp = sdktypes.NewResponseResultTx(res, nil, "")
 
-	return Response{
-		Codec:      s.clientContext.Codec,
-		TxResponse: resp,
-	}, handleBroadcastResult(resp, err)
+	return s.clientContext.BroadcastTx(txBytes)
 }
 
 // EncodeJSON encodes the transaction as a json string.
+// The messages with types that are not registered in the client codec, like
+// dynamic messages, are encoded using their own JSON encoding.
 func (s TxService) EncodeJSON() ([]byte, error) {
-	return s.client.context.TxConfig.TxJSONEncoder()(s.txBuilder.GetTx())
+	return s.client.EncodeTxJSON(s.txBuilder)
 }
@@ -3,10 +3,12 @@ package cosmosclient_test
 import (
 	"context"
 	"encoding/hex"
+	"strings"
 	"testing"
 
 	abci "github.com/cometbft/cometbft/abci/types"
 	ctypes "github.com/cometbft/cometbft/rpc/core/types"
+	"github.com/cosmos/cosmos-sdk/client/tx"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	sdktypes "github.com/cosmos/cosmos-sdk/types"
 	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
@@ -43,6 +45,11 @@ func TestTxServiceBroadcast(t *testing.T) {
 			sdktypes.NewCoin("token", sdktypes.NewIntFromUint64(1)),
 		),
 	}
+	marsMsg := &banktypes.MsgSend{
+		FromAddress: sdktypes.MustBech32ifyAddressBytes("mars", sdkaddr),
+		ToAddress:   sdktypes.MustBech32ifyAddressBytes("mars", sdkaddr),
+		Amount:      msg.Amount,
+	}
 	tests := []struct {
 		name             string
 		msg              sdk.Msg
@@ -51,6 +58,31 @@ func TestTxServiceBroadcast(t *testing.T) {
 		expectedError    string
 		setup            func(suite)
 	}{
+		{
+			name: "ok: msg with the address prefix of the client",
+			msg:  marsMsg,
+			opts: []cosmosclient.Option{
+				cosmosclient.WithAddressPrefix("mars"),
+			},
+			expectedResponse: &sdktypes.TxResponse{
+				TxHash: txHashStr,
+			},
+			setup: func(s suite) {
+				s.expectPrepareFactory(sdkaddr)
+				s.signer.EXPECT().
+					Sign(mock.Anything, "bob", mock.Anything, true).
+					Return(nil)
+				s.rpcClient.EXPECT().
+					BroadcastTxSync(mock.Anything, mock.Anything).
+					Return(&ctypes.ResultBroadcastTx{
+						Hash: txHash,
+					}, nil)
+				s.rpcClient.EXPECT().Tx(goCtx, txHash, false).
+					Return(&ctypes.ResultTx{
+						Hash: txHash,
+					}, nil)
+			},
+		},
 		{
 			name:          "fail: invalid msg",
 			msg:           &banktypes.MsgSend{},
@@ -207,6 +239,145 @@ func TestTxServiceBroadcast(t *testing.T) {
 			require.NoError(t, err)
 			require.Equal(t, ctx.Codec, res.Codec)
 			require.Equal(t, tt.expectedResponse, res.TxResponse)
+			// The prefix of the SDK global config is not changed by the client
+			require.Equal(t, "cosmos", sdktypes.GetConfig().GetBech32AccountAddrPrefix())
 		})
 	}
 }
+
+func TestTxServiceBroadcastAsyncSequenceMismatch(t *testing.T) {
+	var (
+		goCtx       = context.Background()
+		accountName = "bob"
+		passphrase  = "passphrase"
+		txHash      = []byte{1, 2, 3}
+	)
+	r, err := cosmosaccount.NewInMemory()
+	require.NoError(t, err)
+	a, _, err := r.Create(accountName)
+	require.NoError(t, err)
+	key, err := r.Export(accountName, passphrase)
+	require.NoError(t, err)
+	sdkaddr, err := a.Record.GetAddress()
+	require.NoError(t, err)
+	msg := &banktypes.MsgSend{
+		FromAddress: sdkaddr.String(),
+		ToAddress:   "cosmos1k8e50d2d8xkdfw9c4et3m45llh69e7xzw6uzga",
+		Amount: sdktypes.NewCoins(
+			sdktypes.NewCoin("token", sdktypes.NewIntFromUint64(1)),
+		),
+	}
+	withSequence := func(seq uint64) interface{} {
+		return mock.MatchedBy(func(txf tx.Factory) bool { return txf.Sequence() == seq })
+	}
+
+	// Arrange
+	c := newClient(t, func(s suite) {
+		// The sequence is fetched once for both txs
+		s.accountRetriever.EXPECT().
+			GetAccountNumberSequence(mock.Anything, sdkaddr).
+			Return(1, 2, nil).Once()
+
+		// The second tx is broadcasted first and is rejected because of its sequence
+		s.signer.EXPECT().
+			Sign(withSequence(3), accountName, mock.Anything, true).
+			Return(nil).Once()
+		s.rpcClient.EXPECT().
+			BroadcastTxSync(mock.Anything, mock.Anything).
+			Return(&ctypes.ResultBroadcastTx{
+				Code:      sdkerrors.ErrWrongSequence.ABCICode(),
+				Codespace: sdkerrors.ErrWrongSequence.Codespace(),
+				Log:       "account sequence mismatch, expected 2, got 3: incorrect account sequence",
+			}, nil).Once()
+
+		// It is signed again with the expected sequence
+		s.signer.EXPECT().
+			Sign(withSequence(2), accountName, mock.Anything, true).
+			Return(nil).Once()
+		s.rpcClient.EXPECT().
+			BroadcastTxSync(mock.Anything, mock.Anything).
+			Return(&ctypes.ResultBroadcastTx{Hash: txHash}, nil).Once()
+	}, cosmosclient.WithSequenceManager(cosmosclient.NewSequenceManager()))
+	account, err := c.AccountRegistry.Import(accountName, key, passphrase)
+	require.NoError(t, err)
+
+	_, err = c.CreateTx(goCtx, account, msg)
+	require.NoError(t, err)
+	txService, err := c.CreateTx(goCtx, account, msg)
+	require.NoError(t, err)
+
+	// Act
+	res, err := txService.BroadcastAsync()
+
+	// Assert
+	require.NoError(t, err)
+	require.Equal(t, hex.EncodeToString(txHash), strings.ToLower(res.TxHash))
+}
+
+func TestTxServiceBroadcastAsyncReleaseSequence(t *testing.T) {
+	var (
+		goCtx       = context.Background()
+		accountName = "bob"
+		passphrase  = "passphrase"
+		txHash      = []byte{1, 2, 3}
+	)
+	r, err := cosmosaccount.NewInMemory()
+	require.NoError(t, err)
+	a, _, err := r.Create(accountName)
+	require.NoError(t, err)
+	key, err := r.Export(accountName, passphrase)
+	require.NoError(t, err)
+	sdkaddr, err := a.Record.GetAddress()
+	require.NoError(t, err)
+	msg := &banktypes.MsgSend{
+		FromAddress: sdkaddr.String(),
+		ToAddress:   "cosmos1k8e50d2d8xkdfw9c4et3m45llh69e7xzw6uzga",
+		Amount: sdktypes.NewCoins(
+			sdktypes.NewCoin("token", sdktypes.NewIntFromUint64(1)),
+		),
+	}
+	withSequence := func(seq uint64) interface{} {
+		return mock.MatchedBy(func(txf tx.Factory) bool { return txf.Sequence() == seq })
+	}
+
+	// Arrange
+	c := newClient(t, func(s suite) {
+		s.accountRetriever.EXPECT().
+			GetAccountNumberSequence(mock.Anything, sdkaddr).
+			Return(1, 2, nil).Once()
+
+		// The first tx is rejected by the node, so it doesn't enter the mempool
+		s.signer.EXPECT().
+			Sign(withSequence(2), accountName, mock.Anything, true).
+			Return(nil).Once()
+		s.rpcClient.EXPECT().
+			BroadcastTxSync(mock.Anything, mock.Anything).
+			Return(&ctypes.ResultBroadcastTx{Code: 42, Log: "oups"}, nil).Once()
+
+		// The next tx uses the sequence of the rejected one
+		s.signer.EXPECT().
+			Sign(withSequence(2), accountName, mock.Anything, true).
+			Return(nil).Once()
+		s.rpcClient.EXPECT().
+			BroadcastTxSync(mock.Anything, mock.Anything).
+			Return(&ctypes.ResultBroadcastTx{Hash: txHash}, nil).Once()
+	}, cosmosclient.WithSequenceManager(cosmosclient.NewSequenceManager()))
+	account, err := c.AccountRegistry.Import(accountName, key, passphrase)
+	require.NoError(t, err)
+
+	failedTx, err := c.CreateTx(goCtx, account, msg)
+	require.NoError(t, err)
+	_, err = failedTx.BroadcastAsync()
+	require.EqualError(t, err, "error code: '42' msg: 'oups'")
+	require.ErrorAs(t, err, &cosmosclient.TxError{})
+
+	txService, err := c.CreateTx(goCtx, account, msg)
+	require.NoError(t, err)
+
+	// Act
+	res, err := txService.BroadcastAsync()
+
+	// Assert
+	require.NoError(t, err)
+	require.Equal(t, hex.EncodeToString(txHash), strings.ToLower(res.TxHash))
+}
@@ -0,0 +1,190 @@
+package cosmosfaucet
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math/bits"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultChallengeTTL is the time a challenge can be solved before it expires.
+	DefaultChallengeTTL = time.Minute * 5
+
+	// MaxChallengeDifficulty is the highest difficulty that challenges can have.
+	// Each extra bit doubles the time required to solve a challenge.
+	MaxChallengeDifficulty = 32
+
+	// challengeSize is the number of random bytes used to create a challenge.
+	challengeSize = 16
+
+	// challengeKeySize is the number of random bytes of the key used to sign the challenges.
+	challengeKeySize = 32
+)
+
+var (
+	// ErrChallengeRequired is returned when the transfer request doesn't include a solved challenge.
+	ErrChallengeRequired = errors.New("a solved challenge is required")
+
+	// ErrChallengeInvalid is returned when the challenge is unknown, expired or was already used.
+	ErrChallengeInvalid = errors.New("challenge is invalid or expired")
+
+	// ErrChallengeSolution is returned when the challenge solution is not valid.
+	ErrChallengeSolution = errors.New("challenge solution is not valid")
+)
+
+// Challenge is a hashcash-style proof-of-work puzzle that clients must
+// solve before requesting tokens when the challenge mode is enabled.
+//
+// The challenge is solved by finding a solution for which the SHA-256 hash of
+// "<challenge>:<solution>" starts with, at least, the difficulty number of zero bits.
+type Challenge struct {
+	// Challenge is the random value to solve.
+	Challenge string `json:"challenge"`
+
+	// Difficulty is the number of leading zero bits that the hash of the solution must have.
+	Difficulty uint `json:"difficulty"`
+
+	// ExpiresAt is the time when the challenge expires.
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// SolveChallenge finds a solution for the challenge.
+func SolveChallenge(c Challenge) string {
+	for nonce := uint64(0); ; nonce++ {
+		solution := strconv.FormatUint(nonce, 10)
+		if IsChallengeSolved(c.Challenge, solution, c.Difficulty) {
+			return solution
+		}
+	}
+}
+
+// IsChallengeSolved checks if the solution solves the challenge with the given difficulty.
+func IsChallengeSolved(challenge, solution string, difficulty uint) bool {
+	hash := sha256.Sum256([]byte(challenge + ":" + solution))
+
+	var zeros uint
+	for _, b := range hash {
+		if b == 0 {
+			zeros += 8
+			continue
+		}
+
+		zeros += uint(bits.LeadingZeros8(b))
+		break
+	}
+
+	return zeros >= difficulty
+}
+
+// challenges issues and verifies the challenges.
+//
+// Challenges are not stored when they are issued. Each challenge contains a random seed,
+// its expiration time and a MAC signed with a key known only by the faucet, so issuing
+// challenges doesn't use any resources and no client can prevent others from getting one.
+// Only the solved challenges are kept until they expire, so they can't be used twice.
+type challenges struct {
+	mu         sync.Mutex
+	key        []byte
+	difficulty uint
+	ttl        time.Duration
+	used       map[string]time.Time
+}
+
+func newChallenges(difficulty uint, ttl time.Duration) (*challenges, error) {
+	key := make([]byte, challengeKeySize)
+	if _, err := rand.Read(key); err != nil {
+		return nil, err
+	}
+
+	return &challenges{
+		key:        key,
+		difficulty: difficulty,
+		ttl:        ttl,
+		used:       make(map[string]time.Time),
+	}, nil
+}
+
+// issue creates a new challenge.
+// The challenge has the format "<seed>.<expiration>.<mac>".
+func (c *challenges) issue() (Challenge, error) {
+	b := make([]byte, challengeSize)
+	if _, err := rand.Read(b); err != nil {
+		return Challenge{}, err
+	}
+
+	seed := hex.EncodeToString(b)
+	expiresAt := time.Now().Add(c.ttl).Truncate(time.Second)
+	expiration := strconv.FormatInt(expiresAt.Unix(), 10)
+
+	return Challenge{
+		Challenge:  fmt.Sprintf("%s.%s.%s", seed, expiration, c.mac(seed, expiration)),
+		Difficulty: c.difficulty,
+		ExpiresAt:  expiresAt,
+	}, nil
+}
+
+// mac returns the MAC that signs the seed and the expiration of a challenge with its difficulty.
+func (c *challenges) mac(seed, expiration string) string {
+	h := hmac.New(sha256.New, c.key)
+	fmt.Fprintf(h, "%s.%d.%s", seed, c.difficulty, expiration)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// verify checks that the challenge was issued by the faucet and the solution is valid.
+// Challenges can only be used once.
+func (c *challenges) verify(challenge, solution string) error {
+	if challenge == "" || solution == "" {
+		return ErrChallengeRequired
+	}
+
+	parts := strings.Split(challenge, ".")
+	if len(parts) != 3 {
+		return ErrChallengeInvalid
+	}
+
+	seed, expiration, mac := parts[0], parts[1], parts[2]
+	if !hmac.Equal([]byte(mac), []byte(c.mac(seed, expiration))) {
+		return ErrChallengeInvalid
+	}
+
+	unix, err := strconv.ParseInt(expiration, 10, 64)
+	if err != nil {
+		return ErrChallengeInvalid
+	}
+
+	now := time.Now()
+	expiresAt := time.Unix(unix, 0)
+	if now.After(expiresAt) {
+		return ErrChallengeInvalid
+	}
+
+	if !IsChallengeSolved(challenge, solution, c.difficulty) {
+		return ErrChallengeSolution
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	// discard the expired challenges because they can't be used anymore
+	for k, t := range c.used {
+		if now.After(t) {
+			delete(c.used, k)
+		}
+	}
+
+	if _, ok := c.used[challenge]; ok {
+		return ErrChallengeInvalid
+	}
+
+	c.used[challenge] = expiresAt
+
+	return nil
+}
@@ -0,0 +1,139 @@
+package cosmosfaucet
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestChallengesVerify(t *testing.T) {
+	c, err := newChallenges(4, time.Minute)
+	require.NoError(t, err)
+
+	other, err := newChallenges(4, time.Minute)
+	require.NoError(t, err)
+
+	expired, err := newChallenges(4, -time.Minute)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name          string
+		challenges    *challenges
+		challenge     func(Challenge) string
+		solution      func(Challenge) string
+		expectedError error
+	}{
+		{
+			name:       "solved challenge",
+			challenges: c,
+		},
+		{
+			name:          "missing solution",
+			challenges:    c,
+			solution:      func(Challenge) string { return "" },
+			expectedError: ErrChallengeRequired,
+		},
+		{
+			name:       "wrong solution",
+			challenges: c,
+			solution: func(ch Challenge) string {
+				for i := 0; ; i++ {
+					s := strings.Repeat("x", i+1)
+					if !IsChallengeSolved(ch.Challenge, s, ch.Difficulty) {
+						return s
+					}
+				}
+			},
+			expectedError: ErrChallengeSolution,
+		},
+		{
+			name:       "challenge issued by another faucet",
+			challenges: c,
+			challenge: func(Challenge) string {
+				ch, err := other.issue()
+				require.NoError(t, err)
+				return ch.Challenge
+			},
+			expectedError: ErrChallengeInvalid,
+		},
+		{
+			name:          "tampered expiration",
+			challenges:    c,
+			challenge:     func(ch Challenge) string { return strings.Replace(ch.Challenge, ".", ".9", 1) },
+			expectedError: ErrChallengeInvalid,
+		},
+		{
+			name:          "malformed challenge",
+			challenges:    c,
+			challenge:     func(Challenge) string { return "f00" },
+			expectedError: ErrChallengeInvalid,
+		},
+		{
+			name:          "expired challenge",
+			challenges:    expired,
+			expectedError: ErrChallengeInvalid,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			ch, err := tt.challenges.issue()
+			require.NoError(t, err)
+
+			challenge, solution := ch.Challenge, ""
+			if tt.challenge != nil {
+				challenge = tt.challenge(ch)
+			}
+			if tt.solution != nil {
+				solution = tt.solution(ch)
+			} else {
+				solution = SolveChallenge(Challenge{Challenge: challenge, Difficulty: ch.Difficulty})
+			}
+
+			// Act
+			err = tt.challenges.verify(challenge, solution)
+
+			// Assert
+			if tt.expectedError != nil {
+				require.ErrorIs(t, err, tt.expectedError)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestChallengesVerifyOnce(t *testing.T) {
+	// Arrange
+	c, err := newChallenges(4, time.Minute)
+	require.NoError(t, err)
+
+	ch, err := c.issue()
+	require.NoError(t, err)
+
+	solution := SolveChallenge(ch)
+	require.NoError(t, c.verify(ch.Challenge, solution))
+
+	// Act
+	err = c.verify(ch.Challenge, solution)
+
+	// Assert
+	require.ErrorIs(t, err, ErrChallengeInvalid)
+}
+
+func TestChallengesIssueIsStateless(t *testing.T) {
+	// Arrange
+	c, err := newChallenges(4, time.Minute)
+	require.NoError(t, err)
+
+	// Act
+	for i := 0; i < 100; i++ {
+		_, err := c.issue()
+		require.NoError(t, err)
+	}
+
+	// Assert
+	require.Empty(t, c.used)
+}
@@ -0,0 +1,92 @@
+package cosmosfaucet_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ignite/cli/ignite/pkg/chaincmd"
+	chaincmdrunner "github.com/ignite/cli/ignite/pkg/chaincmd/runner"
+	"github.com/ignite/cli/ignite/pkg/cosmosfaucet"
+)
+
+func TestSolveChallenge(t *testing.T) {
+	c := cosmosfaucet.Challenge{
+		Challenge:  "f00",
+		Difficulty: 12,
+	}
+
+	solution := cosmosfaucet.SolveChallenge(c)
+
+	require.True(t, cosmosfaucet.IsChallengeSolved(c.Challenge, solution, c.Difficulty))
+	require.False(t, cosmosfaucet.IsChallengeSolved(c.Challenge, solution, 64))
+}
+
+func TestServeHTTPChallenge(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	runner, err := chaincmdrunner.New(ctx, chaincmd.New("appd"))
+	require.NoError(t, err)
+
+	f, err := cosmosfaucet.New(ctx, runner, cosmosfaucet.ChainID("test"), cosmosfaucet.ChallengeDifficulty(8))
+	require.NoError(t, err)
+
+	res := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, "/challenge", nil)
+
+	// Act
+	f.ServeHTTP(res, req)
+
+	// Assert
+	require.Equal(t, http.StatusOK, res.Result().StatusCode)
+
+	var c cosmosfaucet.Challenge
+	require.NoError(t, json.NewDecoder(res.Body).Decode(&c))
+	require.NotEmpty(t, c.Challenge)
+	require.EqualValues(t, 8, c.Difficulty)
+
+	// Act: request tokens without solving the challenge
+	body := strings.NewReader(`{"address":"cosmos1uzv4v9g9xln2qx2vtqhz99yxum33calja5vruz","challenge":"` + c.Challenge + `"}`)
+	res = httptest.NewRecorder()
+	req, _ = http.NewRequest(http.MethodPost, "/", body)
+	f.ServeHTTP(res, req)
+
+	// Assert
+	require.Equal(t, http.StatusForbidden, res.Result().StatusCode)
+}
+
+func TestServeHTTPChallengeDisabled(t *testing.T) {
+	// Arrange
+	f := cosmosfaucet.Faucet{}
+	res := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, "/challenge", nil)
+
+	// Act
+	f.ServeHTTP(res, req)
+
+	// Assert
+	require.Equal(t, http.StatusNotFound, res.Result().StatusCode)
+}
+
+func TestNewWithChallengeDifficultyTooHigh(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	runner, err := chaincmdrunner.New(ctx, chaincmd.New("appd"))
+	require.NoError(t, err)
+
+	// Act
+	_, err = cosmosfaucet.New(
+		ctx,
+		runner,
+		cosmosfaucet.ChainID("test"),
+		cosmosfaucet.ChallengeDifficulty(cosmosfaucet.MaxChallengeDifficulty+1),
+	)
+
+	// Assert
+	require.EqualError(t, err, "challenge difficulty can't be higher than 32")
+}
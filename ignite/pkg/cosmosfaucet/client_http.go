@@ -77,3 +77,26 @@ func (c HTTPClient) FaucetInfo(ctx context.Context) (FaucetInfoResponse, error)
 	err = json.NewDecoder(hres.Body).Decode(&res)
 	return res, err
 }
+
+// Challenge requests a new challenge that must be solved before requesting
+// tokens when the faucet challenge mode is enabled.
+func (c HTTPClient) Challenge(ctx context.Context) (Challenge, error) {
+	hreq, err := http.NewRequestWithContext(ctx, http.MethodGet, c.addr+"/challenge", nil)
+	if err != nil {
+		return Challenge{}, err
+	}
+
+	hres, err := http.DefaultClient.Do(hreq)
+	if err != nil {
+		return Challenge{}, err
+	}
+	defer hres.Body.Close()
+
+	if hres.StatusCode != http.StatusOK {
+		return Challenge{}, errors.New(http.StatusText(hres.StatusCode))
+	}
+
+	var res Challenge
+	err = json.NewDecoder(hres.Body).Decode(&res)
+	return res, err
+}
@@ -4,6 +4,7 @@ package cosmosfaucet
 import (
 	"context"
 	"errors"
+	"fmt"
 	"time"
 
 	sdkmath "cosmossdk.io/math"
@@ -58,6 +59,16 @@ type Faucet struct {
 
 	limitRefreshWindow time.Duration
 
+	// ledger keeps track of the transfers to enforce the transfer limits.
+	ledger Ledger
+
+	// challengeDifficulty is the difficulty of the proof-of-work challenges.
+	// the challenge mode is enabled when the difficulty is greater than zero.
+	challengeDifficulty uint
+
+	// challenges keeps track of the challenges issued to the clients.
+	challenges *challenges
+
 	// openAPIData holds template data customizations for serving OpenAPI page & spec.
 	openAPIData openAPIData
 }
@@ -95,6 +106,24 @@ func RefreshWindow(refreshWindow time.Duration) Option {
 	}
 }
 
+// WithLedger sets the ledger used to keep track of the transfers made by the faucet.
+// By default transfers are kept in memory.
+func WithLedger(l Ledger) Option {
+	return func(f *Faucet) {
+		f.ledger = l
+	}
+}
+
+// ChallengeDifficulty enables the challenge mode which requires clients to solve a
+// proof-of-work challenge before requesting tokens. The difficulty is the number
+// of leading zero bits that the hash of the challenge solution must have and it
+// can't be higher than MaxChallengeDifficulty.
+func ChallengeDifficulty(difficulty uint) Option {
+	return func(f *Faucet) {
+		f.challengeDifficulty = difficulty
+	}
+}
+
 // ChainID adds chain id to faucet. faucet will automatically fetch when it isn't provided.
 func ChainID(id string) Option {
 	return func(f *Faucet) {
@@ -115,7 +144,7 @@ func New(ctx context.Context, ccr chaincmdrunner.Runner, options ...Option) (Fau
 		runner:      ccr,
 		accountName: DefaultAccountName,
 		coinsMax:    make(map[string]uint64),
-		openAPIData: openAPIData{"Blockchain", "http://localhost:1317"},
+		openAPIData: openAPIData{ChainID: "Blockchain", APIAddress: "http://localhost:1317"},
 	}
 
 	for _, apply := range options {
@@ -130,6 +159,24 @@ func New(ctx context.Context, ccr chaincmdrunner.Runner, options ...Option) (Fau
 		RefreshWindow(DefaultRefreshWindow)(&f)
 	}
 
+	if f.ledger == nil {
+		WithLedger(NewMemoryLedger())(&f)
+	}
+
+	if f.challengeDifficulty > MaxChallengeDifficulty {
+		return Faucet{}, fmt.Errorf("challenge difficulty can't be higher than %d", MaxChallengeDifficulty)
+	}
+
+	if f.challengeDifficulty > 0 {
+		challenges, err := newChallenges(f.challengeDifficulty, DefaultChallengeTTL)
+		if err != nil {
+			return Faucet{}, err
+		}
+
+		f.challenges = challenges
+		f.openAPIData.ChallengeDifficulty = f.challengeDifficulty
+	}
+
 	// import the account if mnemonic is provided.
 	if f.accountMnemonic != "" {
 		_, err := f.runner.AddAccount(ctx, f.accountName, f.accountMnemonic, f.coinType)
@@ -22,6 +22,10 @@ func (f Faucet) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		Handle("/info", cors.Default().Handler(http.HandlerFunc(f.faucetInfoHandler))).
 		Methods(http.MethodGet, http.MethodOptions)
 
+	router.
+		Handle("/challenge", cors.Default().Handler(http.HandlerFunc(f.faucetChallengeHandler))).
+		Methods(http.MethodGet, http.MethodOptions)
+
 	router.
 		HandleFunc("/", openapiconsole.Handler("Faucet", "openapi.yml")).
 		Methods(http.MethodGet)
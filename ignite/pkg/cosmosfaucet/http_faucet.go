@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"net"
 	"net/http"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
@@ -18,6 +19,13 @@ type TransferRequest struct {
 	// Coins that are requested.
 	// default ones used when this one isn't provided.
 	Coins []string `json:"coins"`
+
+	// Challenge is the challenge issued by the faucet.
+	// it is only required when the faucet challenge mode is enabled.
+	Challenge string `json:"challenge,omitempty"`
+
+	// Solution is the solution for the challenge.
+	Solution string `json:"solution,omitempty"`
 }
 
 func NewTransferRequest(accountAddress string, coins []string) TransferRequest {
@@ -40,6 +48,14 @@ func (f Faucet) faucetHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// verify the challenge when the challenge mode is enabled.
+	if f.challenges != nil {
+		if err := f.challenges.verify(req.Challenge, req.Solution); err != nil {
+			responseError(w, http.StatusForbidden, err)
+			return
+		}
+	}
+
 	// determine coins to transfer.
 	coins, err := f.coinsFromRequest(req)
 	if err != nil {
@@ -48,7 +64,7 @@ func (f Faucet) faucetHandler(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// try performing the transfer
-	if err := f.Transfer(r.Context(), req.AccountAddress, coins); err != nil {
+	if err := f.Transfer(r.Context(), req.AccountAddress, coins, ClientIP(clientIP(r))); err != nil {
 		if errors.Is(err, context.Canceled) {
 			return
 		}
@@ -66,15 +82,35 @@ type FaucetInfoResponse struct {
 
 	// ChainID is chain id of the chain that faucet is running for.
 	ChainID string `json:"chain_id"`
+
+	// ChallengeDifficulty is the difficulty of the challenges that must be
+	// solved to request tokens. It is zero when the challenge mode is disabled.
+	ChallengeDifficulty uint `json:"challenge_difficulty,omitempty"`
 }
 
 func (f Faucet) faucetInfoHandler(w http.ResponseWriter, _ *http.Request) {
 	xhttp.ResponseJSON(w, http.StatusOK, FaucetInfoResponse{
-		IsAFaucet: true,
-		ChainID:   f.chainID,
+		IsAFaucet:           true,
+		ChainID:             f.chainID,
+		ChallengeDifficulty: f.challengeDifficulty,
 	})
 }
 
+func (f Faucet) faucetChallengeHandler(w http.ResponseWriter, _ *http.Request) {
+	if f.challenges == nil {
+		responseError(w, http.StatusNotFound, errors.New("challenge mode is not enabled"))
+		return
+	}
+
+	challenge, err := f.challenges.issue()
+	if err != nil {
+		responseError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	xhttp.ResponseJSON(w, http.StatusOK, challenge)
+}
+
 // coinsFromRequest determines tokens to transfer from transfer request.
 func (f Faucet) coinsFromRequest(req TransferRequest) (sdk.Coins, error) {
 	if len(req.Coins) == 0 {
@@ -93,6 +129,16 @@ func (f Faucet) coinsFromRequest(req TransferRequest) (sdk.Coins, error) {
 	return coins, nil
 }
 
+// clientIP returns the IP of the client that sent the request.
+func clientIP(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+
+	return host
+}
+
 func responseSuccess(w http.ResponseWriter) {
 	xhttp.ResponseJSON(w, http.StatusOK, TransferResponse{})
 }
@@ -16,8 +16,9 @@ var bytesOpenAPISpec []byte
 var tmplOpenAPISpec = template.Must(template.New(fileNameOpenAPISpec).Parse(string(bytesOpenAPISpec)))
 
 type openAPIData struct {
-	ChainID    string
-	APIAddress string
+	ChainID             string
+	APIAddress          string
+	ChallengeDifficulty uint
 }
 
 func (f Faucet) openAPISpecHandler(w http.ResponseWriter, _ *http.Request) {
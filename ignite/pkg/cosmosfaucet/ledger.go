@@ -0,0 +1,168 @@
+package cosmosfaucet
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/ignite/cli/ignite/pkg/cache"
+)
+
+// ledgerCacheNamespace is the name of the cache namespace used to store the faucet transfers.
+const ledgerCacheNamespace = "faucet.ledger"
+
+// Transfer is a transfer of coins made by the faucet.
+type Transfer struct {
+	// Coins are the transferred coins.
+	Coins sdk.Coins
+
+	// Time is the time when the coins were transferred.
+	Time time.Time
+}
+
+// Ledger keeps track of the transfers made by the faucet to enforce the transfer limits.
+// Transfers are recorded by key, which identifies an account address or a client IP.
+type Ledger interface {
+	// Transfers returns the transfers recorded for a key that happened after a given time.
+	Transfers(ctx context.Context, key string, since time.Time) ([]Transfer, error)
+
+	// Record records a new transfer for a key.
+	// Transfers recorded for the key that happened before the prune time are discarded.
+	Record(ctx context.Context, key string, t Transfer, pruneBefore time.Time) error
+}
+
+// addressLedgerKey returns the ledger key to track the transfers to an account address.
+func addressLedgerKey(address string) string {
+	return cache.Key("address/", address)
+}
+
+// ipLedgerKey returns the ledger key to track the transfers requested from a client IP.
+func ipLedgerKey(ip string) string {
+	return cache.Key("ip/", ip)
+}
+
+// MemoryLedger is a ledger that keeps the transfers in memory.
+type MemoryLedger struct {
+	mu        sync.Mutex
+	transfers map[string][]Transfer
+}
+
+// NewMemoryLedger creates a new ledger that keeps the transfers in memory.
+func NewMemoryLedger() *MemoryLedger {
+	return &MemoryLedger{
+		transfers: make(map[string][]Transfer),
+	}
+}
+
+// Transfers returns the transfers recorded for a key that happened after a given time.
+func (l *MemoryLedger) Transfers(_ context.Context, key string, since time.Time) ([]Transfer, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	return filterTransfers(l.transfers[key], since), nil
+}
+
+// Record records a new transfer for a key.
+func (l *MemoryLedger) Record(_ context.Context, key string, t Transfer, pruneBefore time.Time) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.transfers[key] = append(filterTransfers(l.transfers[key], pruneBefore), t)
+
+	return nil
+}
+
+// ledgerEntry is the encoded transfer that is saved by the cache ledger.
+type ledgerEntry struct {
+	Coins string
+	Time  time.Time
+}
+
+// CacheLedger is a ledger that keeps the transfers in a cache storage
+// which allows the transfer limits to survive faucet restarts.
+type CacheLedger struct {
+	mu    sync.Mutex
+	cache cache.Cache[[]ledgerEntry]
+}
+
+// NewCacheLedger creates a new ledger that keeps the transfers in a cache storage.
+func NewCacheLedger(storage cache.Storage) *CacheLedger {
+	return &CacheLedger{
+		cache: cache.New[[]ledgerEntry](storage, ledgerCacheNamespace),
+	}
+}
+
+// Transfers returns the transfers recorded for a key that happened after a given time.
+func (l *CacheLedger) Transfers(_ context.Context, key string, since time.Time) ([]Transfer, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	transfers, err := l.load(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return filterTransfers(transfers, since), nil
+}
+
+// Record records a new transfer for a key.
+func (l *CacheLedger) Record(_ context.Context, key string, t Transfer, pruneBefore time.Time) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	transfers, err := l.load(key)
+	if err != nil {
+		return err
+	}
+
+	transfers = append(filterTransfers(transfers, pruneBefore), t)
+	entries := make([]ledgerEntry, len(transfers))
+	for i, t := range transfers {
+		entries[i] = ledgerEntry{
+			Coins: t.Coins.String(),
+			Time:  t.Time,
+		}
+	}
+
+	return l.cache.Put(key, entries)
+}
+
+func (l *CacheLedger) load(key string) ([]Transfer, error) {
+	entries, err := l.cache.Get(key)
+	if errors.Is(err, cache.ErrorNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	transfers := make([]Transfer, len(entries))
+	for i, e := range entries {
+		coins, err := sdk.ParseCoinsNormalized(e.Coins)
+		if err != nil {
+			return nil, err
+		}
+
+		transfers[i] = Transfer{
+			Coins: coins,
+			Time:  e.Time,
+		}
+	}
+
+	return transfers, nil
+}
+
+// filterTransfers returns the transfers that happened after a given time.
+func filterTransfers(transfers []Transfer, since time.Time) []Transfer {
+	var filtered []Transfer
+	for _, t := range transfers {
+		if t.Time.After(since) {
+			filtered = append(filtered, t)
+		}
+	}
+
+	return filtered
+}
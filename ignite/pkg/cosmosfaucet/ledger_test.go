@@ -0,0 +1,64 @@
+package cosmosfaucet_test
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ignite/cli/ignite/pkg/cache"
+	"github.com/ignite/cli/ignite/pkg/cosmosfaucet"
+)
+
+func TestLedger(t *testing.T) {
+	storage, err := cache.NewStorage(filepath.Join(t.TempDir(), "faucet.db"))
+	require.NoError(t, err)
+
+	ledgers := map[string]cosmosfaucet.Ledger{
+		"memory": cosmosfaucet.NewMemoryLedger(),
+		"cache":  cosmosfaucet.NewCacheLedger(storage),
+	}
+
+	for name, ledger := range ledgers {
+		t.Run(name, func(t *testing.T) {
+			var (
+				ctx   = context.Background()
+				now   = time.Now()
+				coins = sdk.NewCoins(sdk.NewInt64Coin("token", 10))
+				old   = cosmosfaucet.Transfer{Coins: coins, Time: now.Add(-time.Hour)}
+				last  = cosmosfaucet.Transfer{Coins: coins, Time: now}
+			)
+
+			// Act
+			err := ledger.Record(ctx, "foo", old, now.Add(-time.Hour*2))
+			require.NoError(t, err)
+			err = ledger.Record(ctx, "foo", last, now.Add(-time.Hour*2))
+			require.NoError(t, err)
+
+			// Assert: transfers are filtered by time
+			transfers, err := ledger.Transfers(ctx, "foo", now.Add(-time.Hour*2))
+			require.NoError(t, err)
+			require.Len(t, transfers, 2)
+
+			transfers, err = ledger.Transfers(ctx, "foo", now.Add(-time.Minute))
+			require.NoError(t, err)
+			require.Len(t, transfers, 1)
+			require.Equal(t, coins, transfers[0].Coins)
+
+			// Assert: old transfers are pruned on record
+			err = ledger.Record(ctx, "foo", last, now.Add(-time.Minute))
+			require.NoError(t, err)
+			transfers, err = ledger.Transfers(ctx, "foo", now.Add(-time.Hour*2))
+			require.NoError(t, err)
+			require.Len(t, transfers, 2)
+
+			// Assert: transfers are recorded by key
+			transfers, err = ledger.Transfers(ctx, "bar", now.Add(-time.Hour*2))
+			require.NoError(t, err)
+			require.Empty(t, transfers)
+		})
+	}
+}
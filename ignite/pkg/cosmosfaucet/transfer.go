@@ -8,78 +8,92 @@ import (
 	"time"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
-
-	chaincmdrunner "github.com/ignite/cli/ignite/pkg/chaincmd/runner"
 )
 
 // transferMutex is a mutex used for keeping transfer requests in a queue so checking account balance and sending tokens is atomic.
 var transferMutex = &sync.Mutex{}
 
+type transferOptions struct {
+	clientIP string
+}
+
+// TransferOption configures a transfer.
+type TransferOption func(*transferOptions)
+
+// ClientIP sets the IP of the client that requested the transfer.
+// When the IP is set, the transfer limits are also enforced for the IP.
+func ClientIP(ip string) TransferOption {
+	return func(o *transferOptions) {
+		o.clientIP = ip
+	}
+}
+
 // TotalTransferredAmount returns the total transferred amount from faucet account to toAccountAddress.
+// Only the transfers that happened within the limit refresh window are considered.
 func (f Faucet) TotalTransferredAmount(ctx context.Context, toAccountAddress, denom string) (totalAmount uint64, err error) {
-	fromAccount, err := f.runner.ShowAccount(ctx, f.accountName)
-	if err != nil {
-		return 0, err
-	}
+	return f.totalTransferred(ctx, addressLedgerKey(toAccountAddress), denom)
+}
 
-	events, err := f.runner.QueryTxEvents(ctx,
-		chaincmdrunner.NewEventSelector("message", "sender", fromAccount.Address),
-		chaincmdrunner.NewEventSelector("transfer", "recipient", toAccountAddress))
+// TotalTransferredAmountToIP returns the total transferred amount from faucet account to the client IP.
+// Only the transfers that happened within the limit refresh window are considered.
+func (f Faucet) TotalTransferredAmountToIP(ctx context.Context, ip, denom string) (totalAmount uint64, err error) {
+	return f.totalTransferred(ctx, ipLedgerKey(ip), denom)
+}
+
+func (f Faucet) totalTransferred(ctx context.Context, key, denom string) (totalAmount uint64, err error) {
+	transfers, err := f.ledger.Transfers(ctx, key, time.Now().Add(-f.limitRefreshWindow))
 	if err != nil {
 		return 0, err
 	}
 
-	for _, event := range events {
-		if event.Type == "transfer" {
-			for _, attr := range event.Attributes {
-				if attr.Key == "amount" {
-					coins, err := sdk.ParseCoinsNormalized(attr.Value)
-					if err != nil {
-						return 0, err
-					}
-
-					amount := coins.AmountOf(denom).Uint64()
-
-					if amount > 0 && time.Since(event.Time) < f.limitRefreshWindow {
-						totalAmount += amount
-					}
-				}
-			}
-		}
+	for _, t := range transfers {
+		totalAmount += t.Coins.AmountOf(denom).Uint64()
 	}
 
 	return totalAmount, nil
 }
 
 // Transfer transfers amount of tokens from the faucet account to toAccountAddress.
-func (f *Faucet) Transfer(ctx context.Context, toAccountAddress string, coins sdk.Coins) error {
+func (f *Faucet) Transfer(ctx context.Context, toAccountAddress string, coins sdk.Coins, options ...TransferOption) error {
 	transferMutex.Lock()
 	defer transferMutex.Unlock()
 
+	var o transferOptions
+	for _, apply := range options {
+		apply(&o)
+	}
+
+	keys := []string{addressLedgerKey(toAccountAddress)}
+	if o.clientIP != "" {
+		keys = append(keys, ipLedgerKey(o.clientIP))
+	}
+
 	var coinsStr []string
 
 	// check for each coin, the max transferred amount hasn't been reached
 	for _, c := range coins {
-		totalSent, err := f.TotalTransferredAmount(ctx, toAccountAddress, c.Denom)
-		if err != nil {
-			return err
-		}
-
 		if f.coinsMax[c.Denom] != 0 {
-			if totalSent >= f.coinsMax[c.Denom] {
-				return fmt.Errorf(
-					"account has reached to the max. allowed amount (%d) for %q denom",
-					f.coinsMax[c.Denom],
-					c.Denom,
-				)
-			}
+			for _, key := range keys {
+				totalSent, err := f.totalTransferred(ctx, key, c.Denom)
+				if err != nil {
+					return err
+				}
 
-			if (totalSent + c.Amount.Uint64()) > f.coinsMax[c.Denom] {
-				return fmt.Errorf(
-					`ask less amount for %q denom. account is reaching to the limit (%d) that faucet can tolerate`,
-					c.Denom,
-					f.coinsMax[c.Denom],
-				)
+				if totalSent >= f.coinsMax[c.Denom] {
+					return fmt.Errorf(
+						"account has reached to the max. allowed amount (%d) for %q denom",
+						f.coinsMax[c.Denom],
+						c.Denom,
+					)
+				}
+
+				if (totalSent + c.Amount.Uint64()) > f.coinsMax[c.Denom] {
+					return fmt.Errorf(
+						`ask less amount for %q denom. account is reaching to the limit (%d) that faucet can tolerate`,
+						c.Denom,
+						f.coinsMax[c.Denom],
+					)
+				}
 			}
 		}
 
@@ -97,5 +111,18 @@ func (f *Faucet) Transfer(ctx context.Context, toAccountAddress string, coins sd
 	}
 
 	// wait for send tx to be confirmed
-	return f.runner.WaitTx(ctx, txHash, time.Second, 30)
+	if err := f.runner.WaitTx(ctx, txHash, time.Second, 30); err != nil {
+		return err
+	}
+
+	// record the transfer to enforce the limits of future requests
+	now := time.Now()
+	for _, key := range keys {
+		t := Transfer{Coins: coins, Time: now}
+		if err := f.ledger.Record(ctx, key, t, now.Add(-f.limitRefreshWindow)); err != nil {
+			return err
+		}
+	}
+
+	return nil
 }
@@ -1,9 +1,16 @@
 package cosmosgen
 
 import (
+	"context"
+	"io/fs"
+	"path/filepath"
+
+	"github.com/gobuffalo/genny/v2"
 	webtemplates "github.com/ignite/web"
 
 	"github.com/ignite/cli/ignite/pkg/localfs"
+	"github.com/ignite/cli/ignite/pkg/placeholder"
+	"github.com/ignite/cli/ignite/pkg/xgenny"
 )
 
 // React scaffolds a React app for a chain.
@@ -11,7 +18,31 @@ func React(path string) error {
 	return localfs.Save(webtemplates.ReactBoilerplate(), path)
 }
 
+// ReactDryRun returns the changes that scaffolding a React app would apply,
+// without writing any file.
+func ReactDryRun(ctx context.Context, path string) (xgenny.SourceModification, error) {
+	return webDryRun(ctx, webtemplates.ReactBoilerplate(), path)
+}
+
 // Vue scaffolds a Vue.js app for a chain.
 func Vue(path string) error {
 	return localfs.Save(webtemplates.VueBoilerplate(), path)
 }
+
+// VueDryRun returns the changes that scaffolding a Vue.js app would apply,
+// without writing any file.
+func VueDryRun(ctx context.Context, path string) (xgenny.SourceModification, error) {
+	return webDryRun(ctx, webtemplates.VueBoilerplate(), path)
+}
+
+func webDryRun(ctx context.Context, f fs.FS, path string) (xgenny.SourceModification, error) {
+	path, err := filepath.Abs(path)
+	if err != nil {
+		return xgenny.SourceModification{}, err
+	}
+	g := genny.New()
+	if err := g.FS(f); err != nil {
+		return xgenny.SourceModification{}, err
+	}
+	return xgenny.DryRun(ctx, path, placeholder.New(), g)
+}
@@ -0,0 +1,200 @@
+// Package cosmosreflect resolves the gRPC services of the modules of a Cosmos SDK
+// node and invokes them with dynamic messages built from JSON, without the need
+// of generated Go types.
+package cosmosreflect
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/golang/protobuf/jsonpb"
+	"github.com/jhump/protoreflect/desc"
+	"github.com/jhump/protoreflect/dynamic"
+	"github.com/jhump/protoreflect/dynamic/grpcdynamic"
+)
+
+const (
+	// ServiceQuery is the name of the query service of the modules.
+	ServiceQuery = "Query"
+
+	// ServiceMsg is the name of the message service of the modules.
+	ServiceMsg = "Msg"
+)
+
+var (
+	// ErrServiceNotFound is returned when a module service can't be resolved.
+	ErrServiceNotFound = errors.New("service not found")
+
+	// ErrMethodNotFound is returned when a service method doesn't exist.
+	ErrMethodNotFound = errors.New("method not found")
+
+	// ErrMsgNotFound is returned when a message of a Msg service can't be resolved.
+	ErrMsgNotFound = errors.New("message not found")
+)
+
+// FindService finds the service of a module using the resolvers in order.
+// The next resolver is used when a resolver fails to list its services, for
+// example when the gRPC reflection service is not available.
+// The module is either a full proto package name like "cosmos.bank.v1beta1"
+// or one of the segments of the package name like "bank".
+func FindService(resolvers []Resolver, module, service string) (*desc.ServiceDescriptor, error) {
+	var errs []string
+	for _, r := range resolvers {
+		names, err := r.ListServices()
+		if err != nil {
+			errs = append(errs, err.Error())
+			continue
+		}
+
+		name, err := matchService(names, module, service)
+		if errors.Is(err, ErrServiceNotFound) {
+			continue
+		} else if err != nil {
+			return nil, err
+		}
+
+		return r.ResolveService(name)
+	}
+
+	if len(errs) > 0 {
+		return nil, fmt.Errorf("%w: %s %s (%s)", ErrServiceNotFound, module, service, strings.Join(errs, ", "))
+	}
+
+	return nil, fmt.Errorf("%w: %s %s", ErrServiceNotFound, module, service)
+}
+
+// FindMsg finds a message by its full name using the resolvers in order.
+// The message must be the request of a method of the Msg service of its package,
+// like the messages included in transactions.
+func FindMsg(resolvers []Resolver, name string) (*desc.MessageDescriptor, error) {
+	pkg, _ := splitFullName(name)
+
+	var errs []string
+	for _, r := range resolvers {
+		s, err := r.ResolveService(pkg + "." + ServiceMsg)
+		if err != nil {
+			errs = append(errs, err.Error())
+			continue
+		}
+
+		for _, m := range s.GetMethods() {
+			if m.GetInputType().GetFullyQualifiedName() == name {
+				return m.GetInputType(), nil
+			}
+		}
+	}
+
+	if len(errs) > 0 {
+		return nil, fmt.Errorf("%w: %s (%s)", ErrMsgNotFound, name, strings.Join(errs, ", "))
+	}
+
+	return nil, fmt.Errorf("%w: %s", ErrMsgNotFound, name)
+}
+
+// matchService returns the full name of the service of the module.
+// An error is returned when the module matches more than one package.
+func matchService(names []string, module, service string) (string, error) {
+	var matches []string
+	for _, name := range names {
+		pkg, short := splitFullName(name)
+		if short != service {
+			continue
+		}
+
+		if pkg == module {
+			return name, nil
+		}
+
+		for _, s := range strings.Split(pkg, ".") {
+			if s == module {
+				matches = append(matches, name)
+				break
+			}
+		}
+	}
+
+	switch len(matches) {
+	case 0:
+		return "", fmt.Errorf("%w: %s %s", ErrServiceNotFound, module, service)
+	case 1:
+		return matches[0], nil
+	}
+
+	sort.Strings(matches)
+
+	var pkgs []string
+	for _, m := range matches {
+		pkg, _ := splitFullName(m)
+		pkgs = append(pkgs, pkg)
+	}
+
+	return "", fmt.Errorf("module %q matches more than one package, use one of: %s", module, strings.Join(pkgs, ", "))
+}
+
+// FindMethod finds a method of a service by its name or by the name of its request message.
+// The comparison ignores the case, dashes and underscores so "total-supply" matches "TotalSupply".
+func FindMethod(s *desc.ServiceDescriptor, name string) (*desc.MethodDescriptor, error) {
+	name = normalizeName(name)
+	for _, m := range s.GetMethods() {
+		if normalizeName(m.GetName()) == name || normalizeName(m.GetInputType().GetName()) == name {
+			return m, nil
+		}
+	}
+
+	return nil, fmt.Errorf("%w: %s %s", ErrMethodNotFound, s.GetFullyQualifiedName(), name)
+}
+
+// MethodNames returns the names of the methods of a service.
+func MethodNames(s *desc.ServiceDescriptor) []string {
+	var names []string
+	for _, m := range s.GetMethods() {
+		names = append(names, m.GetName())
+	}
+
+	return names
+}
+
+func normalizeName(name string) string {
+	return strings.ToLower(strings.NewReplacer("-", "", "_", "").Replace(name))
+}
+
+// NewMessage returns a dynamic message of the descriptor with the values of the JSON object.
+func NewMessage(md *desc.MessageDescriptor, jsonArgs string) (*dynamic.Message, error) {
+	m := dynamic.NewMessage(md)
+	if strings.TrimSpace(jsonArgs) == "" {
+		return m, nil
+	}
+
+	if err := m.UnmarshalJSON([]byte(jsonArgs)); err != nil {
+		return nil, fmt.Errorf("invalid %s JSON: %w", md.GetFullyQualifiedName(), err)
+	}
+
+	return m, nil
+}
+
+// Invoke calls a unary method using the connection and returns its response.
+func Invoke(
+	ctx context.Context,
+	conn grpcdynamic.Channel,
+	method *desc.MethodDescriptor,
+	req *dynamic.Message,
+) (*dynamic.Message, error) {
+	res, err := grpcdynamic.NewStub(conn).InvokeRpc(ctx, method, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return dynamic.AsDynamicMessage(res)
+}
+
+// MarshalJSON encodes a dynamic message as indented JSON using the proto field names.
+func MarshalJSON(m *dynamic.Message) ([]byte, error) {
+	return m.MarshalJSONPB(&jsonpb.Marshaler{
+		OrigName:     true,
+		EmitDefaults: true,
+		Indent:       "  ",
+	})
+}
@@ -0,0 +1,233 @@
+package cosmosreflect_test
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/reflection"
+
+	"github.com/ignite/cli/ignite/pkg/cosmosreflect"
+)
+
+const testProtoDir = "testdata/proto"
+
+func TestFindService(t *testing.T) {
+	ctx := context.Background()
+	resolver, err := cosmosreflect.NewProtoResolver(ctx, testProtoDir)
+	require.NoError(t, err)
+
+	cases := []struct {
+		name, module, service, method, want string
+		err                                 error
+	}{
+		{
+			name:    "query by module name",
+			module:  "mars",
+			service: cosmosreflect.ServiceQuery,
+			method:  "post",
+			want:    "mars.mars.Query.Post",
+		},
+		{
+			name:    "msg by package name",
+			module:  "mars.mars",
+			service: cosmosreflect.ServiceMsg,
+			method:  "MsgCreatePost",
+			want:    "mars.mars.Msg.CreatePost",
+		},
+		{
+			name:    "msg by dashed name",
+			module:  "mars",
+			service: cosmosreflect.ServiceMsg,
+			method:  "send-fee",
+			want:    "mars.mars.Msg.SendFee",
+		},
+		{
+			name:    "unknown module",
+			module:  "venus",
+			service: cosmosreflect.ServiceQuery,
+			err:     cosmosreflect.ErrServiceNotFound,
+		},
+		{
+			name:    "unknown method",
+			module:  "mars",
+			service: cosmosreflect.ServiceQuery,
+			method:  "posts",
+			err:     cosmosreflect.ErrMethodNotFound,
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			name, err := findMethod(resolver, tt.module, tt.service, tt.method)
+			if tt.err != nil {
+				require.ErrorIs(t, err, tt.err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tt.want, name)
+		})
+	}
+}
+
+func findMethod(r cosmosreflect.Resolver, module, service, method string) (string, error) {
+	s, err := cosmosreflect.FindService([]cosmosreflect.Resolver{r}, module, service)
+	if err != nil {
+		return "", err
+	}
+
+	m, err := cosmosreflect.FindMethod(s, method)
+	if err != nil {
+		return "", err
+	}
+
+	return m.GetFullyQualifiedName(), nil
+}
+
+func TestFindMsg(t *testing.T) {
+	ctx := context.Background()
+	resolver, err := cosmosreflect.NewProtoResolver(ctx, testProtoDir)
+	require.NoError(t, err)
+
+	cases := []struct {
+		name, msg string
+		err       error
+	}{
+		{
+			name: "msg",
+			msg:  "mars.mars.MsgCreatePost",
+		},
+		{
+			name: "not a msg",
+			msg:  "mars.mars.MsgCreatePostResponse",
+			err:  cosmosreflect.ErrMsgNotFound,
+		},
+		{
+			name: "unknown package",
+			msg:  "venus.venus.MsgCreatePost",
+			err:  cosmosreflect.ErrMsgNotFound,
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			md, err := cosmosreflect.FindMsg([]cosmosreflect.Resolver{resolver}, tt.msg)
+			if tt.err != nil {
+				require.ErrorIs(t, err, tt.err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tt.msg, md.GetFullyQualifiedName())
+		})
+	}
+}
+
+func TestNewMsg(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	resolver, err := cosmosreflect.NewProtoResolver(ctx, testProtoDir)
+	require.NoError(t, err)
+
+	s, err := cosmosreflect.FindService([]cosmosreflect.Resolver{resolver}, "mars", cosmosreflect.ServiceMsg)
+	require.NoError(t, err)
+
+	createPost, err := cosmosreflect.FindMethod(s, "CreatePost")
+	require.NoError(t, err)
+
+	sendFee, err := cosmosreflect.FindMethod(s, "SendFee")
+	require.NoError(t, err)
+
+	signer := sdk.MustBech32ifyAddressBytes("cosmos", []byte("signer______________"))
+
+	// Act
+	post, err := cosmosreflect.NewMsg(createPost.GetInputType(), `{"title":"hello"}`, signer)
+	require.NoError(t, err)
+
+	fee, err := cosmosreflect.NewMsg(sendFee.GetInputType(), `{"amount":[{"denom":"token","amount":"10"}]}`, signer)
+	require.NoError(t, err)
+
+	msgAny, err := codectypes.NewAnyWithValue(fee)
+
+	// Assert
+	require.NoError(t, err)
+	require.Equal(t, signer, post.GetFieldByName("creator"))
+	require.Equal(t, "hello", post.GetFieldByName("title"))
+	require.Equal(t, signer, fee.GetFieldByName("from_address"))
+	require.Equal(t, []sdk.AccAddress{[]byte("signer______________")}, fee.GetSigners())
+	require.Equal(t, "/mars.mars.MsgSendFee", msgAny.TypeUrl)
+
+	_, err = cosmosreflect.NewMsg(sendFee.GetInputType(), `{"amount":"10token"}`, signer)
+	require.Error(t, err)
+}
+
+func TestInvoke(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	server := grpc.NewServer()
+	healthpb.RegisterHealthServer(server, health.NewServer())
+	reflection.Register(server)
+
+	go server.Serve(l) //nolint:errcheck
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.Dial(l.Addr().String(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+
+	resolver := cosmosreflect.NewReflectionResolver(ctx, conn)
+	t.Cleanup(resolver.Close)
+
+	s, err := cosmosreflect.FindService([]cosmosreflect.Resolver{resolver}, "health", "Health")
+	require.NoError(t, err)
+
+	method, err := cosmosreflect.FindMethod(s, "check")
+	require.NoError(t, err)
+
+	req, err := cosmosreflect.NewMessage(method.GetInputType(), `{"service":""}`)
+	require.NoError(t, err)
+
+	// Act
+	res, err := cosmosreflect.Invoke(ctx, conn, method, req)
+
+	// Assert
+	require.NoError(t, err)
+	out, err := cosmosreflect.MarshalJSON(res)
+	require.NoError(t, err)
+	require.JSONEq(t, `{"status":"SERVING"}`, string(out))
+}
+
+func TestLazyResolver(t *testing.T) {
+	ctx := context.Background()
+	created := 0
+	lazy := cosmosreflect.NewLazyResolver(func() (cosmosreflect.Resolver, error) {
+		created++
+		return cosmosreflect.NewProtoResolver(ctx, testProtoDir)
+	})
+	failing := cosmosreflect.NewLazyResolver(func() (cosmosreflect.Resolver, error) {
+		t.Fatal("the resolver must not be created when a previous resolver finds the service")
+		return nil, nil
+	})
+
+	// The resolver is only created when it's used
+	require.Equal(t, 0, created)
+
+	for i := 0; i < 2; i++ {
+		s, err := cosmosreflect.FindService([]cosmosreflect.Resolver{lazy, failing}, "mars", cosmosreflect.ServiceQuery)
+		require.NoError(t, err)
+		require.Equal(t, "mars.mars.Query", s.GetFullyQualifiedName())
+	}
+	require.Equal(t, 1, created)
+}
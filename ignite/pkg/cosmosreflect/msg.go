@@ -0,0 +1,87 @@
+package cosmosreflect
+
+import (
+	"fmt"
+
+	msgv1 "cosmossdk.io/api/cosmos/msg/v1"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/bech32"
+	"github.com/golang/protobuf/jsonpb"
+	"github.com/jhump/protoreflect/desc"
+	"github.com/jhump/protoreflect/dynamic"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+// creatorField is the signer field of the messages scaffolded by Ignite.
+const creatorField = "creator"
+
+// Msg is a dynamic message of a Msg service that can be included in a transaction.
+type Msg struct {
+	*dynamic.Message
+}
+
+var _ sdk.Msg = Msg{}
+
+// NewMsg returns a message of the descriptor with the values of the JSON object.
+// The signer field of the message is set to the signer address when it's not
+// defined in the JSON object.
+func NewMsg(md *desc.MessageDescriptor, jsonArgs, signer string) (Msg, error) {
+	m, err := NewMessage(md, jsonArgs)
+	if err != nil {
+		return Msg{}, err
+	}
+
+	if f := signerField(md); f != nil && m.GetField(f) == "" {
+		if err := m.TrySetField(f, signer); err != nil {
+			return Msg{}, err
+		}
+	}
+
+	return Msg{m}, nil
+}
+
+// ValidateBasic doesn't validate the message, the node does it when the transaction is checked.
+func (Msg) ValidateBasic() error {
+	return nil
+}
+
+// GetSigners returns the address of the signer field of the message.
+func (m Msg) GetSigners() []sdk.AccAddress {
+	f := signerField(m.GetMessageDescriptor())
+	if f == nil {
+		return nil
+	}
+
+	addr, _ := m.GetField(f).(string)
+	_, bz, err := bech32.DecodeAndConvert(addr)
+	if err != nil {
+		panic(fmt.Errorf("invalid %s signer address: %w", m.XXX_MessageName(), err))
+	}
+
+	return []sdk.AccAddress{bz}
+}
+
+// signerField returns the string field of the message that contains the signer address.
+// The field is defined with the "cosmos.msg.v1.signer" option, or it's the creator
+// field of the messages scaffolded by Ignite.
+func signerField(md *desc.MessageDescriptor) *desc.FieldDescriptor {
+	name := creatorField
+	if opts := md.GetMessageOptions(); opts != nil && proto.HasExtension(opts, msgv1.E_Signer) {
+		if signers, _ := proto.GetExtension(opts, msgv1.E_Signer).([]string); len(signers) > 0 {
+			name = signers[0]
+		}
+	}
+
+	f := md.FindFieldByName(name)
+	if f == nil || f.IsRepeated() || f.GetType() != descriptorpb.FieldDescriptorProto_TYPE_STRING {
+		return nil
+	}
+
+	return f
+}
+
+// MarshalJSON encodes the message as JSON using the proto field names.
+func (m Msg) MarshalJSON() ([]byte, error) {
+	return m.MarshalJSONPB(&jsonpb.Marshaler{OrigName: true})
+}
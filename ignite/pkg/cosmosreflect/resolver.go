@@ -0,0 +1,222 @@
+package cosmosreflect
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	gogoproto "github.com/cosmos/gogoproto/proto"
+	"github.com/jhump/protoreflect/desc"
+	"github.com/jhump/protoreflect/desc/protoparse"
+	"github.com/jhump/protoreflect/grpcreflect"
+	"google.golang.org/grpc"
+	rpb "google.golang.org/grpc/reflection/grpc_reflection_v1alpha"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/descriptorpb"
+
+	"github.com/ignite/cli/ignite/pkg/protoanalysis"
+
+	// Register the third party proto files commonly imported by the app proto files
+	_ "github.com/cosmos/cosmos-proto"
+	_ "github.com/cosmos/cosmos-sdk/types/tx/amino"
+	_ "github.com/cosmos/gogoproto/gogoproto"
+	_ "google.golang.org/genproto/googleapis/api/annotations"
+)
+
+// Resolver resolves the descriptors of gRPC services.
+type Resolver interface {
+	// ListServices returns the full names of the available services.
+	ListServices() ([]string, error)
+
+	// ResolveService returns the descriptor of a service by its full name.
+	ResolveService(name string) (*desc.ServiceDescriptor, error)
+}
+
+// ReflectionResolver resolves the services using the gRPC reflection service of a server.
+type ReflectionResolver struct {
+	client *grpcreflect.Client
+}
+
+// NewReflectionResolver returns a resolver that uses the gRPC reflection service
+// of the server of the connection. Close must be called to release the reflection
+// stream once the resolver is no longer used.
+func NewReflectionResolver(ctx context.Context, conn grpc.ClientConnInterface) ReflectionResolver {
+	return ReflectionResolver{
+		client: grpcreflect.NewClient(ctx, rpb.NewServerReflectionClient(conn)),
+	}
+}
+
+// ListServices returns the full names of the services of the server.
+func (r ReflectionResolver) ListServices() ([]string, error) {
+	return r.client.ListServices()
+}
+
+// ResolveService returns the descriptor of a service of the server by its full name.
+func (r ReflectionResolver) ResolveService(name string) (*desc.ServiceDescriptor, error) {
+	return r.client.ResolveService(name)
+}
+
+// Close releases the reflection stream.
+func (r ReflectionResolver) Close() {
+	r.client.Reset()
+}
+
+// lazyResolver creates its resolver the first time it's used.
+type lazyResolver struct {
+	once     sync.Once
+	create   func() (Resolver, error)
+	resolver Resolver
+	err      error
+}
+
+// NewLazyResolver returns a resolver that is created the first time it's used.
+// It's used for resolvers that are costly to create, like the proto resolver,
+// when they are only a fallback of other resolvers.
+func NewLazyResolver(create func() (Resolver, error)) Resolver {
+	return &lazyResolver{create: create}
+}
+
+func (r *lazyResolver) ListServices() ([]string, error) {
+	if err := r.init(); err != nil {
+		return nil, err
+	}
+
+	return r.resolver.ListServices()
+}
+
+func (r *lazyResolver) ResolveService(name string) (*desc.ServiceDescriptor, error) {
+	if err := r.init(); err != nil {
+		return nil, err
+	}
+
+	return r.resolver.ResolveService(name)
+}
+
+func (r *lazyResolver) init() error {
+	r.once.Do(func() {
+		r.resolver, r.err = r.create()
+	})
+
+	return r.err
+}
+
+// protoResolver resolves the services by compiling the proto files of an app.
+type protoResolver struct {
+	dir  string
+	pkgs protoanalysis.Packages
+}
+
+// NewProtoResolver returns a resolver that uses the proto files of the directory.
+// The imports that are not found in the directory are resolved using the proto
+// files registered by the Go packages linked in the binary, like the Cosmos SDK ones.
+func NewProtoResolver(ctx context.Context, dir string) (Resolver, error) {
+	pkgs, err := protoanalysis.Parse(ctx, nil, dir)
+	if err != nil {
+		return nil, err
+	}
+
+	return protoResolver{dir: dir, pkgs: pkgs}, nil
+}
+
+func (r protoResolver) ListServices() ([]string, error) {
+	var names []string
+	for _, pkg := range r.pkgs {
+		for _, s := range pkg.Services {
+			names = append(names, pkg.Name+"."+s.Name)
+		}
+	}
+
+	return names, nil
+}
+
+func (r protoResolver) ResolveService(name string) (*desc.ServiceDescriptor, error) {
+	pkgName, serviceName := splitFullName(name)
+
+	for _, pkg := range r.pkgs {
+		if pkg.Name != pkgName {
+			continue
+		}
+
+		// Only compile the files of the service package
+		var files []string
+		for _, f := range pkg.Files {
+			path, err := filepath.Rel(r.dir, f.Path)
+			if err != nil {
+				return nil, err
+			}
+
+			files = append(files, filepath.ToSlash(path))
+		}
+
+		fds, err := NewProtoParser(r.dir).ParseFiles(files...)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, fd := range fds {
+			if s := fd.FindService(pkgName + "." + serviceName); s != nil {
+				return s, nil
+			}
+		}
+	}
+
+	return nil, fmt.Errorf("%w: %s", ErrServiceNotFound, name)
+}
+
+// NewProtoParser returns a parser of proto files that resolves the imports using the
+// import paths. The imports that are not found in the import paths are resolved using
+// the proto files registered by the Go packages linked in the binary, like the Cosmos
+// SDK ones.
+func NewProtoParser(importPaths ...string) protoparse.Parser {
+	return protoparse.Parser{
+		ImportPaths:       importPaths,
+		LookupImportProto: lookupRegisteredFile,
+	}
+}
+
+// lookupRegisteredFile returns the descriptor of a proto file registered by the
+// Go packages linked in the binary, either with gogoproto or with the Go protobuf API.
+func lookupRegisteredFile(path string) (*descriptorpb.FileDescriptorProto, error) {
+	if gz := gogoproto.FileDescriptor(path); gz != nil {
+		zr, err := gzip.NewReader(bytes.NewReader(gz))
+		if err != nil {
+			return nil, err
+		}
+
+		b, err := io.ReadAll(zr)
+		if err != nil {
+			return nil, err
+		}
+
+		var fd descriptorpb.FileDescriptorProto
+		if err := proto.Unmarshal(b, &fd); err != nil {
+			return nil, err
+		}
+
+		return &fd, nil
+	}
+
+	fd, err := protoregistry.GlobalFiles.FindFileByPath(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return protodesc.ToFileDescriptorProto(fd), nil
+}
+
+// splitFullName splits a full proto name into its package and its name.
+func splitFullName(name string) (pkg, short string) {
+	i := strings.LastIndex(name, ".")
+	if i == -1 {
+		return "", name
+	}
+
+	return name[:i], name[i+1:]
+}
@@ -0,0 +1,270 @@
+// Package cosmosstore reads the state of a stopped Cosmos SDK node from its
+// application database, without starting the app.
+package cosmosstore
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"syscall"
+
+	dbm "github.com/cometbft/cometbft-db"
+	storetypes "github.com/cosmos/cosmos-sdk/store/types"
+	gogotypes "github.com/cosmos/gogoproto/types"
+	"github.com/cosmos/iavl"
+	"github.com/syndtr/goleveldb/leveldb/opt"
+)
+
+const (
+	// appDBName is the name of the application database in the node's data directory.
+	appDBName = "application"
+
+	// Keys used by the Cosmos SDK multi store to save its metadata.
+	latestVersionKey = "s/latest"
+	commitInfoKeyFmt = "s/%d"
+	storePrefixFmt   = "s/k:%s/"
+
+	iavlCacheSize = 10000
+)
+
+var (
+	// ErrDatabaseLocked is returned when the application database is used by a running node.
+	ErrDatabaseLocked = errors.New("application database is locked, make sure the node is stopped")
+
+	// ErrStoreNotFound is returned when a store doesn't exist at a height.
+	ErrStoreNotFound = errors.New("store not found")
+)
+
+// DB is the application database of a node opened in read-only mode.
+type DB struct {
+	db dbm.DB
+}
+
+// Open opens the application database in the data directory of a node.
+// The database is opened in read-only mode so the node must be stopped.
+func Open(dataDir string) (*DB, error) {
+	if _, err := os.Stat(filepath.Join(dataDir, appDBName+".db")); err != nil {
+		return nil, fmt.Errorf("cannot open the application database: %w", err)
+	}
+
+	db, err := dbm.NewGoLevelDBWithOpts(appDBName, dataDir, &opt.Options{
+		ReadOnly:       true,
+		ErrorIfMissing: true,
+	})
+	if errors.Is(err, syscall.EWOULDBLOCK) {
+		return nil, ErrDatabaseLocked
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &DB{db: db}, nil
+}
+
+// Close closes the database.
+func (d *DB) Close() error {
+	return d.db.Close()
+}
+
+// LatestHeight returns the latest height committed to the database.
+func (d *DB) LatestHeight() (int64, error) {
+	bz, err := d.db.Get([]byte(latestVersionKey))
+	if err != nil {
+		return 0, err
+	}
+	if bz == nil {
+		return 0, nil
+	}
+
+	var height int64
+	if err := gogotypes.StdInt64Unmarshal(&height, bz); err != nil {
+		return 0, err
+	}
+
+	return height, nil
+}
+
+// Stores returns the names of the stores committed at a height, sorted by name.
+// The latest height is used when height is zero.
+func (d *DB) Stores(height int64) ([]string, error) {
+	height, err := d.height(height)
+	if err != nil {
+		return nil, err
+	}
+
+	bz, err := d.db.Get([]byte(fmt.Sprintf(commitInfoKeyFmt, height)))
+	if err != nil {
+		return nil, err
+	}
+	if bz == nil {
+		return nil, fmt.Errorf("no commit info found at height %d", height)
+	}
+
+	var info storetypes.CommitInfo
+	if err := info.Unmarshal(bz); err != nil {
+		return nil, err
+	}
+
+	names := make([]string, len(info.StoreInfos))
+	for i, s := range info.StoreInfos {
+		names[i] = s.Name
+	}
+	sort.Strings(names)
+
+	return names, nil
+}
+
+// Iterate calls fn for each key of a store at a height that starts with prefix,
+// in ascending order, until fn returns false.
+// The latest height is used when height is zero.
+func (d *DB) Iterate(store string, height int64, prefix []byte, fn func(key, value []byte) bool) error {
+	tree, err := d.tree(store, height)
+	if err != nil {
+		return err
+	}
+
+	it, err := tree.Iterator(prefix, prefixEnd(prefix), true)
+	if err != nil {
+		return err
+	}
+	defer it.Close()
+
+	for ; it.Valid(); it.Next() {
+		if !fn(it.Key(), it.Value()) {
+			break
+		}
+	}
+
+	return it.Error()
+}
+
+// Change is a change of a store key between two heights.
+type Change struct {
+	// Key is the changed key.
+	Key []byte
+
+	// Old is the value at the first height, it is nil when the key was added.
+	Old []byte
+
+	// New is the value at the second height, it is nil when the key was removed.
+	New []byte
+}
+
+// Diff returns the changes of the keys of a store that start with prefix
+// between two heights, sorted by key.
+func (d *DB) Diff(store string, from, to int64, prefix []byte) ([]Change, error) {
+	fromTree, err := d.tree(store, from)
+	if err != nil {
+		return nil, err
+	}
+
+	toTree, err := d.tree(store, to)
+	if err != nil {
+		return nil, err
+	}
+
+	fromIt, err := fromTree.Iterator(prefix, prefixEnd(prefix), true)
+	if err != nil {
+		return nil, err
+	}
+	defer fromIt.Close()
+
+	toIt, err := toTree.Iterator(prefix, prefixEnd(prefix), true)
+	if err != nil {
+		return nil, err
+	}
+	defer toIt.Close()
+
+	var changes []Change
+	for fromIt.Valid() || toIt.Valid() {
+		var cmp int
+		switch {
+		case !fromIt.Valid():
+			cmp = 1
+		case !toIt.Valid():
+			cmp = -1
+		default:
+			cmp = bytes.Compare(fromIt.Key(), toIt.Key())
+		}
+
+		switch {
+		case cmp < 0:
+			changes = append(changes, Change{Key: fromIt.Key(), Old: fromIt.Value()})
+			fromIt.Next()
+		case cmp > 0:
+			changes = append(changes, Change{Key: toIt.Key(), New: toIt.Value()})
+			toIt.Next()
+		default:
+			if !bytes.Equal(fromIt.Value(), toIt.Value()) {
+				changes = append(changes, Change{Key: fromIt.Key(), Old: fromIt.Value(), New: toIt.Value()})
+			}
+			fromIt.Next()
+			toIt.Next()
+		}
+	}
+
+	if err := fromIt.Error(); err != nil {
+		return nil, err
+	}
+
+	return changes, toIt.Error()
+}
+
+// height returns the latest height when height is zero.
+func (d *DB) height(height int64) (int64, error) {
+	if height != 0 {
+		return height, nil
+	}
+
+	latest, err := d.LatestHeight()
+	if err != nil {
+		return 0, err
+	}
+	if latest == 0 {
+		return 0, errors.New("no height committed to the application database")
+	}
+
+	return latest, nil
+}
+
+// tree returns the IAVL tree of a store at a height.
+func (d *DB) tree(store string, height int64) (*iavl.ImmutableTree, error) {
+	height, err := d.height(height)
+	if err != nil {
+		return nil, err
+	}
+
+	stores, err := d.Stores(height)
+	if err != nil {
+		return nil, err
+	}
+
+	i := sort.SearchStrings(stores, store)
+	if i == len(stores) || stores[i] != store {
+		return nil, fmt.Errorf("%w: %s", ErrStoreNotFound, store)
+	}
+
+	db := dbm.NewPrefixDB(d.db, []byte(fmt.Sprintf(storePrefixFmt, store)))
+	tree, err := iavl.NewMutableTree(db, iavlCacheSize, true)
+	if err != nil {
+		return nil, err
+	}
+
+	immutable, err := tree.GetImmutable(height)
+	if errors.Is(err, iavl.ErrVersionDoesNotExist) {
+		return nil, fmt.Errorf("store %s height %d was pruned or doesn't exist", store, height)
+	}
+
+	return immutable, err
+}
+
+// prefixEnd returns the end of the range of keys starting with prefix.
+func prefixEnd(prefix []byte) []byte {
+	if len(prefix) == 0 {
+		return nil
+	}
+	return storetypes.PrefixEndBytes(prefix)
+}
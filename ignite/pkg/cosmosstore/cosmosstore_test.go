@@ -0,0 +1,125 @@
+package cosmosstore_test
+
+import (
+	"fmt"
+	"testing"
+
+	dbm "github.com/cometbft/cometbft-db"
+	storetypes "github.com/cosmos/cosmos-sdk/store/types"
+	gogotypes "github.com/cosmos/gogoproto/types"
+	"github.com/cosmos/iavl"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ignite/cli/ignite/pkg/cosmosstore"
+)
+
+// createAppDB creates an application database with a "mars" store and two heights.
+func createAppDB(t *testing.T) string {
+	t.Helper()
+
+	dir := t.TempDir()
+	db, err := dbm.NewGoLevelDB("application", dir)
+	require.NoError(t, err)
+	defer db.Close()
+
+	tree, err := iavl.NewMutableTree(dbm.NewPrefixDB(db, []byte("s/k:mars/")), 0, true)
+	require.NoError(t, err)
+
+	heights := []map[string]string{
+		{"planet/mars": "red", "planet/venus": "yellow", "moon/phobos": "small"},
+		{"planet/mars": "rust", "planet/earth": "blue", "moon/phobos": "small"},
+	}
+	for i, kvs := range heights {
+		for _, k := range []string{"planet/mars", "planet/venus", "planet/earth", "moon/phobos"} {
+			if v, ok := kvs[k]; ok {
+				_, err = tree.Set([]byte(k), []byte(v))
+			} else {
+				_, _, err = tree.Remove([]byte(k))
+			}
+			require.NoError(t, err)
+		}
+
+		_, version, err := tree.SaveVersion()
+		require.NoError(t, err)
+		require.EqualValues(t, i+1, version)
+
+		info := storetypes.CommitInfo{
+			Version:    version,
+			StoreInfos: []storetypes.StoreInfo{{Name: "mars"}, {Name: "bank"}},
+		}
+		bz, err := info.Marshal()
+		require.NoError(t, err)
+		require.NoError(t, db.Set([]byte(fmt.Sprintf("s/%d", version)), bz))
+
+		bz, err = gogotypes.StdInt64Marshal(version)
+		require.NoError(t, err)
+		require.NoError(t, db.Set([]byte("s/latest"), bz))
+	}
+
+	return dir
+}
+
+func TestDB(t *testing.T) {
+	// Arrange
+	db, err := cosmosstore.Open(createAppDB(t))
+	require.NoError(t, err)
+	defer db.Close()
+
+	// Act
+	height, err := db.LatestHeight()
+	require.NoError(t, err)
+
+	stores, err := db.Stores(0)
+	require.NoError(t, err)
+
+	values := make(map[string]string)
+	err = db.Iterate("mars", 1, []byte("planet/"), func(key, value []byte) bool {
+		values[string(key)] = string(value)
+		return true
+	})
+	require.NoError(t, err)
+
+	changes, err := db.Diff("mars", 1, 2, nil)
+	require.NoError(t, err)
+
+	// Assert
+	require.EqualValues(t, 2, height)
+	require.Equal(t, []string{"bank", "mars"}, stores)
+	require.Equal(t, map[string]string{"planet/mars": "red", "planet/venus": "yellow"}, values)
+	require.Equal(t, []cosmosstore.Change{
+		{Key: []byte("planet/earth"), New: []byte("blue")},
+		{Key: []byte("planet/mars"), Old: []byte("red"), New: []byte("rust")},
+		{Key: []byte("planet/venus"), Old: []byte("yellow")},
+	}, changes)
+}
+
+func TestDBErrors(t *testing.T) {
+	dir := createAppDB(t)
+	db, err := cosmosstore.Open(dir)
+	require.NoError(t, err)
+	defer db.Close()
+
+	iterate := func(key, value []byte) bool { return true }
+
+	err = db.Iterate("staking", 0, nil, iterate)
+	require.ErrorIs(t, err, cosmosstore.ErrStoreNotFound)
+
+	err = db.Iterate("mars", 3, nil, iterate)
+	require.EqualError(t, err, "no commit info found at height 3")
+
+	_, err = cosmosstore.Open(t.TempDir())
+	require.Error(t, err)
+}
+
+func TestOpenLockedDB(t *testing.T) {
+	dir := createAppDB(t)
+
+	// Open the database as a running node does
+	nodeDB, err := dbm.NewGoLevelDB("application", dir)
+	require.NoError(t, err)
+	defer nodeDB.Close()
+
+	_, err = cosmosstore.Open(dir)
+
+	require.ErrorIs(t, err, cosmosstore.ErrDatabaseLocked)
+}
@@ -40,3 +40,16 @@ type Adapter interface {
 	// Query executes a query in the data backend.
 	Query(context.Context, query.Query) (query.Cursor, error)
 }
+
+// Checkpointer is the interface for data backends that keep track of the latest collected block.
+// The checkpoint is saved after the transactions of each block are saved, which allows the
+// collection to be resumed from the next block without gaps. Data backends must ignore the
+// transactions that are already saved so resuming after a failure that happens before the
+// checkpoint is saved doesn't duplicate the collected data.
+type Checkpointer interface {
+	// SaveCheckpoint saves the height of the latest block that was collected.
+	SaveCheckpoint(ctx context.Context, height int64) error
+
+	// GetCheckpoint returns the height of the latest block that was collected.
+	GetCheckpoint(context.Context) (int64, error)
+}
@@ -13,6 +13,7 @@ import (
 	"github.com/lib/pq"
 
 	"github.com/ignite/cli/ignite/pkg/cosmosclient"
+	"github.com/ignite/cli/ignite/pkg/cosmostxcollector/adapter/schema"
 	"github.com/ignite/cli/ignite/pkg/cosmostxcollector/query"
 )
 
@@ -33,9 +34,14 @@ const (
 		WHERE event_id = ANY($1)
 		ORDER BY event_id
 	`
+	sqlSelectCheckpoint = `
+		SELECT COALESCE(MAX(height), 0)
+		FROM checkpoint
+	`
 	sqlInsertTX = `
 		INSERT INTO tx (hash, index, height, block_time)
 		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (hash) DO NOTHING
 	`
 	sqlInsertEvent = `
 		INSERT INTO event (tx_hash, type, index)
@@ -48,6 +54,12 @@ const (
 	sqlInsertRawTX = `
 		INSERT INTO raw_tx (hash, data)
 		VALUES ($1, $2)
+		ON CONFLICT (hash) DO NOTHING
+	`
+	sqlUpsertCheckpoint = `
+		INSERT INTO checkpoint (id, height)
+		VALUES (1, $1)
+		ON CONFLICT (id) DO UPDATE SET height = EXCLUDED.height, updated_at = CURRENT_TIMESTAMP
 	`
 )
 
@@ -101,7 +113,7 @@ func NewAdapter(database string, options ...Option) (Adapter, error) {
 		host:     DefaultHost,
 		port:     DefaultPort,
 		database: database,
-		schemas:  NewSchemas(fsSchemas, ""),
+		schemas:  schema.New(fsSchemas, ""),
 	}
 
 	for _, o := range options {
@@ -124,12 +136,12 @@ type Adapter struct {
 	port                           uint
 	params                         map[string]string
 	db                             *sql.DB
-	schemas                        Schemas
+	schemas                        schema.Schemas
 }
 
 // UpdateSchema updates the database schema to the latest version available.
 // It applies all available schemas that were not applied already.
-func (a Adapter) UpdateSchema(ctx context.Context, s Schemas) error {
+func (a Adapter) UpdateSchema(ctx context.Context, s schema.Schemas) error {
 	db, err := a.getDB()
 	if err != nil {
 		return err
@@ -155,6 +167,16 @@ func (a Adapter) UpdateSchema(ctx context.Context, s Schemas) error {
 	})
 }
 
+// Close closes the database connection.
+func (a Adapter) Close() error {
+	db, err := a.getDB()
+	if err != nil {
+		return err
+	}
+
+	return db.Close()
+}
+
 func (a Adapter) GetType() string {
 	return adapterType
 }
@@ -230,6 +252,35 @@ func (a Adapter) GetLatestHeight(ctx context.Context) (height int64, err error)
 	return height, nil
 }
 
+// SaveCheckpoint saves the height of the latest block that was collected.
+func (a Adapter) SaveCheckpoint(ctx context.Context, height int64) error {
+	db, err := a.getDB()
+	if err != nil {
+		return err
+	}
+
+	if _, err := db.ExecContext(ctx, sqlUpsertCheckpoint, height); err != nil {
+		return fmt.Errorf("error saving checkpoint: %w", err)
+	}
+
+	return nil
+}
+
+// GetCheckpoint returns the height of the latest block that was collected.
+func (a Adapter) GetCheckpoint(ctx context.Context) (height int64, err error) {
+	db, err := a.getDB()
+	if err != nil {
+		return 0, err
+	}
+
+	row := db.QueryRowContext(ctx, sqlSelectCheckpoint)
+	if err = row.Scan(&height); err != nil {
+		return 0, err
+	}
+
+	return height, nil
+}
+
 func (a Adapter) QueryEvents(ctx context.Context, q query.EventQuery) ([]query.Event, error) {
 	db, err := a.getDB()
 	if err != nil {
@@ -366,10 +417,19 @@ func saveRawTX(ctx context.Context, sqlTx *sql.Tx, rtx *ctypes.ResultTx) error {
 
 func saveTX(ctx context.Context, txStmt, evtStmt, attrStmt *sql.Stmt, tx cosmosclient.TX) error {
 	hash := tx.Raw.Hash.String()
-	if _, err := txStmt.ExecContext(ctx, hash, tx.Raw.Index, tx.Raw.Height, tx.BlockTime); err != nil {
+	res, err := txStmt.ExecContext(ctx, hash, tx.Raw.Index, tx.Raw.Height, tx.BlockTime)
+	if err != nil {
 		return fmt.Errorf("error saving TX %s: %w", hash, err)
 	}
 
+	// Skip the events when the TX was already saved so collecting
+	// the same block more than once doesn't duplicate them
+	if n, err := res.RowsAffected(); err != nil {
+		return fmt.Errorf("error saving TX %s: %w", hash, err)
+	} else if n == 0 {
+		return nil
+	}
+
 	events, err := tx.GetEvents()
 	if err != nil {
 		return err
@@ -18,6 +18,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/ignite/cli/ignite/pkg/cosmosclient"
+	"github.com/ignite/cli/ignite/pkg/cosmostxcollector/adapter/schema"
 	"github.com/ignite/cli/ignite/pkg/cosmostxcollector/query"
 )
 
@@ -43,7 +44,7 @@ func TestUpdateSchema(t *testing.T) {
 		"schemas/1.sql": &fstest.MapFile{Data: []byte(schemasData[0])},
 		"schemas/2.sql": &fstest.MapFile{Data: []byte(schemasData[1])},
 	}
-	s := NewSchemas(fs, "")
+	s := schema.New(fs, "")
 
 	// Arrange: Prepare database adapter
 	adapter := Adapter{
@@ -126,6 +127,7 @@ func TestSave(t *testing.T) {
 	txStmt := mock.ExpectPrepare(`
 		INSERT INTO tx (hash, index, height, block_time)
 		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (hash) DO NOTHING
 	`)
 	evtStmt := mock.ExpectPrepare(`
 		INSERT INTO event (tx_hash, type, index)
@@ -146,6 +148,7 @@ func TestSave(t *testing.T) {
 		ExpectExec(`
 			INSERT INTO raw_tx (hash, data)
 			VALUES ($1, $2)
+			ON CONFLICT (hash) DO NOTHING
 		`).
 		WithArgs(tx.Raw.Hash.String(), jsonResTX).
 		WillReturnResult(insertResult)
@@ -201,6 +204,65 @@ func TestGetLatestHeight(t *testing.T) {
 	require.NoError(t, mock.ExpectationsWereMet())
 }
 
+func TestClose(t *testing.T) {
+	// Arrange
+	db, mock := createMatchEqualSQLMock(t)
+	adapter := Adapter{db: db}
+
+	mock.ExpectClose()
+
+	// Act
+	err := adapter.Close()
+
+	// Assert
+	require.NoError(t, err)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSaveCheckpoint(t *testing.T) {
+	// Arrange
+	db, mock := createMatchEqualSQLMock(t)
+	defer db.Close()
+
+	adapter := Adapter{db: db}
+	height := int64(42)
+
+	mock.
+		ExpectExec(sqlUpsertCheckpoint).
+		WithArgs(height).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	// Act
+	err := adapter.SaveCheckpoint(context.Background(), height)
+
+	// Assert
+	require.NoError(t, err)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetCheckpoint(t *testing.T) {
+	// Arrange
+	db, mock := createMatchEqualSQLMock(t)
+	defer db.Close()
+
+	adapter := Adapter{db: db}
+	wantHeight := int64(42)
+
+	mock.
+		ExpectQuery(sqlSelectCheckpoint).
+		WillReturnRows(
+			sqlmock.NewRows([]string{"height"}).AddRow(wantHeight),
+		)
+
+	// Act
+	height, err := adapter.GetCheckpoint(context.Background())
+
+	// Assert
+	require.NoError(t, err)
+	require.Equal(t, wantHeight, height)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
 func TestQuery(t *testing.T) {
 	// Arrange
 	var rowValue string
@@ -0,0 +1,11 @@
+CREATE TABLE checkpoint (
+    id          SMALLINT NOT NULL,
+    height      BIGINT NOT NULL,
+    updated_at  TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT checkpoint_pk PRIMARY KEY (id),
+    CONSTRAINT checkpoint_single_row CHECK (id = 1)
+);
+
+INSERT INTO checkpoint (id, height)
+SELECT 1, MAX(height) FROM tx HAVING MAX(height) IS NOT NULL;
@@ -1,4 +1,5 @@
-package postgres
+// Package schema manages the versions of the SQL schemas of the data backend adapters.
+package schema
 
 import (
 	"bytes"
@@ -10,8 +11,8 @@ import (
 	"strings"
 )
 
-// SchemasDir defines the name for the embedded schema directory.
-const SchemasDir = "schemas"
+// Dir defines the name for the embedded schema directory.
+const Dir = "schemas"
 
 const (
 	defaultSchemasTableName = "schema"
@@ -38,15 +39,15 @@ const (
 	`
 )
 
-// SchemasWalkFunc is the type of the function called by WalkFrom.
-type SchemasWalkFunc func(version uint64, script []byte) error
+// WalkFunc is the type of the function called by WalkFrom.
+type WalkFunc func(version uint64, script []byte) error
 
-// NewSchemas creates a new embedded SQL schema manager.
+// New creates a new embedded SQL schema manager.
 // The embedded FS is used to iterate the schema files.
 // By default, the applied schema versions are stored in the "schema"
 // table but the name can have a prefix namespace when different
 // packages are storing the schemas in the same database.
-func NewSchemas(fs fs.FS, namespace string) Schemas {
+func New(fs fs.FS, namespace string) Schemas {
 	tableName := defaultSchemasTableName
 	if namespace != "" {
 		tableName = fmt.Sprintf("%s_%s", namespace, tableName)
@@ -75,17 +76,17 @@ func (s Schemas) GetSchemaVersionSQL() string {
 
 // WalkFrom calls a function for SQL schemas starting from a specific version.
 // This is useful to apply newer schemas that are not yet applied.
-func (s Schemas) WalkFrom(fromVersion uint64, fn SchemasWalkFunc) error {
+func (s Schemas) WalkFrom(fromVersion uint64, fn WalkFunc) error {
 	// Stores schema file paths by version
 	paths := map[uint64]string{}
 
 	// Index the paths to the schemas with the matching versions
-	err := fs.WalkDir(s.fs, SchemasDir, func(path string, d fs.DirEntry, err error) error {
+	err := fs.WalkDir(s.fs, Dir, func(path string, d fs.DirEntry, err error) error {
 		if err != nil {
 			return fmt.Errorf("failed to read schema %s: %w", path, err)
 		}
 
-		if path == SchemasDir {
+		if path == Dir {
 			return nil
 		}
 
@@ -1,4 +1,4 @@
-package postgres_test
+package schema_test
 
 import (
 	"bytes"
@@ -9,7 +9,7 @@ import (
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 
-	"github.com/ignite/cli/ignite/pkg/cosmostxcollector/adapter/postgres"
+	"github.com/ignite/cli/ignite/pkg/cosmostxcollector/adapter/schema"
 )
 
 func TestSchemasWalk(t *testing.T) {
@@ -42,7 +42,7 @@ func TestSchemasWalk(t *testing.T) {
 		"schemas/1.sql": &fstest.MapFile{Data: []byte(data[1])},
 		"schemas/2.sql": &fstest.MapFile{Data: []byte(data[2])},
 	}
-	s := postgres.NewSchemas(fs, "")
+	s := schema.New(fs, "")
 
 	// Act
 	err := s.WalkFrom(1, fn)
@@ -92,7 +92,7 @@ func TestSchemasWalkOrder(t *testing.T) {
 		"schemas/2.sql":  &fstest.MapFile{Data: []byte(data[2])},
 		"schemas/10.sql": &fstest.MapFile{Data: []byte(data[10])},
 	}
-	s := postgres.NewSchemas(fs, "")
+	s := schema.New(fs, "")
 
 	// Act
 	err := s.WalkFrom(1, fn)
@@ -110,7 +110,7 @@ func TestScriptBuilder(t *testing.T) {
 	c1 := "COMMAND-1"
 	c2 := "COMMAND-2"
 
-	b := postgres.ScriptBuilder{}
+	b := schema.ScriptBuilder{}
 	b.BeginTX()
 	b.AppendScript([]byte(s1))
 	b.AppendScript([]byte(s2))
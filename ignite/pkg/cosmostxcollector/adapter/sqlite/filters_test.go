@@ -0,0 +1,64 @@
+package sqlite_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ignite/cli/ignite/pkg/cosmostxcollector/adapter/sqlite"
+)
+
+func TestFilter(t *testing.T) {
+	// Arrange
+	name := "string_field"
+	value := "test"
+	repr := fmt.Sprintf("%s = ?", name)
+
+	// Act
+	filter := sqlite.NewFilter(name, value)
+
+	// Assert
+	require.Equal(t, repr, filter.String())
+	require.Equal(t, name, filter.Field())
+	require.Equal(t, value, filter.Value())
+}
+
+func TestFilterModifiers(t *testing.T) {
+	// Act
+	filter := sqlite.NewFilter("field", nil, sqlite.WithModifiers(sqlite.CastToNumeric))
+
+	// Assert
+	require.Equal(t, "CAST(field AS NUMERIC) = ?", filter.String())
+}
+
+func TestSliceFilter(t *testing.T) {
+	cases := []struct {
+		name   string
+		filter sqlite.SliceFilter
+		want   string
+	}{
+		{
+			name:   "strings",
+			filter: sqlite.NewStringSliceFilter("field", []string{"a", "b"}),
+			want:   `["a","b"]`,
+		},
+		{
+			name:   "integers",
+			filter: sqlite.NewIntSliceFilter("field", []int64{1, 2}),
+			want:   "[1,2]",
+		},
+		{
+			name:   "empty",
+			filter: sqlite.NewStringSliceFilter("field", nil),
+			want:   "[]",
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, "field IN (SELECT value FROM json_each(?))", tt.filter.String())
+			require.Equal(t, tt.want, tt.filter.Value())
+		})
+	}
+}
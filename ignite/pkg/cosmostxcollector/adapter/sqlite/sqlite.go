@@ -0,0 +1,459 @@
+// Package sqlite implements a SQLite data backend adapter for the transaction collector.
+// The adapter keeps all the collected data in a single database file, which makes it
+// handy to run the collector in CI or locally without a database server.
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+
+	ctypes "github.com/cometbft/cometbft/rpc/core/types"
+	_ "modernc.org/sqlite" // register the SQLite database driver
+
+	"github.com/ignite/cli/ignite/pkg/cosmosclient"
+	"github.com/ignite/cli/ignite/pkg/cosmostxcollector/adapter/schema"
+	"github.com/ignite/cli/ignite/pkg/cosmostxcollector/query"
+)
+
+// DefaultBusyTimeout is the default time in milliseconds to wait for a locked database.
+const DefaultBusyTimeout = 5000
+
+const (
+	adapterType = "sqlite"
+	driverName  = "sqlite"
+
+	sqlSelectBlockHeight = `
+		SELECT COALESCE(MAX(height), 0)
+		FROM tx
+	`
+	sqlSelectEventAttrs = `
+		SELECT event_id, name, value FROM attribute
+		WHERE event_id IN (SELECT value FROM json_each(?))
+		ORDER BY event_id
+	`
+	sqlSelectCheckpoint = `
+		SELECT COALESCE(MAX(height), 0)
+		FROM checkpoint
+	`
+	sqlInsertTX = `
+		INSERT INTO tx (hash, "index", height, block_time)
+		VALUES (?, ?, ?, ?)
+		ON CONFLICT (hash) DO NOTHING
+	`
+	sqlInsertEvent = `
+		INSERT INTO event (tx_hash, "type", "index")
+		VALUES (?, ?, ?)
+	`
+	sqlInsertEventAttr = `
+		INSERT INTO attribute (event_id, name, value)
+		VALUES (?, ?, ?)
+	`
+	sqlInsertRawTX = `
+		INSERT INTO raw_tx (hash, data)
+		VALUES (?, ?)
+		ON CONFLICT (hash) DO NOTHING
+	`
+	sqlUpsertCheckpoint = `
+		INSERT INTO checkpoint (id, height)
+		VALUES (1, ?)
+		ON CONFLICT (id) DO UPDATE SET height = excluded.height, updated_at = CURRENT_TIMESTAMP
+	`
+)
+
+//go:embed schemas/*
+var fsSchemas embed.FS
+
+// ErrClosed is returned when database connection is not open.
+var ErrClosed = errors.New("no database connection")
+
+// Option defines an option for the adapter.
+type Option func(*Adapter)
+
+// WithBusyTimeout configures the time in milliseconds to wait for a locked database.
+func WithBusyTimeout(timeout uint) Option {
+	return func(a *Adapter) {
+		a.busyTimeout = timeout
+	}
+}
+
+// NewAdapter creates a new SQLite adapter.
+// The path is the path to the database file, which is created when it doesn't exist.
+func NewAdapter(path string, options ...Option) (Adapter, error) {
+	adapter := Adapter{
+		path:        path,
+		busyTimeout: DefaultBusyTimeout,
+		schemas:     schema.New(fsSchemas, ""),
+	}
+
+	for _, o := range options {
+		o(&adapter)
+	}
+
+	db, err := sql.Open(driverName, createSQLiteURI(adapter))
+	if err != nil {
+		return Adapter{}, err
+	}
+
+	// SQLite only allows one writer at a time
+	db.SetMaxOpenConns(1)
+
+	adapter.db = db
+
+	return adapter, nil
+}
+
+// Adapter implements a data backend adapter for SQLite.
+type Adapter struct {
+	path        string
+	busyTimeout uint
+	db          *sql.DB
+	schemas     schema.Schemas
+}
+
+// UpdateSchema updates the database schema to the latest version available.
+// It applies all available schemas that were not applied already.
+func (a Adapter) UpdateSchema(ctx context.Context, s schema.Schemas) error {
+	db, err := a.getDB()
+	if err != nil {
+		return err
+	}
+
+	// Create the schema table if it doesn't exist
+	if _, err := db.ExecContext(ctx, s.GetTableDDL()); err != nil {
+		return fmt.Errorf("failed to check schema table: %w", err)
+	}
+
+	// Get the current schema version
+	var v uint64
+	if err := db.QueryRowContext(ctx, s.GetSchemaVersionSQL()).Scan(&v); err != nil {
+		return fmt.Errorf("failed to read current schema version: %w", err)
+	}
+
+	return s.WalkFrom(v+1, func(version uint64, script []byte) error {
+		if _, err := db.ExecContext(ctx, string(script)); err != nil {
+			return fmt.Errorf("error applying schema version %d: %w", version, err)
+		}
+
+		return nil
+	})
+}
+
+// Close closes the database file.
+func (a Adapter) Close() error {
+	db, err := a.getDB()
+	if err != nil {
+		return err
+	}
+
+	return db.Close()
+}
+
+func (a Adapter) GetType() string {
+	return adapterType
+}
+
+func (a Adapter) Init(ctx context.Context) error {
+	return a.UpdateSchema(ctx, a.schemas)
+}
+
+func (a Adapter) Save(ctx context.Context, txs []cosmosclient.TX) error {
+	db, err := a.getDB()
+	if err != nil {
+		return err
+	}
+
+	// Start a transaction
+	sqlTx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	// Rollback won't have any effect if the transaction is committed before
+	defer sqlTx.Rollback()
+
+	// Prepare insert statements to speed up "bulk" saving times
+	txStmt, err := sqlTx.PrepareContext(ctx, sqlInsertTX)
+	if err != nil {
+		return err
+	}
+
+	defer txStmt.Close()
+
+	evtStmt, err := sqlTx.PrepareContext(ctx, sqlInsertEvent)
+	if err != nil {
+		return err
+	}
+
+	defer evtStmt.Close()
+
+	attrStmt, err := sqlTx.PrepareContext(ctx, sqlInsertEventAttr)
+	if err != nil {
+		return err
+	}
+
+	defer attrStmt.Close()
+
+	// All the transactions are saved within the context of the same database
+	// transactions and because of that either all block transactions are
+	// saved or none of them.
+	for _, tx := range txs {
+		if err := saveRawTX(ctx, sqlTx, tx.Raw); err != nil {
+			return err
+		}
+
+		if err := saveTX(ctx, txStmt, evtStmt, attrStmt, tx); err != nil {
+			return err
+		}
+	}
+
+	return sqlTx.Commit()
+}
+
+func (a Adapter) GetLatestHeight(ctx context.Context) (height int64, err error) {
+	db, err := a.getDB()
+	if err != nil {
+		return 0, err
+	}
+
+	row := db.QueryRowContext(ctx, sqlSelectBlockHeight)
+	if err = row.Scan(&height); err != nil {
+		return 0, err
+	}
+
+	return height, nil
+}
+
+// SaveCheckpoint saves the height of the latest block that was collected.
+func (a Adapter) SaveCheckpoint(ctx context.Context, height int64) error {
+	db, err := a.getDB()
+	if err != nil {
+		return err
+	}
+
+	if _, err := db.ExecContext(ctx, sqlUpsertCheckpoint, height); err != nil {
+		return fmt.Errorf("error saving checkpoint: %w", err)
+	}
+
+	return nil
+}
+
+// GetCheckpoint returns the height of the latest block that was collected.
+func (a Adapter) GetCheckpoint(ctx context.Context) (height int64, err error) {
+	db, err := a.getDB()
+	if err != nil {
+		return 0, err
+	}
+
+	row := db.QueryRowContext(ctx, sqlSelectCheckpoint)
+	if err = row.Scan(&height); err != nil {
+		return 0, err
+	}
+
+	return height, nil
+}
+
+func (a Adapter) QueryEvents(ctx context.Context, q query.EventQuery) ([]query.Event, error) {
+	db, err := a.getDB()
+	if err != nil {
+		return nil, err
+	}
+
+	sql := parseEventQuery(q)
+	args := extractEventQueryArgs(q)
+	rows, err := db.QueryContext(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	var (
+		events   []query.Event
+		eventIDs []int64
+
+		// Keep an index of the event position within the events slice
+		// to find them later when updating their attributes.
+		eventIndexes = make(map[int64]int)
+	)
+
+	for i := 0; rows.Next(); i++ {
+		e := query.Event{}
+		if err := rows.Scan(&e.ID, &e.Index, &e.TXHash, &e.Type, &e.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to read event: %w", err)
+		}
+
+		events = append(events, e)
+		eventIDs = append(eventIDs, e.ID)
+
+		eventIndexes[e.ID] = i
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	// Don't query attributes when there are no events
+	if len(events) == 0 {
+		return events, nil
+	}
+
+	// The event IDs are passed as a JSON array to select the attributes
+	ids, err := json.Marshal(eventIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	// Select the attributes for the events that matched the query
+	attrRows, err := db.QueryContext(ctx, sqlSelectEventAttrs, string(ids))
+	if err != nil {
+		return nil, err
+	}
+
+	defer attrRows.Close()
+
+	// Update the attributes of the selected events
+	for attrRows.Next() {
+		var (
+			eventID int64
+			name    string
+			value   []byte
+		)
+
+		if err := attrRows.Scan(&eventID, &name, &value); err != nil {
+			return nil, fmt.Errorf("failed to read event attribute: %w", err)
+		}
+
+		i := eventIndexes[eventID]
+		events[i].Attributes = append(events[i].Attributes, query.NewAttribute(name, value))
+	}
+
+	return events, attrRows.Err()
+}
+
+func (a Adapter) Query(ctx context.Context, q query.Query) (query.Cursor, error) {
+	db, err := a.getDB()
+	if err != nil {
+		return nil, err
+	}
+
+	sql, err := parseQuery(q)
+	if err != nil {
+		return nil, err
+	}
+
+	args := extractQueryArgs(q)
+	rows, err := db.QueryContext(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	return rows, nil
+}
+
+func (a Adapter) getDB() (*sql.DB, error) {
+	if a.db == nil {
+		return nil, ErrClosed
+	}
+
+	return a.db, nil
+}
+
+func createSQLiteURI(a Adapter) string {
+	params := url.Values{}
+	params.Add("_pragma", "foreign_keys(1)")
+	params.Add("_pragma", fmt.Sprintf("busy_timeout(%d)", a.busyTimeout))
+
+	uri := url.URL{
+		Scheme:   "file",
+		Opaque:   a.path,
+		RawQuery: params.Encode(),
+	}
+
+	return uri.String()
+}
+
+func saveRawTX(ctx context.Context, sqlTx *sql.Tx, rtx *ctypes.ResultTx) error {
+	hash := rtx.Hash.String()
+	raw, err := json.Marshal(rtx)
+	if err != nil {
+		return fmt.Errorf("failed to encode raw TX %s: %w", hash, err)
+	}
+
+	if _, err := sqlTx.ExecContext(ctx, sqlInsertRawTX, hash, string(raw)); err != nil {
+		return fmt.Errorf("error saving raw TX %s: %w", hash, err)
+	}
+
+	return nil
+}
+
+func saveTX(ctx context.Context, txStmt, evtStmt, attrStmt *sql.Stmt, tx cosmosclient.TX) error {
+	hash := tx.Raw.Hash.String()
+	res, err := txStmt.ExecContext(ctx, hash, tx.Raw.Index, tx.Raw.Height, tx.BlockTime)
+	if err != nil {
+		return fmt.Errorf("error saving TX %s: %w", hash, err)
+	}
+
+	// Skip the events when the TX was already saved so collecting
+	// the same block more than once doesn't duplicate them
+	if n, err := res.RowsAffected(); err != nil {
+		return fmt.Errorf("error saving TX %s: %w", hash, err)
+	} else if n == 0 {
+		return nil
+	}
+
+	events, err := tx.GetEvents()
+	if err != nil {
+		return err
+	}
+
+	for i, evt := range events {
+		res, err := evtStmt.ExecContext(ctx, hash, evt.Type, i)
+		if err != nil {
+			return fmt.Errorf("error saving event '%s': %w", evt.Type, err)
+		}
+
+		evtID, err := res.LastInsertId()
+		if err != nil {
+			return fmt.Errorf("error reading event ID: %w", err)
+		}
+
+		for _, attr := range evt.Attributes {
+			// Values are saved as text to be able to use SQLite JSON functions
+			if _, err := attrStmt.ExecContext(ctx, evtID, attr.Key, string(attr.Value)); err != nil {
+				return fmt.Errorf("error saving event attr '%s.%s': %w", evt.Type, attr.Key, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+func extractQueryArgs(q query.Query) []any {
+	// When the query is a call to a table-valued function
+	// add the arguments before the filter values
+	args := q.Args()
+
+	// Add the values from the filters
+	for _, f := range q.Filters() {
+		if a := f.Value(); a != nil {
+			args = append(args, a)
+		}
+	}
+
+	return args
+}
+
+func extractEventQueryArgs(q query.EventQuery) (args []any) {
+	for _, f := range q.Filters() {
+		if a := f.Value(); a != nil {
+			args = append(args, a)
+		}
+	}
+
+	return args
+}
@@ -0,0 +1,363 @@
+package sqlite_test
+
+import (
+	"context"
+	"encoding/hex"
+	"path/filepath"
+	"testing"
+	"time"
+
+	abci "github.com/cometbft/cometbft/abci/types"
+	ctypes "github.com/cometbft/cometbft/rpc/core/types"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ignite/cli/ignite/pkg/cosmosclient"
+	"github.com/ignite/cli/ignite/pkg/cosmostxcollector/adapter/sqlite"
+	"github.com/ignite/cli/ignite/pkg/cosmostxcollector/query"
+)
+
+const (
+	txHash    = "F2564C78071E26643AE9B3E2A19FA0DC10D4D9E873AA0BE808660123F11A1E78"
+	recipient = "cosmos1crje20aj4gxdtyct7z3knxqry2jqt2fuaey6u5"
+)
+
+func TestInit(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	adapter := createAdapter(t)
+
+	// Act
+	err := adapter.Init(ctx)
+
+	// Assert: Initializing again must not apply the schemas twice
+	require.NoError(t, err)
+	require.NoError(t, adapter.Init(ctx))
+	require.Equal(t, "sqlite", adapter.GetType())
+}
+
+func TestSave(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	adapter := createInitializedAdapter(t)
+	tx := createTX(t)
+
+	// Act
+	err := adapter.Save(ctx, []cosmosclient.TX{tx})
+
+	// Assert
+	require.NoError(t, err)
+
+	height, err := adapter.GetLatestHeight(ctx)
+	require.NoError(t, err)
+	require.Equal(t, tx.Raw.Height, height)
+}
+
+func TestSaveTwice(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	adapter := createInitializedAdapter(t)
+	tx := createTX(t)
+
+	require.NoError(t, adapter.Save(ctx, []cosmosclient.TX{tx}))
+
+	// Act: Saving a TX that was already saved must not duplicate its events
+	err := adapter.Save(ctx, []cosmosclient.TX{tx})
+
+	// Assert
+	require.NoError(t, err)
+
+	events, err := adapter.QueryEvents(ctx, query.NewEventQuery())
+	require.NoError(t, err)
+	require.Len(t, events, 2)
+}
+
+func TestSaveRollback(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	adapter := createInitializedAdapter(t)
+	tx := createTX(t)
+
+	// Arrange: An invalid TX with a duplicated event attribute
+	invalidTX := createTX(t)
+	invalidTX.Raw.Hash = []byte("invalid")
+	invalidTX.Raw.TxResult.Events[0].Attributes = append(
+		invalidTX.Raw.TxResult.Events[0].Attributes,
+		invalidTX.Raw.TxResult.Events[0].Attributes[0],
+	)
+
+	// Act
+	err := adapter.Save(ctx, []cosmosclient.TX{tx, invalidTX})
+
+	// Assert: None of the block TXs must be saved
+	require.Error(t, err)
+
+	height, err := adapter.GetLatestHeight(ctx)
+	require.NoError(t, err)
+	require.Zero(t, height)
+}
+
+func TestCheckpoint(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	adapter := createInitializedAdapter(t)
+
+	// Act
+	err := adapter.SaveCheckpoint(ctx, 42)
+
+	// Assert
+	require.NoError(t, err)
+
+	height, err := adapter.GetCheckpoint(ctx)
+	require.NoError(t, err)
+	require.EqualValues(t, 42, height)
+}
+
+func TestCheckpointAfterClose(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "collector.db")
+
+	adapter, err := sqlite.NewAdapter(path)
+	require.NoError(t, err)
+	require.NoError(t, adapter.Init(ctx))
+	require.NoError(t, adapter.SaveCheckpoint(ctx, 42))
+	require.NoError(t, adapter.Close())
+
+	// Act
+	adapter, err = sqlite.NewAdapter(path)
+	require.NoError(t, err)
+	defer adapter.Close()
+
+	height, err := adapter.GetCheckpoint(ctx)
+
+	// Assert
+	require.NoError(t, err)
+	require.EqualValues(t, 42, height)
+}
+
+func TestCheckpointWithoutBlocks(t *testing.T) {
+	// Arrange
+	adapter := createInitializedAdapter(t)
+
+	// Act
+	height, err := adapter.GetCheckpoint(context.Background())
+
+	// Assert
+	require.NoError(t, err)
+	require.Zero(t, height)
+}
+
+func TestGetLatestHeightWithoutTXs(t *testing.T) {
+	// Arrange
+	adapter := createInitializedAdapter(t)
+
+	// Act
+	height, err := adapter.GetLatestHeight(context.Background())
+
+	// Assert
+	require.NoError(t, err)
+	require.Zero(t, height)
+}
+
+func TestQuery(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	adapter := createInitializedAdapter(t)
+	tx := createTX(t)
+
+	require.NoError(t, adapter.Save(ctx, []cosmosclient.TX{tx}))
+
+	qry := query.New(
+		"tx",
+		query.Fields("hash", "height"),
+		query.WithFilters(sqlite.NewFilter("height", tx.Raw.Height)),
+		query.SortByFields(query.SortOrderDesc, "height"),
+	)
+
+	// Act
+	cr, err := adapter.Query(ctx, qry)
+
+	// Assert
+	require.NoError(t, err)
+	defer cr.Close()
+
+	var (
+		hash   string
+		height int64
+	)
+
+	require.True(t, cr.Next())
+	require.NoError(t, cr.Scan(&hash, &height))
+	require.Equal(t, txHash, hash)
+	require.Equal(t, tx.Raw.Height, height)
+	require.False(t, cr.Next())
+	require.NoError(t, cr.Err())
+}
+
+func TestQueryInvalidSortOrder(t *testing.T) {
+	// Arrange
+	adapter := createInitializedAdapter(t)
+	qry := query.New("tx", query.SortByFields("invalid", "height"))
+
+	// Act
+	_, err := adapter.Query(context.Background(), qry)
+
+	// Assert
+	require.ErrorIs(t, err, sqlite.ErrInvalidSortOrder)
+}
+
+func TestEventQuery(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	adapter := createInitializedAdapter(t)
+	tx := createTX(t)
+
+	require.NoError(t, adapter.Save(ctx, []cosmosclient.TX{tx}))
+
+	cases := []struct {
+		name    string
+		filters []query.Filter
+		want    int
+	}{
+		{
+			name: "without filters",
+			want: 2,
+		},
+		{
+			name:    "by event type",
+			filters: []query.Filter{sqlite.FilterByEventType("transfer")},
+			want:    1,
+		},
+		{
+			name:    "by TX hashes",
+			filters: []query.Filter{sqlite.FilterByEventTXs(txHash)},
+			want:    2,
+		},
+		{
+			name:    "by unknown TX hash",
+			filters: []query.Filter{sqlite.FilterByEventTXs("unknown")},
+		},
+		{
+			name: "by attribute value",
+			filters: []query.Filter{
+				sqlite.FilterByEventAttrName("recipient"),
+				sqlite.FilterByEventAttrValue(recipient),
+			},
+			want: 1,
+		},
+		{
+			name: "by attribute int value",
+			filters: []query.Filter{
+				sqlite.FilterByEventAttrName("count"),
+				sqlite.FilterByEventAttrValueInt(42),
+			},
+			want: 1,
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			qry := query.NewEventQuery(query.WithFilters(tt.filters...))
+
+			// Act
+			events, err := adapter.QueryEvents(ctx, qry)
+
+			// Assert
+			require.NoError(t, err)
+			require.Len(t, events, tt.want)
+		})
+	}
+}
+
+func TestEventQueryAttributes(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	adapter := createInitializedAdapter(t)
+	tx := createTX(t)
+
+	require.NoError(t, adapter.Save(ctx, []cosmosclient.TX{tx}))
+
+	qry := query.NewEventQuery(query.WithFilters(sqlite.FilterByEventType("transfer")))
+
+	// Act
+	events, err := adapter.QueryEvents(ctx, qry)
+
+	// Assert
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+
+	evt := events[0]
+	require.Equal(t, txHash, evt.TXHash)
+	require.Equal(t, "transfer", evt.Type)
+	require.Len(t, evt.Attributes, 1)
+	require.Equal(t, "recipient", evt.Attributes[0].Name)
+
+	v, err := evt.Attributes[0].Value()
+	require.NoError(t, err)
+	require.Equal(t, recipient, v)
+}
+
+func TestClosedAdapter(t *testing.T) {
+	// Arrange
+	adapter := sqlite.Adapter{}
+
+	// Act
+	_, err := adapter.GetLatestHeight(context.Background())
+
+	// Assert
+	require.ErrorIs(t, err, sqlite.ErrClosed)
+}
+
+func createAdapter(t *testing.T) sqlite.Adapter {
+	t.Helper()
+
+	adapter, err := sqlite.NewAdapter(filepath.Join(t.TempDir(), "collector.db"))
+	require.NoError(t, err)
+
+	t.Cleanup(func() {
+		adapter.Close()
+	})
+
+	return adapter
+}
+
+func createInitializedAdapter(t *testing.T) sqlite.Adapter {
+	t.Helper()
+
+	adapter := createAdapter(t)
+	require.NoError(t, adapter.Init(context.Background()))
+
+	return adapter
+}
+
+func createTX(t *testing.T) cosmosclient.TX {
+	t.Helper()
+
+	hash, err := hex.DecodeString(txHash)
+	require.NoError(t, err)
+
+	return cosmosclient.TX{
+		BlockTime: time.Now().UTC(),
+		Raw: &ctypes.ResultTx{
+			Hash:   hash,
+			Height: 42,
+			Index:  0,
+			TxResult: abci.ResponseDeliverTx{
+				Events: []abci.Event{
+					{
+						Type: "transfer",
+						Attributes: []abci.EventAttribute{
+							{Key: "recipient", Value: recipient},
+						},
+					},
+					{
+						Type: "message",
+						Attributes: []abci.EventAttribute{
+							{Key: "count", Value: "42"},
+						},
+					},
+				},
+			},
+		},
+	}
+}
@@ -13,7 +13,7 @@ import (
 //
 //go:generate mockery --name TXsCollector --filename txs_collector.go --with-expecter
 type TXsCollector interface {
-	CollectTXs(ctx context.Context, fromHeight int64, tc chan<- []cosmosclient.TX) error
+	CollectBlockTXs(ctx context.Context, fromHeight int64, bc chan<- cosmosclient.BlockTXs, options ...cosmosclient.CollectTXsOption) error
 }
 
 // New creates a new Cosmos transaction collector.
@@ -29,15 +29,17 @@ type Collector struct {
 
 // Collect gathers transactions for all blocks starting from a specific height.
 // Each group of block transactions is saved sequentially after being collected.
-func (c Collector) Collect(ctx context.Context, fromHeight int64) error {
-	tc := make(chan []cosmosclient.TX)
+// When the data backend supports checkpoints, a checkpoint is saved after each
+// group of block transactions is saved, and after each block without transactions.
+func (c Collector) Collect(ctx context.Context, fromHeight int64, options ...cosmosclient.CollectTXsOption) error {
+	bc := make(chan cosmosclient.BlockTXs)
 	wg, ctx := errgroup.WithContext(ctx)
 
 	// Start collecting block transactions.
-	// The transactions channel is closed by the client when all transactions
+	// The blocks channel is closed by the client when all transactions
 	// are collected or when an error occurs during the collection.
 	wg.Go(func() error {
-		return c.client.CollectTXs(ctx, fromHeight, tc)
+		return c.client.CollectBlockTXs(ctx, fromHeight, bc, options...)
 	})
 
 	// The transactions for each block are saved in "bulks" so they are not
@@ -45,8 +47,20 @@ func (c Collector) Collect(ctx context.Context, fromHeight int64) error {
 	// gaps that can occur if a group of transactions from a previous block
 	// fail to be saved.
 	wg.Go(func() error {
-		for txs := range tc {
-			if err := c.db.Save(ctx, txs); err != nil {
+		checkpointer, _ := c.db.(adapter.Checkpointer)
+
+		for b := range bc {
+			if err := c.db.Save(ctx, b.TXs); err != nil {
+				return err
+			}
+
+			if checkpointer == nil {
+				continue
+			}
+
+			// Blocks without transactions are also checkpointed
+			// so they are not collected again when resuming
+			if err := checkpointer.SaveCheckpoint(ctx, b.Height); err != nil {
 				return err
 			}
 		}
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"testing"
 
+	ctypes "github.com/cometbft/cometbft/rpc/core/types"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 
@@ -25,17 +26,17 @@ func TestCollector(t *testing.T) {
 
 	client := mocks.NewTXsCollector(t)
 	client.EXPECT().
-		CollectTXs(
+		CollectBlockTXs(
 			mock.Anything,
 			fromHeight,
-			mock.AnythingOfType("chan<- []cosmosclient.TX"),
+			mock.AnythingOfType("chan<- cosmosclient.BlockTXs"),
 		).
-		Run(func(ctx context.Context, fromHeight int64, tc chan<- []cosmosclient.TX) {
-			defer close(tc)
+		Run(func(ctx context.Context, fromHeight int64, bc chan<- cosmosclient.BlockTXs, _ ...cosmosclient.CollectTXsOption) {
+			defer close(bc)
 
 			// Send the collected block transactions
-			tc <- txs[0]
-			tc <- txs[1]
+			bc <- cosmosclient.BlockTXs{Height: 1, TXs: txs[0]}
+			bc <- cosmosclient.BlockTXs{Height: 2, TXs: txs[1]}
 		}).
 		Return(nil).
 		Times(1)
@@ -70,13 +71,13 @@ func TestCollectorWithCollectError(t *testing.T) {
 
 	client := mocks.NewTXsCollector(t)
 	client.EXPECT().
-		CollectTXs(
+		CollectBlockTXs(
 			mock.Anything,
 			mock.AnythingOfType("int64"),
-			mock.AnythingOfType("chan<- []cosmosclient.TX"),
+			mock.AnythingOfType("chan<- cosmosclient.BlockTXs"),
 		).
-		Run(func(ctx context.Context, fromHeight int64, tc chan<- []cosmosclient.TX) {
-			close(tc)
+		Run(func(ctx context.Context, fromHeight int64, bc chan<- cosmosclient.BlockTXs, _ ...cosmosclient.CollectTXsOption) {
+			close(bc)
 		}).
 		Return(wantErr).
 		Times(1)
@@ -101,16 +102,16 @@ func TestCollectorWithSaveError(t *testing.T) {
 
 	client := mocks.NewTXsCollector(t)
 	client.EXPECT().
-		CollectTXs(
+		CollectBlockTXs(
 			mock.Anything,
 			mock.AnythingOfType("int64"),
-			mock.AnythingOfType("chan<- []cosmosclient.TX"),
+			mock.AnythingOfType("chan<- cosmosclient.BlockTXs"),
 		).
-		Run(func(ctx context.Context, fromHeight int64, tc chan<- []cosmosclient.TX) {
-			defer close(tc)
+		Run(func(ctx context.Context, fromHeight int64, bc chan<- cosmosclient.BlockTXs, _ ...cosmosclient.CollectTXsOption) {
+			defer close(bc)
 
 			// Send the collected block transactions
-			tc <- txs
+			bc <- cosmosclient.BlockTXs{Height: 1, TXs: txs}
 		}).
 		Return(nil).
 		Times(1)
@@ -133,3 +134,67 @@ func TestCollectorWithSaveError(t *testing.T) {
 	// Assert
 	require.ErrorIs(t, err, wantErr)
 }
+
+// checkpointSaver is a data backend that supports checkpoints.
+type checkpointSaver struct {
+	*mocks.Saver
+
+	checkpoints []int64
+}
+
+func (s *checkpointSaver) SaveCheckpoint(_ context.Context, height int64) error {
+	s.checkpoints = append(s.checkpoints, height)
+	return nil
+}
+
+func (s *checkpointSaver) GetCheckpoint(context.Context) (int64, error) {
+	return 0, nil
+}
+
+func TestCollectorWithCheckpoint(t *testing.T) {
+	// Arrange
+	blocks := []cosmosclient.BlockTXs{
+		{Height: 1, TXs: []cosmosclient.TX{{Raw: &ctypes.ResultTx{Height: 1}}}},
+		{Height: 2},
+		{Height: 3, TXs: []cosmosclient.TX{{Raw: &ctypes.ResultTx{Height: 3}}, {Raw: &ctypes.ResultTx{Height: 3}}}},
+		{Height: 4},
+	}
+
+	client := mocks.NewTXsCollector(t)
+	client.EXPECT().
+		CollectBlockTXs(
+			mock.Anything,
+			mock.AnythingOfType("int64"),
+			mock.AnythingOfType("chan<- cosmosclient.BlockTXs"),
+			mock.Anything,
+		).
+		Run(func(ctx context.Context, fromHeight int64, bc chan<- cosmosclient.BlockTXs, _ ...cosmosclient.CollectTXsOption) {
+			defer close(bc)
+
+			// Send the collected block transactions
+			for _, b := range blocks {
+				bc <- b
+			}
+		}).
+		Return(nil).
+		Times(1)
+
+	db := &checkpointSaver{Saver: mocks.NewSaver(t)}
+	db.EXPECT().
+		Save(
+			mock.Anything,
+			mock.AnythingOfType("[]cosmosclient.TX"),
+		).
+		Return(nil).
+		Times(4)
+
+	c := cosmostxcollector.New(db, client)
+	ctx := context.Background()
+
+	// Act
+	err := c.Collect(ctx, 1, cosmosclient.CollectFollow())
+
+	// Assert
+	require.NoError(t, err)
+	require.Equal(t, []int64{1, 2, 3, 4}, db.checkpoints)
+}
@@ -23,13 +23,20 @@ func (_m *TXsCollector) EXPECT() *TXsCollector_Expecter {
 	return &TXsCollector_Expecter{mock: &_m.Mock}
 }
 
-// CollectTXs provides a mock function with given fields: ctx, fromHeight, tc
-func (_m *TXsCollector) CollectTXs(ctx context.Context, fromHeight int64, tc chan<- []cosmosclient.TX) error {
-	ret := _m.Called(ctx, fromHeight, tc)
+// CollectBlockTXs provides a mock function with given fields: ctx, fromHeight, bc, options
+func (_m *TXsCollector) CollectBlockTXs(ctx context.Context, fromHeight int64, bc chan<- cosmosclient.BlockTXs, options ...cosmosclient.CollectTXsOption) error {
+	_va := make([]interface{}, len(options))
+	for _i := range options {
+		_va[_i] = options[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, fromHeight, bc)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, int64, chan<- []cosmosclient.TX) error); ok {
-		r0 = rf(ctx, fromHeight, tc)
+	if rf, ok := ret.Get(0).(func(context.Context, int64, chan<- cosmosclient.BlockTXs, ...cosmosclient.CollectTXsOption) error); ok {
+		r0 = rf(ctx, fromHeight, bc, options...)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -37,27 +44,35 @@ func (_m *TXsCollector) CollectTXs(ctx context.Context, fromHeight int64, tc cha
 	return r0
 }
 
-// TXsCollector_CollectTXs_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CollectTXs'
-type TXsCollector_CollectTXs_Call struct {
+// TXsCollector_CollectBlockTXs_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CollectBlockTXs'
+type TXsCollector_CollectBlockTXs_Call struct {
 	*mock.Call
 }
 
-// CollectTXs is a helper method to define mock.On call
+// CollectBlockTXs is a helper method to define mock.On call
 //   - ctx context.Context
 //   - fromHeight int64
-//   - tc chan<- []cosmosclient.TX
-func (_e *TXsCollector_Expecter) CollectTXs(ctx interface{}, fromHeight interface{}, tc interface{}) *TXsCollector_CollectTXs_Call {
-	return &TXsCollector_CollectTXs_Call{Call: _e.mock.On("CollectTXs", ctx, fromHeight, tc)}
+//   - bc chan<- cosmosclient.BlockTXs
+//   - options ...cosmosclient.CollectTXsOption
+func (_e *TXsCollector_Expecter) CollectBlockTXs(ctx interface{}, fromHeight interface{}, bc interface{}, options ...interface{}) *TXsCollector_CollectBlockTXs_Call {
+	return &TXsCollector_CollectBlockTXs_Call{Call: _e.mock.On("CollectBlockTXs",
+		append([]interface{}{ctx, fromHeight, bc}, options...)...)}
 }
 
-func (_c *TXsCollector_CollectTXs_Call) Run(run func(ctx context.Context, fromHeight int64, tc chan<- []cosmosclient.TX)) *TXsCollector_CollectTXs_Call {
+func (_c *TXsCollector_CollectBlockTXs_Call) Run(run func(ctx context.Context, fromHeight int64, bc chan<- cosmosclient.BlockTXs, options ...cosmosclient.CollectTXsOption)) *TXsCollector_CollectBlockTXs_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(int64), args[2].(chan<- []cosmosclient.TX))
+		variadicArgs := make([]cosmosclient.CollectTXsOption, len(args)-3)
+		for i, a := range args[3:] {
+			if a != nil {
+				variadicArgs[i] = a.(cosmosclient.CollectTXsOption)
+			}
+		}
+		run(args[0].(context.Context), args[1].(int64), args[2].(chan<- cosmosclient.BlockTXs), variadicArgs...)
 	})
 	return _c
 }
 
-func (_c *TXsCollector_CollectTXs_Call) Return(_a0 error) *TXsCollector_CollectTXs_Call {
+func (_c *TXsCollector_CollectBlockTXs_Call) Return(_a0 error) *TXsCollector_CollectBlockTXs_Call {
 	_c.Call.Return(_a0)
 	return _c
 }
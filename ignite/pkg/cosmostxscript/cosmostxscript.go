@@ -0,0 +1,388 @@
+// Package cosmostxscript runs scripts of transactions, where each step broadcasts
+// a message of a module and checks the outcome of its transaction. The steps can
+// use variables, defined in the script or saved from the results of the previous
+// steps, like an ID emitted in an event.
+package cosmostxscript
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+
+	abci "github.com/cometbft/cometbft/abci/types"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	pathHash     = "hash"
+	pathEvents   = "events"
+	pathResponse = "response"
+)
+
+// varRe matches the references to the variables, like ${name}.
+var varRe = regexp.MustCompile(`\$\{([a-zA-Z0-9_.-]+)\}`)
+
+// ErrExpectation is returned when the outcome of a step is not the expected one.
+var ErrExpectation = errors.New("unexpected outcome")
+
+// Script is a list of steps that broadcast transactions.
+type Script struct {
+	// Vars are the variables available to all the steps.
+	Vars map[string]string `json:"vars"`
+
+	// Steps are the steps of the script, executed in order.
+	Steps []Step `json:"steps"`
+}
+
+// Step broadcasts a transaction with a message of a module.
+type Step struct {
+	// Name of the step, used in the reports.
+	Name string `json:"name"`
+
+	// From is the name or address of the account that signs the transaction.
+	From string `json:"from"`
+
+	// Module is the module of the message, like "bank".
+	Module string `json:"module"`
+
+	// Msg is the name of the message or of its RPC method, like "send".
+	Msg string `json:"msg"`
+
+	// Args are the fields of the message.
+	Args map[string]interface{} `json:"args"`
+
+	// Expect is the expected outcome of the transaction.
+	Expect Expect `json:"expect"`
+
+	// Save defines the variables to save from the result of the transaction.
+	// The values are paths of the result:
+	//  - "hash": the hash of the transaction.
+	//  - "events.<type>.<attribute>": an attribute of the first event of the type.
+	//  - "response.<field>": a field of the response of the message, nested
+	//    fields are separated by dots.
+	Save map[string]string `json:"save"`
+}
+
+// Expect is the expected outcome of a transaction.
+type Expect struct {
+	// Fail is true when the transaction is expected to fail.
+	Fail bool `json:"fail"`
+
+	// Error is a text that the error of a failed transaction must contain.
+	Error string `json:"error"`
+
+	// Events are the events that the transaction must emit.
+	Events []Event `json:"events"`
+}
+
+// Event is an event with attributes.
+type Event struct {
+	// Type of the event, like "transfer".
+	Type string `json:"type"`
+
+	// Attributes that the event must have.
+	Attributes map[string]string `json:"attributes"`
+}
+
+// Result is the result of the transaction of a step.
+type Result struct {
+	// Hash of the transaction.
+	Hash string
+
+	// Events emitted by the transaction.
+	Events []abci.Event
+
+	// Response is the response of the message encoded as JSON.
+	Response []byte
+
+	// Err is the error of the transaction when it failed.
+	Err error
+}
+
+// StepResult is the result of a step of the script.
+type StepResult struct {
+	// Step is the step with its variables expanded.
+	Step Step
+
+	// Result is the result of the transaction of the step.
+	Result Result
+
+	// Err is the error of the step, either an error while running it or a
+	// ErrExpectation when the outcome is not the expected one.
+	Err error
+}
+
+// Executor broadcasts the message of a step and returns the result of its transaction.
+// The error of a transaction rejected or failed on the node is set in the result,
+// so it's checked against the expected outcome. Other errors, like network ones,
+// are returned and stop the script.
+type Executor interface {
+	Execute(ctx context.Context, step Step, args []byte) (Result, error)
+}
+
+// Parse parses a script written in YAML or JSON.
+func Parse(data []byte) (Script, error) {
+	var s Script
+	if err := yaml.UnmarshalStrict(data, &s); err != nil {
+		return Script{}, fmt.Errorf("invalid script: %w", err)
+	}
+
+	for i := range s.Steps {
+		if s.Steps[i].Name == "" {
+			s.Steps[i].Name = fmt.Sprintf("step %d", i+1)
+		}
+
+		if s.Steps[i].Module == "" || s.Steps[i].Msg == "" {
+			return Script{}, fmt.Errorf("invalid script: %s: module and msg are required", s.Steps[i].Name)
+		}
+	}
+
+	return s, nil
+}
+
+// ParseFile parses a script file written in YAML or JSON.
+func ParseFile(path string) (Script, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return Script{}, err
+	}
+
+	return Parse(data)
+}
+
+// Run executes the steps of the script in order and calls onStep after each step.
+// The vars override the variables of the script. Run stops at the first step that
+// fails or that doesn't have the expected outcome.
+func (s Script) Run(ctx context.Context, exec Executor, vars map[string]string, onStep func(StepResult)) error {
+	scope := make(map[string]string, len(s.Vars)+len(vars))
+	for name, value := range s.Vars {
+		scope[name] = value
+	}
+	for name, value := range vars {
+		scope[name] = value
+	}
+
+	for _, step := range s.Steps {
+		r := runStep(ctx, exec, step, scope)
+		if onStep != nil {
+			onStep(r)
+		}
+
+		if r.Err != nil {
+			return fmt.Errorf("%s: %w", step.Name, r.Err)
+		}
+	}
+
+	return nil
+}
+
+func runStep(ctx context.Context, exec Executor, step Step, vars map[string]string) StepResult {
+	step, err := step.expand(vars)
+	if err != nil {
+		return StepResult{Step: step, Err: err}
+	}
+
+	args, err := json.Marshal(step.Args)
+	if err != nil {
+		return StepResult{Step: step, Err: err}
+	}
+
+	res, err := exec.Execute(ctx, step, args)
+	if err != nil {
+		return StepResult{Step: step, Err: err}
+	}
+
+	r := StepResult{Step: step, Result: res}
+	if r.Err = step.Expect.check(res); r.Err != nil {
+		return r
+	}
+
+	for name, path := range step.Save {
+		if vars[name], r.Err = res.lookup(path); r.Err != nil {
+			return r
+		}
+	}
+
+	return r
+}
+
+// expand returns the step with the references to the variables replaced by their values.
+func (s Step) expand(vars map[string]string) (Step, error) {
+	var err error
+	expand := func(v string) string {
+		return varRe.ReplaceAllStringFunc(v, func(ref string) string {
+			name := varRe.FindStringSubmatch(ref)[1]
+			value, ok := vars[name]
+			if !ok && err == nil {
+				err = fmt.Errorf("undefined variable %q", name)
+			}
+
+			return value
+		})
+	}
+
+	s.From = expand(s.From)
+	s.Args = expandValue(s.Args, expand).(map[string]interface{})
+	s.Expect.Error = expand(s.Expect.Error)
+
+	events := make([]Event, len(s.Expect.Events))
+	for i, e := range s.Expect.Events {
+		attrs := make(map[string]string, len(e.Attributes))
+		for k, v := range e.Attributes {
+			attrs[k] = expand(v)
+		}
+
+		events[i] = Event{Type: e.Type, Attributes: attrs}
+	}
+	s.Expect.Events = events
+
+	return s, err
+}
+
+// expandValue expands the strings of a value decoded from JSON.
+func expandValue(v interface{}, expand func(string) string) interface{} {
+	switch v := v.(type) {
+	case string:
+		return expand(v)
+	case map[string]interface{}:
+		m := make(map[string]interface{}, len(v))
+		for k, e := range v {
+			m[k] = expandValue(e, expand)
+		}
+
+		return m
+	case []interface{}:
+		l := make([]interface{}, len(v))
+		for i, e := range v {
+			l[i] = expandValue(e, expand)
+		}
+
+		return l
+	}
+
+	return v
+}
+
+// check checks that the result of a transaction is the expected one.
+func (e Expect) check(r Result) error {
+	if e.Fail {
+		if r.Err == nil {
+			return fmt.Errorf("%w: transaction succeeded but it was expected to fail", ErrExpectation)
+		}
+
+		if !strings.Contains(r.Err.Error(), e.Error) {
+			return fmt.Errorf("%w: error %q doesn't contain %q", ErrExpectation, r.Err, e.Error)
+		}
+
+		return nil
+	}
+
+	if r.Err != nil {
+		return fmt.Errorf("%w: transaction failed: %s", ErrExpectation, r.Err)
+	}
+
+	for _, event := range e.Events {
+		if !hasEvent(r.Events, event) {
+			return fmt.Errorf("%w: event %q with attributes %v not emitted", ErrExpectation, event.Type, event.Attributes)
+		}
+	}
+
+	return nil
+}
+
+func hasEvent(events []abci.Event, want Event) bool {
+	for _, e := range events {
+		if e.Type != want.Type {
+			continue
+		}
+
+		attrs := make(map[string]string, len(e.Attributes))
+		for _, a := range e.Attributes {
+			attrs[a.Key] = attributeValue(a.Value)
+		}
+
+		matches := true
+		for k, v := range want.Attributes {
+			if attrs[k] != v {
+				matches = false
+				break
+			}
+		}
+
+		if matches {
+			return true
+		}
+	}
+
+	return false
+}
+
+// attributeValue returns the value of an event attribute.
+// The values of the typed events are quoted JSON strings.
+func attributeValue(v string) string {
+	if s, err := strconv.Unquote(v); err == nil && strings.HasPrefix(v, `"`) {
+		return s
+	}
+
+	return v
+}
+
+// lookup returns the value of the result at the path.
+func (r Result) lookup(path string) (string, error) {
+	parts := strings.Split(path, ".")
+	switch {
+	case path == pathHash:
+		return r.Hash, nil
+
+	case parts[0] == pathEvents && len(parts) > 2:
+		// The types of the typed events contain dots, like "cosmos.bank.v1beta1.EventSend"
+		var (
+			eventType = strings.Join(parts[1:len(parts)-1], ".")
+			key       = parts[len(parts)-1]
+		)
+		for _, e := range r.Events {
+			if e.Type != eventType {
+				continue
+			}
+
+			for _, a := range e.Attributes {
+				if a.Key == key {
+					return attributeValue(a.Value), nil
+				}
+			}
+		}
+
+		return "", fmt.Errorf("event attribute %q not found", path)
+
+	case parts[0] == pathResponse && len(parts) > 1:
+		var v interface{}
+		if err := json.Unmarshal(r.Response, &v); err != nil {
+			return "", fmt.Errorf("invalid response: %w", err)
+		}
+
+		for _, p := range parts[1:] {
+			m, ok := v.(map[string]interface{})
+			if !ok {
+				return "", fmt.Errorf("response field %q not found", path)
+			}
+
+			if v, ok = m[p]; !ok {
+				return "", fmt.Errorf("response field %q not found", path)
+			}
+		}
+
+		if s, ok := v.(string); ok {
+			return s, nil
+		}
+
+		bz, err := json.Marshal(v)
+		return string(bz), err
+	}
+
+	return "", fmt.Errorf("invalid path %q, use %q, %q or %q", path, pathHash, "events.<type>.<attribute>", "response.<field>")
+}
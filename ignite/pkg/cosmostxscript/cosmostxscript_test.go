@@ -0,0 +1,198 @@
+package cosmostxscript_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	abci "github.com/cometbft/cometbft/abci/types"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ignite/cli/ignite/pkg/cosmostxscript"
+)
+
+const testScript = `
+vars:
+  title: hello
+steps:
+  - name: create post
+    from: alice
+    module: mars
+    msg: create-post
+    args:
+      title: ${title}
+    expect:
+      events:
+        - type: mars.mars.EventPostCreated
+          attributes:
+            creator: alice
+    save:
+      postID: events.mars.mars.EventPostCreated.id
+      txHash: hash
+  - from: ${author}
+    module: mars
+    msg: like-post
+    args:
+      id: ${postID}
+      tags: [first, "${title}"]
+    save:
+      likes: response.post.likes
+  - name: like post twice
+    from: bob
+    module: mars
+    msg: like-post
+    args:
+      id: ${postID}
+    expect:
+      fail: true
+      error: post ${postID} already liked
+`
+
+// executor returns the results in order and records the executed steps.
+// The error is returned once all the results are returned.
+type executor struct {
+	results []cosmostxscript.Result
+	err     error
+	steps   []cosmostxscript.Step
+	args    []string
+}
+
+func (e *executor) Execute(_ context.Context, step cosmostxscript.Step, args []byte) (cosmostxscript.Result, error) {
+	e.steps = append(e.steps, step)
+	e.args = append(e.args, string(args))
+
+	if len(e.results) == 0 {
+		return cosmostxscript.Result{}, e.err
+	}
+
+	r := e.results[0]
+	e.results = e.results[1:]
+
+	return r, nil
+}
+
+func TestParse(t *testing.T) {
+	s, err := cosmostxscript.Parse([]byte(testScript))
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{"title": "hello"}, s.Vars)
+	require.Len(t, s.Steps, 3)
+	require.Equal(t, "create post", s.Steps[0].Name)
+	require.Equal(t, "step 2", s.Steps[1].Name)
+	require.True(t, s.Steps[2].Expect.Fail)
+
+	// JSON is valid YAML
+	s, err = cosmostxscript.Parse([]byte(`{"steps":[{"module":"bank","msg":"send"}]}`))
+	require.NoError(t, err)
+	require.Equal(t, "bank", s.Steps[0].Module)
+
+	_, err = cosmostxscript.Parse([]byte(`{"steps":[{"module":"bank"}]}`))
+	require.EqualError(t, err, "invalid script: step 1: module and msg are required")
+
+	_, err = cosmostxscript.Parse([]byte(`{"steps":[{"module":"bank","msg":"send","unknown":true}]}`))
+	require.Error(t, err)
+}
+
+func TestScriptRun(t *testing.T) {
+	created := cosmostxscript.Result{
+		Hash: "ABCD",
+		Events: []abci.Event{
+			{Type: "message", Attributes: []abci.EventAttribute{{Key: "action", Value: "create"}}},
+			{
+				Type: "mars.mars.EventPostCreated",
+				Attributes: []abci.EventAttribute{
+					{Key: "creator", Value: `"alice"`},
+					{Key: "id", Value: `"7"`},
+				},
+			},
+		},
+	}
+	liked := cosmostxscript.Result{
+		Hash:     "EF01",
+		Response: []byte(`{"post":{"id":"7","likes":"1"}}`),
+	}
+	alreadyLiked := cosmostxscript.Result{
+		Err: errors.New("error code: '2' msg: 'post 7 already liked'"),
+	}
+
+	tests := []struct {
+		name          string
+		results       []cosmostxscript.Result
+		err           error
+		vars          map[string]string
+		expectedSteps int
+		expectedError string
+	}{
+		{
+			name:          "ok",
+			results:       []cosmostxscript.Result{created, liked, alreadyLiked},
+			vars:          map[string]string{"author": "bob"},
+			expectedSteps: 3,
+		},
+		{
+			name:          "fail: undefined variable",
+			results:       []cosmostxscript.Result{created},
+			expectedSteps: 2,
+			expectedError: `step 2: undefined variable "author"`,
+		},
+		{
+			name:          "fail: unexpected failure",
+			results:       []cosmostxscript.Result{alreadyLiked},
+			vars:          map[string]string{"author": "bob"},
+			expectedSteps: 1,
+			expectedError: "create post: unexpected outcome: transaction failed: error code: '2' msg: 'post 7 already liked'",
+		},
+		{
+			name:          "fail: unexpected success",
+			results:       []cosmostxscript.Result{created, liked, liked},
+			vars:          map[string]string{"author": "bob"},
+			expectedSteps: 3,
+			expectedError: "like post twice: unexpected outcome: transaction succeeded but it was expected to fail",
+		},
+		{
+			name:          "fail: executor error of a step expected to fail",
+			results:       []cosmostxscript.Result{created, liked},
+			err:           errors.New("connection refused"),
+			vars:          map[string]string{"author": "bob"},
+			expectedSteps: 3,
+			expectedError: "like post twice: connection refused",
+		},
+		{
+			name:          "fail: missing event",
+			results:       []cosmostxscript.Result{liked},
+			expectedSteps: 1,
+			expectedError: `create post: unexpected outcome: event "mars.mars.EventPostCreated" with attributes map[creator:alice] not emitted`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			s, err := cosmostxscript.Parse([]byte(testScript))
+			require.NoError(t, err)
+
+			var (
+				exec    = &executor{results: tt.results, err: tt.err}
+				results []cosmostxscript.StepResult
+			)
+
+			// Act
+			err = s.Run(context.Background(), exec, tt.vars, func(r cosmostxscript.StepResult) {
+				results = append(results, r)
+			})
+
+			// Assert
+			require.Len(t, results, tt.expectedSteps)
+			if tt.expectedError != "" {
+				require.EqualError(t, err, tt.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, `{"title":"hello"}`, exec.args[0])
+			require.Equal(t, "bob", exec.steps[1].From)
+			require.Equal(t, `{"id":"7","tags":["first","hello"]}`, exec.args[1])
+			require.Equal(t, "post 7 already liked", results[2].Step.Expect.Error)
+			for _, r := range results {
+				require.NoError(t, r.Err)
+			}
+		})
+	}
+}
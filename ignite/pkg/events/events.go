@@ -9,6 +9,7 @@ type ProgressIndication uint8
 
 const (
 	GroupError = "error"
+	GroupTest  = "test"
 )
 
 const (
@@ -39,6 +39,12 @@ const (
 	// CommandEnv represents go "env" command.
 	CommandEnv = "env"
 
+	// CommandList represents go "list" command.
+	CommandList = "list"
+
+	// CommandTest represents go "test" command.
+	CommandTest = "test"
+
 	// EnvGOARCH represents GOARCH variable.
 	EnvGOARCH = "GOARCH"
 	// EnvGOMOD represents GOMOD variable.
@@ -60,6 +66,12 @@ const (
 	FlagModValueReadOnly = "readonly"
 	// FlagOut represents out go flag.
 	FlagOut = "-o"
+	// FlagTrimPath represents trimpath go flag.
+	FlagTrimPath = "-trimpath"
+	// FlagJSON represents json go flag.
+	FlagJSON = "-json"
+	// FlagRun represents run go test flag.
+	FlagRun = "-run"
 )
 
 // Env returns the value of `go env name`.
@@ -148,6 +160,29 @@ func Install(ctx context.Context, path string, pkgs []string, options ...exec.Op
 	return exec.Exec(ctx, command, append(options, exec.StepOption(step.Workdir(path)))...)
 }
 
+// List runs go list with flags for the package patterns on path with options.
+func List(ctx context.Context, path string, flags, patterns []string, options ...exec.Option) error {
+	command := []string{
+		Name(),
+		CommandList,
+	}
+	command = append(command, flags...)
+	command = append(command, patterns...)
+	return exec.Exec(ctx, command, append(options, exec.StepOption(step.Workdir(path)))...)
+}
+
+// Test runs go test for the package patterns with flags on path with options.
+// The flags are added after the patterns so they can include test binary flags.
+func Test(ctx context.Context, path string, patterns, flags []string, options ...exec.Option) error {
+	command := []string{
+		Name(),
+		CommandTest,
+	}
+	command = append(command, patterns...)
+	command = append(command, flags...)
+	return exec.Exec(ctx, command, append(options, exec.StepOption(step.Workdir(path)))...)
+}
+
 // IsInstallError returns true if err is interpreted as a go install error.
 func IsInstallError(err error) bool {
 	if err == nil {
@@ -0,0 +1,136 @@
+package gomodule
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	"golang.org/x/mod/modfile"
+	"golang.org/x/mod/module"
+)
+
+const (
+	cycloneDXFormat      = "CycloneDX"
+	cycloneDXSpecVersion = "1.4"
+)
+
+// SBOM is a software bill of materials in CycloneDX format.
+type SBOM struct {
+	BOMFormat    string          `json:"bomFormat"`
+	SpecVersion  string          `json:"specVersion"`
+	Version      int             `json:"version"`
+	Metadata     SBOMMetadata    `json:"metadata"`
+	Components   []SBOMComponent `json:"components"`
+	Dependencies []SBOMDep       `json:"dependencies"`
+}
+
+// SBOMMetadata describes the module of the SBOM.
+type SBOMMetadata struct {
+	Timestamp string        `json:"timestamp"`
+	Tools     []SBOMTool    `json:"tools"`
+	Component SBOMComponent `json:"component"`
+}
+
+// SBOMTool is the tool that created the SBOM.
+type SBOMTool struct {
+	Vendor string `json:"vendor"`
+	Name   string `json:"name"`
+}
+
+// SBOMComponent is a Go module.
+type SBOMComponent struct {
+	BOMRef      string `json:"bom-ref"`
+	Type        string `json:"type"`
+	Name        string `json:"name"`
+	Version     string `json:"version,omitempty"`
+	Description string `json:"description,omitempty"`
+	PURL        string `json:"purl,omitempty"`
+	Scope       string `json:"scope,omitempty"`
+}
+
+// SBOMDep lists the components that a component depends on.
+type SBOMDep struct {
+	Ref       string   `json:"ref"`
+	DependsOn []string `json:"dependsOn"`
+}
+
+// NewSBOM returns the CycloneDX SBOM of the module defined by a go.mod file.
+// Version is the version of the module and timestamp the time when the SBOM
+// is created. The SBOM lists the required modules, after applying the
+// replacements, so the same go.mod always produces the same SBOM.
+func NewSBOM(f *modfile.File, version string, timestamp time.Time) SBOM {
+	main := newSBOMComponent(f.Module.Mod.Path, version, "application")
+
+	var (
+		components []SBOMComponent
+		direct     []string
+	)
+
+	for _, req := range f.Require {
+		c := newSBOMComponent(req.Mod.Path, req.Mod.Version, "library")
+		c.Scope = "required"
+
+		for _, rep := range f.Replace {
+			if rep.Old.Path != req.Mod.Path || (rep.Old.Version != "" && rep.Old.Version != req.Mod.Version) {
+				continue
+			}
+
+			if rep.New.Version == "" {
+				// Local replacements don't have a version
+				c = newSBOMComponent(req.Mod.Path, "", "library")
+				c.Scope = "required"
+				c.Description = fmt.Sprintf("replaced by the local directory %s", rep.New.Path)
+			} else {
+				c = newSBOMComponent(rep.New.Path, rep.New.Version, "library")
+				c.Scope = "required"
+				c.Description = fmt.Sprintf("replacement of %s", module.Version{Path: req.Mod.Path, Version: req.Mod.Version})
+			}
+		}
+
+		components = append(components, c)
+		if !req.Indirect {
+			direct = append(direct, c.BOMRef)
+		}
+	}
+
+	sort.Slice(components, func(i, j int) bool {
+		return components[i].BOMRef < components[j].BOMRef
+	})
+	sort.Strings(direct)
+
+	return SBOM{
+		BOMFormat:   cycloneDXFormat,
+		SpecVersion: cycloneDXSpecVersion,
+		Version:     1,
+		Metadata: SBOMMetadata{
+			Timestamp: timestamp.UTC().Format(time.RFC3339),
+			Tools:     []SBOMTool{{Vendor: "Ignite", Name: "ignite"}},
+			Component: main,
+		},
+		Components: components,
+		Dependencies: []SBOMDep{
+			{Ref: main.BOMRef, DependsOn: direct},
+		},
+	}
+}
+
+// JSON returns the SBOM in JSON format.
+func (s SBOM) JSON() ([]byte, error) {
+	return json.MarshalIndent(s, "", "  ")
+}
+
+func newSBOMComponent(path, version, componentType string) SBOMComponent {
+	purl := fmt.Sprintf("pkg:golang/%s", path)
+	if version != "" {
+		purl = fmt.Sprintf("%s@%s", purl, version)
+	}
+
+	return SBOMComponent{
+		BOMRef:  purl,
+		Type:    componentType,
+		Name:    path,
+		Version: version,
+		PURL:    purl,
+	}
+}
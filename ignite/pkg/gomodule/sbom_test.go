@@ -0,0 +1,100 @@
+package gomodule_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/mod/modfile"
+
+	"github.com/ignite/cli/ignite/pkg/gomodule"
+)
+
+func TestNewSBOM(t *testing.T) {
+	// Arrange
+	gomod := `module github.com/ignite/mars
+
+go 1.19
+
+require (
+	github.com/cosmos/cosmos-sdk v0.47.2
+	github.com/gogo/protobuf v1.3.2
+	github.com/spf13/cobra v1.6.1 // indirect
+	github.com/ignite/local v0.1.0
+)
+
+replace (
+	github.com/gogo/protobuf => github.com/regen-network/protobuf v1.3.3-alpha.regen.1
+	github.com/ignite/local => ../local
+)
+`
+	f, err := modfile.Parse("go.mod", []byte(gomod), nil)
+	require.NoError(t, err)
+	timestamp := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	// Act
+	sbom := gomodule.NewSBOM(f, "v1.0.0", timestamp)
+
+	// Assert
+	require.Equal(t, gomodule.SBOM{
+		BOMFormat:   "CycloneDX",
+		SpecVersion: "1.4",
+		Version:     1,
+		Metadata: gomodule.SBOMMetadata{
+			Timestamp: "2023-01-01T00:00:00Z",
+			Tools:     []gomodule.SBOMTool{{Vendor: "Ignite", Name: "ignite"}},
+			Component: gomodule.SBOMComponent{
+				BOMRef:  "pkg:golang/github.com/ignite/mars@v1.0.0",
+				Type:    "application",
+				Name:    "github.com/ignite/mars",
+				Version: "v1.0.0",
+				PURL:    "pkg:golang/github.com/ignite/mars@v1.0.0",
+			},
+		},
+		Components: []gomodule.SBOMComponent{
+			{
+				BOMRef:  "pkg:golang/github.com/cosmos/cosmos-sdk@v0.47.2",
+				Type:    "library",
+				Name:    "github.com/cosmos/cosmos-sdk",
+				Version: "v0.47.2",
+				PURL:    "pkg:golang/github.com/cosmos/cosmos-sdk@v0.47.2",
+				Scope:   "required",
+			},
+			{
+				BOMRef:      "pkg:golang/github.com/ignite/local",
+				Type:        "library",
+				Name:        "github.com/ignite/local",
+				Description: "replaced by the local directory ../local",
+				PURL:        "pkg:golang/github.com/ignite/local",
+				Scope:       "required",
+			},
+			{
+				BOMRef:      "pkg:golang/github.com/regen-network/protobuf@v1.3.3-alpha.regen.1",
+				Type:        "library",
+				Name:        "github.com/regen-network/protobuf",
+				Version:     "v1.3.3-alpha.regen.1",
+				Description: "replacement of github.com/gogo/protobuf@v1.3.2",
+				PURL:        "pkg:golang/github.com/regen-network/protobuf@v1.3.3-alpha.regen.1",
+				Scope:       "required",
+			},
+			{
+				BOMRef:  "pkg:golang/github.com/spf13/cobra@v1.6.1",
+				Type:    "library",
+				Name:    "github.com/spf13/cobra",
+				Version: "v1.6.1",
+				PURL:    "pkg:golang/github.com/spf13/cobra@v1.6.1",
+				Scope:   "required",
+			},
+		},
+		Dependencies: []gomodule.SBOMDep{
+			{
+				Ref: "pkg:golang/github.com/ignite/mars@v1.0.0",
+				DependsOn: []string{
+					"pkg:golang/github.com/cosmos/cosmos-sdk@v0.47.2",
+					"pkg:golang/github.com/ignite/local",
+					"pkg:golang/github.com/regen-network/protobuf@v1.3.3-alpha.regen.1",
+				},
+			},
+		},
+	}, sbom)
+}
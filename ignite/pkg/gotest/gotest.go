@@ -0,0 +1,266 @@
+// Package gotest finds the Go packages affected by source changes using the
+// import graph of a module and runs their tests.
+package gotest
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/ignite/cli/ignite/pkg/cmdrunner/exec"
+	"github.com/ignite/cli/ignite/pkg/cmdrunner/step"
+	"github.com/ignite/cli/ignite/pkg/gocmd"
+)
+
+// maxEventSize is the maximum size of an event printed by "go test -json".
+const maxEventSize = 1024 * 1024
+
+// Package is a Go package of a module.
+type Package struct {
+	ImportPath   string
+	Dir          string
+	GoFiles      []string
+	TestGoFiles  []string
+	XTestGoFiles []string
+	Imports      []string
+	TestImports  []string
+	XTestImports []string
+}
+
+// HasTests returns true when the package has test files.
+func (p Package) HasTests() bool {
+	return len(p.TestGoFiles)+len(p.XTestGoFiles) > 0
+}
+
+// Checksum returns the checksum of the Go and test files of the package.
+func (p Package) Checksum() (string, error) {
+	files := append(append(append([]string{}, p.GoFiles...), p.TestGoFiles...), p.XTestGoFiles...)
+	sort.Strings(files)
+
+	h := sha256.New()
+	for _, name := range files {
+		f, err := os.Open(filepath.Join(p.Dir, name))
+		if err != nil {
+			return "", err
+		}
+
+		h.Write([]byte(name))
+		_, err = io.Copy(h, f)
+		f.Close()
+		if err != nil {
+			return "", err
+		}
+	}
+
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// List returns the packages of the module in path.
+func List(ctx context.Context, path string) ([]Package, error) {
+	var out bytes.Buffer
+	err := gocmd.List(
+		ctx,
+		path,
+		[]string{gocmd.FlagJSON},
+		[]string{"./..."},
+		exec.StepOption(step.Stdout(&out)),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		pkgs []Package
+		dec  = json.NewDecoder(&out)
+	)
+	for {
+		var p Package
+		if err := dec.Decode(&p); errors.Is(err, io.EOF) {
+			break
+		} else if err != nil {
+			return nil, err
+		}
+
+		pkgs = append(pkgs, p)
+	}
+
+	return pkgs, nil
+}
+
+// Affected returns the import paths of the packages with tests that are affected
+// by the changed packages, sorted by import path. A package is affected when it
+// is changed, when it imports an affected package or when its tests import one.
+func Affected(pkgs []Package, changed ...string) []string {
+	// Index the packages that import each package
+	importedBy := make(map[string][]string)
+	for _, p := range pkgs {
+		for _, imp := range p.Imports {
+			importedBy[imp] = append(importedBy[imp], p.ImportPath)
+		}
+	}
+
+	affected := make(map[string]bool)
+	queue := append([]string{}, changed...)
+	for len(queue) > 0 {
+		path := queue[0]
+		queue = queue[1:]
+
+		if affected[path] {
+			continue
+		}
+
+		affected[path] = true
+		queue = append(queue, importedBy[path]...)
+	}
+
+	var paths []string
+	for _, p := range pkgs {
+		if !p.HasTests() {
+			continue
+		}
+
+		if affected[p.ImportPath] || importsAny(affected, p.TestImports, p.XTestImports) {
+			paths = append(paths, p.ImportPath)
+		}
+	}
+
+	sort.Strings(paths)
+
+	return paths
+}
+
+func importsAny(paths map[string]bool, imports ...[]string) bool {
+	for _, list := range imports {
+		for _, imp := range list {
+			if paths[imp] {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// Result is the result of the tests of a package.
+type Result struct {
+	// Package is the import path of the package.
+	Package string
+
+	// Passed is true when all the tests of the package passed.
+	Passed bool
+
+	// Elapsed is the time spent running the tests.
+	Elapsed time.Duration
+
+	// Output is the output of the failed tests.
+	Output []string
+}
+
+// testEvent is an event printed by "go test -json".
+type testEvent struct {
+	Action  string
+	Package string
+	Test    string
+	Elapsed float64
+	Output  string
+}
+
+// Run runs the tests of the packages of the module in path with the flags,
+// returning the results sorted by package. Test failures are returned as
+// results while an error is returned when the tests can't be run.
+func Run(ctx context.Context, path string, pkgs, flags []string) ([]Result, error) {
+	var out bytes.Buffer
+	runErr := gocmd.Test(
+		ctx,
+		path,
+		pkgs,
+		append([]string{gocmd.FlagJSON}, flags...),
+		exec.StepOption(step.Stdout(&out)),
+	)
+
+	results, err := parseEvents(&out)
+	if err != nil {
+		return nil, err
+	}
+
+	// A failed run without results means that the tests couldn't be built
+	if runErr != nil && len(results) == 0 {
+		return nil, runErr
+	}
+
+	return results, nil
+}
+
+func parseEvents(r io.Reader) ([]Result, error) {
+	var (
+		results = make(map[string]*Result)
+		outputs = make(map[string][]string)
+		scanner = bufio.NewScanner(r)
+	)
+
+	// Allow long output lines
+	scanner.Buffer(make([]byte, 0, bufio.MaxScanTokenSize), maxEventSize)
+
+	for scanner.Scan() {
+		var e testEvent
+		if err := json.Unmarshal(scanner.Bytes(), &e); err != nil {
+			// Skip the lines printed by the Go tool that are not events
+			continue
+		}
+
+		key := e.Package + "." + e.Test
+		switch e.Action {
+		case "output":
+			outputs[key] = append(outputs[key], strings.TrimRight(e.Output, "\n"))
+			continue
+		case "pass", "fail", "skip":
+		default:
+			continue
+		}
+
+		res, ok := results[e.Package]
+		if !ok {
+			res = &Result{Package: e.Package}
+			results[e.Package] = res
+		}
+
+		switch {
+		case e.Test == "":
+			// The package is finished
+			res.Passed = e.Action != "fail"
+			res.Elapsed = time.Duration(e.Elapsed * float64(time.Second))
+
+			// Keep the package output when it failed without failed tests, for example on panic
+			if !res.Passed && len(res.Output) == 0 {
+				res.Output = outputs[key]
+			}
+		case e.Action == "fail":
+			res.Output = append(res.Output, outputs[key]...)
+		}
+
+		delete(outputs, key)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	list := make([]Result, 0, len(results))
+	for _, r := range results {
+		list = append(list, *r)
+	}
+
+	sort.Slice(list, func(i, j int) bool { return list[i].Package < list[j].Package })
+
+	return list, nil
+}
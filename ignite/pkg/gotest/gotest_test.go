@@ -0,0 +1,82 @@
+package gotest_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ignite/cli/ignite/pkg/gotest"
+)
+
+func TestAffected(t *testing.T) {
+	pkgs := []gotest.Package{
+		{ImportPath: "mars/planet", TestGoFiles: []string{"planet_test.go"}},
+		{ImportPath: "mars/moon", Imports: []string{"mars/planet"}, TestGoFiles: []string{"moon_test.go"}},
+		{ImportPath: "mars/app", Imports: []string{"mars/moon"}},
+		{ImportPath: "mars/cmd", Imports: []string{"mars/app"}, XTestGoFiles: []string{"cmd_test.go"}},
+		{ImportPath: "mars/sim", XTestImports: []string{"mars/app"}, XTestGoFiles: []string{"sim_test.go"}},
+		{ImportPath: "mars/venus", TestGoFiles: []string{"venus_test.go"}},
+	}
+
+	cases := []struct {
+		name    string
+		changed []string
+		want    []string
+	}{
+		{
+			name:    "imported package",
+			changed: []string{"mars/planet"},
+			want:    []string{"mars/cmd", "mars/moon", "mars/planet", "mars/sim"},
+		},
+		{
+			name:    "package without tests",
+			changed: []string{"mars/app"},
+			want:    []string{"mars/cmd", "mars/sim"},
+		},
+		{
+			name:    "package not imported",
+			changed: []string{"mars/venus"},
+			want:    []string{"mars/venus"},
+		},
+		{
+			name: "no changes",
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, gotest.Affected(pkgs, tt.changed...))
+		})
+	}
+}
+
+func TestListAndRun(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+
+	// Act
+	pkgs, err := gotest.List(ctx, "testdata/mars")
+	require.NoError(t, err)
+
+	results, err := gotest.Run(ctx, "testdata/mars", gotest.Affected(pkgs, "mars/planet"), nil)
+
+	// Assert
+	require.NoError(t, err)
+	require.Len(t, pkgs, 3)
+	require.Len(t, results, 2)
+
+	require.Equal(t, "mars/moon", results[0].Package)
+	require.False(t, results[0].Passed)
+	require.Contains(t, results[0].Output, "--- FAIL: TestPlanet (0.00s)")
+
+	require.Equal(t, "mars/planet", results[1].Package)
+	require.True(t, results[1].Passed)
+	require.Empty(t, results[1].Output)
+
+	for _, p := range pkgs {
+		sum, err := p.Checksum()
+		require.NoError(t, err)
+		require.Len(t, sum, 64)
+	}
+}
@@ -0,0 +1,8 @@
+package app
+
+import "mars/moon"
+
+// Moon returns the planet of the moon.
+func Moon() string {
+	return moon.Planet()
+}
@@ -0,0 +1,8 @@
+package moon
+
+import "mars/planet"
+
+// Planet returns the planet of the moon.
+func Planet() string {
+	return planet.Name()
+}
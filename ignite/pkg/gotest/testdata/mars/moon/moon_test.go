@@ -0,0 +1,8 @@
+package moon
+
+import "testing"
+
+func TestPlanet(t *testing.T) {
+	t.Log("phobos")
+	t.Fatal("phobos is not a planet")
+}
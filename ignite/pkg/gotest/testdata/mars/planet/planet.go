@@ -0,0 +1,6 @@
+package planet
+
+// Name returns the name of the planet.
+func Name() string {
+	return "mars"
+}
@@ -0,0 +1,9 @@
+package planet
+
+import "testing"
+
+func TestName(t *testing.T) {
+	if Name() != "mars" {
+		t.Fatal("wrong name")
+	}
+}
@@ -0,0 +1,344 @@
+// Package jsonpatch applies JSON patch operations, as defined by RFC 6902,
+// to JSON documents decoded into Go values.
+package jsonpatch
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// Operation names.
+const (
+	OpAdd     = "add"
+	OpRemove  = "remove"
+	OpReplace = "replace"
+	OpMove    = "move"
+	OpCopy    = "copy"
+	OpTest    = "test"
+)
+
+// ErrPathNotFound is returned when a path doesn't exist in the document.
+var ErrPathNotFound = errors.New("path not found")
+
+// Operation is a JSON patch operation.
+type Operation struct {
+	// Op is the name of the operation.
+	Op string `json:"op"`
+
+	// Path is the JSON pointer (RFC 6901) of the target location.
+	Path string `json:"path"`
+
+	// From is the JSON pointer of the source location of move and copy operations.
+	From string `json:"from,omitempty"`
+
+	// Value is the value of add, replace and test operations.
+	Value interface{} `json:"value,omitempty"`
+}
+
+func (o Operation) String() string {
+	if o.From != "" {
+		return fmt.Sprintf("%s %s to %s", o.Op, o.From, o.Path)
+	}
+
+	return fmt.Sprintf("%s %s", o.Op, o.Path)
+}
+
+// Validate checks that the operation is well-formed without applying it.
+func (o Operation) Validate() error {
+	switch o.Op {
+	case OpAdd, OpRemove, OpReplace, OpTest:
+	case OpMove, OpCopy:
+		if _, err := parsePointer(o.From); err != nil {
+			return fmt.Errorf("invalid from: %w", err)
+		}
+
+		if o.Op == OpMove && strings.HasPrefix(o.Path+"/", o.From+"/") && o.Path != o.From {
+			return fmt.Errorf("cannot move %s into one of its children", o.From)
+		}
+	case "":
+		return errors.New("op is required")
+	default:
+		return fmt.Errorf("unknown op %q", o.Op)
+	}
+
+	if _, err := parsePointer(o.Path); err != nil {
+		return fmt.Errorf("invalid path: %w", err)
+	}
+
+	if _, err := normalize(o.Value); err != nil {
+		return fmt.Errorf("invalid value: %w", err)
+	}
+
+	return nil
+}
+
+// Apply applies the operation to a document and returns the patched document.
+// Objects of the document must be decoded as map[string]interface{} and arrays
+// as []interface{}. The document can be modified even when an error is returned.
+func (o Operation) Apply(doc interface{}) (interface{}, error) {
+	if err := o.Validate(); err != nil {
+		return nil, err
+	}
+
+	path, _ := parsePointer(o.Path)
+	value, _ := normalize(o.Value)
+
+	switch o.Op {
+	case OpAdd:
+		return add(doc, path, value)
+	case OpRemove:
+		return remove(doc, path)
+	case OpReplace:
+		return replace(doc, path, value)
+	case OpTest:
+		v, err := get(doc, path)
+		if err != nil {
+			return nil, err
+		}
+
+		if v, err = normalize(v); err != nil {
+			return nil, err
+		}
+
+		if !reflect.DeepEqual(v, value) {
+			return nil, fmt.Errorf("value of %s doesn't match", o.Path)
+		}
+
+		return doc, nil
+	}
+
+	from, _ := parsePointer(o.From)
+	value, err := get(doc, from)
+	if err != nil {
+		return nil, err
+	}
+
+	if o.Op == OpMove {
+		if doc, err = remove(doc, from); err != nil {
+			return nil, err
+		}
+	} else if value, err = normalize(value); err != nil {
+		// Copy the value so the document doesn't share it in two locations
+		return nil, err
+	}
+
+	return add(doc, path, value)
+}
+
+// Apply applies a list of operations to a document, in order, and returns the
+// patched document.
+func Apply(doc interface{}, ops ...Operation) (interface{}, error) {
+	for i, o := range ops {
+		var err error
+		if doc, err = o.Apply(doc); err != nil {
+			return nil, fmt.Errorf("operation %d (%s): %w", i, o, err)
+		}
+	}
+
+	return doc, nil
+}
+
+// pointer is a parsed JSON pointer.
+type pointer []string
+
+func parsePointer(s string) (pointer, error) {
+	if s == "" {
+		return pointer{}, nil
+	}
+
+	if !strings.HasPrefix(s, "/") {
+		return nil, fmt.Errorf("%q must start with '/'", s)
+	}
+
+	tokens := strings.Split(s[1:], "/")
+	for i, t := range tokens {
+		tokens[i] = strings.NewReplacer("~1", "/", "~0", "~").Replace(t)
+	}
+
+	return tokens, nil
+}
+
+func (p pointer) String() string {
+	var b strings.Builder
+	for _, t := range p {
+		b.WriteString("/")
+		b.WriteString(strings.NewReplacer("~", "~0", "/", "~1").Replace(t))
+	}
+
+	return b.String()
+}
+
+func get(doc interface{}, path pointer) (interface{}, error) {
+	for i, token := range path {
+		switch v := doc.(type) {
+		case map[string]interface{}:
+			value, ok := v[token]
+			if !ok {
+				return nil, fmt.Errorf("%w: %s", ErrPathNotFound, path[:i+1])
+			}
+
+			doc = value
+		case []interface{}:
+			index, err := arrayIndex(token, len(v)-1)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", path[:i+1], err)
+			}
+
+			doc = v[index]
+		default:
+			return nil, fmt.Errorf("%w: %s", ErrPathNotFound, path[:i+1])
+		}
+	}
+
+	return doc, nil
+}
+
+// update calls fn with the parent of the path location and the last path token.
+// The result of fn replaces the parent in the document, which is required when
+// the parent is an array that changes its length.
+func update(
+	doc interface{},
+	path pointer,
+	fn func(parent interface{}, token string) (interface{}, error),
+) (interface{}, error) {
+	parentPath, token := path[:len(path)-1], path[len(path)-1]
+	if len(parentPath) == 0 {
+		return fn(doc, token)
+	}
+
+	parent, err := get(doc, parentPath)
+	if err != nil {
+		return nil, err
+	}
+
+	parent, err = fn(parent, token)
+	if err != nil {
+		return nil, err
+	}
+
+	return replace(doc, parentPath, parent)
+}
+
+func add(doc interface{}, path pointer, value interface{}) (interface{}, error) {
+	if len(path) == 0 {
+		return value, nil
+	}
+
+	return update(doc, path, func(parent interface{}, token string) (interface{}, error) {
+		switch v := parent.(type) {
+		case map[string]interface{}:
+			v[token] = value
+			return v, nil
+		case []interface{}:
+			if token == "-" {
+				return append(v, value), nil
+			}
+
+			index, err := arrayIndex(token, len(v))
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", path, err)
+			}
+
+			v = append(v, nil)
+			copy(v[index+1:], v[index:])
+			v[index] = value
+			return v, nil
+		default:
+			return nil, fmt.Errorf("%w: %s", ErrPathNotFound, path[:len(path)-1])
+		}
+	})
+}
+
+func remove(doc interface{}, path pointer) (interface{}, error) {
+	if len(path) == 0 {
+		return nil, errors.New("cannot remove the whole document")
+	}
+
+	return update(doc, path, func(parent interface{}, token string) (interface{}, error) {
+		switch v := parent.(type) {
+		case map[string]interface{}:
+			if _, ok := v[token]; !ok {
+				return nil, fmt.Errorf("%w: %s", ErrPathNotFound, path)
+			}
+
+			delete(v, token)
+			return v, nil
+		case []interface{}:
+			index, err := arrayIndex(token, len(v)-1)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", path, err)
+			}
+
+			return append(v[:index], v[index+1:]...), nil
+		default:
+			return nil, fmt.Errorf("%w: %s", ErrPathNotFound, path)
+		}
+	})
+}
+
+func replace(doc interface{}, path pointer, value interface{}) (interface{}, error) {
+	if len(path) == 0 {
+		return value, nil
+	}
+
+	return update(doc, path, func(parent interface{}, token string) (interface{}, error) {
+		switch v := parent.(type) {
+		case map[string]interface{}:
+			if _, ok := v[token]; !ok {
+				return nil, fmt.Errorf("%w: %s", ErrPathNotFound, path)
+			}
+
+			v[token] = value
+			return v, nil
+		case []interface{}:
+			index, err := arrayIndex(token, len(v)-1)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", path, err)
+			}
+
+			v[index] = value
+			return v, nil
+		default:
+			return nil, fmt.Errorf("%w: %s", ErrPathNotFound, path)
+		}
+	})
+}
+
+// arrayIndex parses an array index token which must be between zero and max.
+func arrayIndex(token string, max int) (int, error) {
+	// Leading zeros are not allowed by the RFC
+	if token == "" || (len(token) > 1 && token[0] == '0') {
+		return 0, fmt.Errorf("invalid array index %q", token)
+	}
+
+	index, err := strconv.Atoi(token)
+	if err != nil {
+		return 0, fmt.Errorf("invalid array index %q", token)
+	}
+
+	if index < 0 || index > max {
+		return 0, fmt.Errorf("array index %d out of bounds", index)
+	}
+
+	return index, nil
+}
+
+// normalize returns a deep copy of a value that only contains the types
+// decoded by the JSON decoder.
+func normalize(v interface{}) (interface{}, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	var value interface{}
+	if err := json.Unmarshal(data, &value); err != nil {
+		return nil, err
+	}
+
+	return value, nil
+}
@@ -0,0 +1,167 @@
+package jsonpatch_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ignite/cli/ignite/pkg/jsonpatch"
+)
+
+func decode(t *testing.T, s string) interface{} {
+	t.Helper()
+
+	var v interface{}
+	require.NoError(t, json.Unmarshal([]byte(s), &v))
+	return v
+}
+
+func TestApply(t *testing.T) {
+	cases := []struct {
+		name     string
+		doc      string
+		ops      []jsonpatch.Operation
+		expected string
+	}{
+		{
+			name:     "add object member",
+			doc:      `{"a": {}}`,
+			ops:      []jsonpatch.Operation{{Op: jsonpatch.OpAdd, Path: "/a/b", Value: "c"}},
+			expected: `{"a": {"b": "c"}}`,
+		},
+		{
+			name:     "add replaces existing member",
+			doc:      `{"a": 1}`,
+			ops:      []jsonpatch.Operation{{Op: jsonpatch.OpAdd, Path: "/a", Value: 2}},
+			expected: `{"a": 2}`,
+		},
+		{
+			name: "append and insert array elements",
+			doc:  `{"a": [1, 3]}`,
+			ops: []jsonpatch.Operation{
+				{Op: jsonpatch.OpAdd, Path: "/a/-", Value: 4},
+				{Op: jsonpatch.OpAdd, Path: "/a/1", Value: 2},
+				{Op: jsonpatch.OpAdd, Path: "/a/0", Value: map[string]interface{}{"b": true}},
+			},
+			expected: `{"a": [{"b": true}, 1, 2, 3, 4]}`,
+		},
+		{
+			name: "remove",
+			doc:  `{"a": [1, 2, 3], "b": "c"}`,
+			ops: []jsonpatch.Operation{
+				{Op: jsonpatch.OpRemove, Path: "/a/1"},
+				{Op: jsonpatch.OpRemove, Path: "/b"},
+			},
+			expected: `{"a": [1, 3]}`,
+		},
+		{
+			name:     "replace",
+			doc:      `{"a": [1, {"b": "c"}]}`,
+			ops:      []jsonpatch.Operation{{Op: jsonpatch.OpReplace, Path: "/a/1/b", Value: "d"}},
+			expected: `{"a": [1, {"b": "d"}]}`,
+		},
+		{
+			name:     "move",
+			doc:      `{"a": {"b": [1]}, "c": {}}`,
+			ops:      []jsonpatch.Operation{{Op: jsonpatch.OpMove, From: "/a/b", Path: "/c/d"}},
+			expected: `{"a": {}, "c": {"d": [1]}}`,
+		},
+		{
+			name: "copy",
+			doc:  `{"a": {"b": [1]}}`,
+			ops: []jsonpatch.Operation{
+				{Op: jsonpatch.OpCopy, From: "/a", Path: "/c"},
+				{Op: jsonpatch.OpAdd, Path: "/c/b/-", Value: 2},
+			},
+			expected: `{"a": {"b": [1]}, "c": {"b": [1, 2]}}`,
+		},
+		{
+			name:     "test",
+			doc:      `{"a": {"b": [1, "c"]}}`,
+			ops:      []jsonpatch.Operation{{Op: jsonpatch.OpTest, Path: "/a", Value: map[string]interface{}{"b": []interface{}{1, "c"}}}},
+			expected: `{"a": {"b": [1, "c"]}}`,
+		},
+		{
+			name:     "escaped tokens",
+			doc:      `{"a/b": {"c~d": 1}}`,
+			ops:      []jsonpatch.Operation{{Op: jsonpatch.OpReplace, Path: "/a~1b/c~0d", Value: 2}},
+			expected: `{"a/b": {"c~d": 2}}`,
+		},
+		{
+			name:     "replace document",
+			doc:      `{"a": 1}`,
+			ops:      []jsonpatch.Operation{{Op: jsonpatch.OpReplace, Path: "", Value: []interface{}{}}},
+			expected: `[]`,
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			doc, err := jsonpatch.Apply(decode(t, tt.doc), tt.ops...)
+
+			require.NoError(t, err)
+			require.Equal(t, decode(t, tt.expected), doc)
+		})
+	}
+}
+
+func TestApplyErrors(t *testing.T) {
+	cases := []struct {
+		name string
+		ops  []jsonpatch.Operation
+		err  string
+	}{
+		{
+			name: "unknown op",
+			ops:  []jsonpatch.Operation{{Op: "set", Path: "/a"}},
+			err:  `operation 0 (set /a): unknown op "set"`,
+		},
+		{
+			name: "invalid path",
+			ops:  []jsonpatch.Operation{{Op: jsonpatch.OpAdd, Path: "a"}},
+			err:  `operation 0 (add a): invalid path: "a" must start with '/'`,
+		},
+		{
+			name: "missing parent",
+			ops: []jsonpatch.Operation{
+				{Op: jsonpatch.OpAdd, Path: "/a/b", Value: 1},
+				{Op: jsonpatch.OpAdd, Path: "/b/c/d", Value: 1},
+			},
+			err: "operation 1 (add /b/c/d): path not found: /b",
+		},
+		{
+			name: "remove missing member",
+			ops:  []jsonpatch.Operation{{Op: jsonpatch.OpRemove, Path: "/c"}},
+			err:  "operation 0 (remove /c): path not found: /c",
+		},
+		{
+			name: "array index out of bounds",
+			ops:  []jsonpatch.Operation{{Op: jsonpatch.OpReplace, Path: "/l/2", Value: 1}},
+			err:  "operation 0 (replace /l/2): /l/2: array index 2 out of bounds",
+		},
+		{
+			name: "invalid array index",
+			ops:  []jsonpatch.Operation{{Op: jsonpatch.OpAdd, Path: "/l/01", Value: 1}},
+			err:  `operation 0 (add /l/01): /l/01: invalid array index "01"`,
+		},
+		{
+			name: "move into child",
+			ops:  []jsonpatch.Operation{{Op: jsonpatch.OpMove, From: "/a", Path: "/a/b"}},
+			err:  "operation 0 (move /a to /a/b): cannot move /a into one of its children",
+		},
+		{
+			name: "test fails",
+			ops:  []jsonpatch.Operation{{Op: jsonpatch.OpTest, Path: "/l/0", Value: 2}},
+			err:  "operation 0 (test /l/0): value of /l/0 doesn't match",
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := jsonpatch.Apply(decode(t, `{"a": {}, "l": [1, 2]}`), tt.ops...)
+
+			require.EqualError(t, err, tt.err)
+		})
+	}
+}
@@ -21,6 +21,7 @@ func build(p protoPackage) Package {
 		Path:     p.dir,
 		Files:    br.buildFiles(),
 		Messages: br.buildMessages(),
+		Enums:    br.buildEnums(),
 		Services: br.toServices(p.services()),
 	}
 
@@ -63,29 +64,8 @@ func (b builder) buildMessages() (messages []Message) {
 				fields[field.Name] = field.Type
 			}
 
-			// some proto messages might be defined inside another proto messages.
-			// to represents these types, an underscore is used.
-			// e.g. if C message inside B, and B inside A: A_B_C.
-			var (
-				name   = message.Name
-				parent = message.Parent
-			)
-			for {
-				if parent == nil {
-					break
-				}
-
-				parentMessage, ok := parent.(*proto.Message)
-				if !ok {
-					break
-				}
-
-				name = fmt.Sprintf("%s_%s", parentMessage.Name, name)
-				parent = parentMessage.Parent
-			}
-
 			messages = append(messages, Message{
-				Name:               name,
+				Name:               nestedName(message.Name, message.Parent),
 				Path:               f.path,
 				HighestFieldNumber: highestFieldNumber,
 				Fields:             fields,
@@ -96,6 +76,41 @@ func (b builder) buildMessages() (messages []Message) {
 	return messages
 }
 
+func (b builder) buildEnums() (enums []Enum) {
+	for _, f := range b.p.files {
+		for _, enum := range f.enums {
+			enums = append(enums, Enum{
+				Name: nestedName(enum.Name, enum.Parent),
+				Path: f.path,
+			})
+		}
+	}
+
+	return enums
+}
+
+// nestedName returns the name of a type including the names of its parent messages.
+// some proto types might be defined inside proto messages.
+// to represents these types, an underscore is used.
+// e.g. if C message inside B, and B inside A: A_B_C.
+func nestedName(name string, parent proto.Visitee) string {
+	for {
+		if parent == nil {
+			break
+		}
+
+		parentMessage, ok := parent.(*proto.Message)
+		if !ok {
+			break
+		}
+
+		name = fmt.Sprintf("%s_%s", parentMessage.Name, name)
+		parent = parentMessage.Parent
+	}
+
+	return name
+}
+
 func (b builder) toServices(ps []*proto.Service) (services []Service) {
 	for _, service := range ps {
 		s := Service{
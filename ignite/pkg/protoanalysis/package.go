@@ -33,6 +33,9 @@ type Package struct {
 	// Messages is a list of proto messages defined in the package.
 	Messages []Message
 
+	// Enums is a list of proto enums defined in the package.
+	Enums []Enum
+
 	// Services is a list of RPC services.
 	Services []Service
 }
@@ -86,6 +89,15 @@ type Message struct {
 	Fields map[string]string
 }
 
+// Enum represents a proto enum.
+type Enum struct {
+	// Name of the enum.
+	Name string
+
+	// Path of the file where enum is defined at.
+	Path string
+}
+
 // Service is an RPC service.
 type Service struct {
 	// Name of the services.
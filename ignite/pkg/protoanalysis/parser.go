@@ -62,6 +62,7 @@ type file struct {
 	imports  []string // imported protos.
 	options  []*proto.Option
 	messages []*proto.Message
+	enums    []*proto.Enum
 	services []*proto.Service
 }
 
@@ -133,6 +134,7 @@ func (p *parser) parseFile(path string) error {
 		proto.WithImport(func(s *proto.Import) { pf.imports = append(pf.imports, s.Filename) }),
 		proto.WithOption(func(o *proto.Option) { pf.options = append(pf.options, o) }),
 		proto.WithMessage(func(m *proto.Message) { pf.messages = append(pf.messages, m) }),
+		proto.WithEnum(func(e *proto.Enum) { pf.enums = append(pf.enums, e) }),
 		proto.WithService(func(s *proto.Service) { pf.services = append(pf.services, s) }),
 	)
 
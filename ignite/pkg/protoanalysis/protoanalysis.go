@@ -84,6 +84,32 @@ func HasMessages(ctx context.Context, path string, names ...string) error {
 	return nil
 }
 
+// HasEnums checks if the proto package under path contains enums with given names.
+func HasEnums(ctx context.Context, path string, names ...string) error {
+	pkgs, err := Parse(ctx, NewCache(), path)
+	if err != nil {
+		return err
+	}
+
+	hasName := func(name string) error {
+		for _, pkg := range pkgs {
+			for _, enum := range pkg.Enums {
+				if enum.Name == name {
+					return nil
+				}
+			}
+		}
+		return fmt.Errorf("invalid proto enum name %s", name)
+	}
+
+	for _, name := range names {
+		if err := hasName(name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // IsImported checks if the proto package under path imports list of dependencies.
 func IsImported(path string, dependencies ...string) error {
 	f, err := ParseFile(path)
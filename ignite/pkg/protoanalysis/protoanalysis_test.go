@@ -17,6 +17,24 @@ func TestNestedMessages(t *testing.T) {
 	require.Equal(t, "A_B_C", pkg.Messages[2].Name)
 }
 
+func TestEnums(t *testing.T) {
+	packages, err := Parse(context.Background(), nil, "testdata/enums")
+	require.NoError(t, err)
+
+	pkg := packages[0]
+	require.Equal(t, []Enum{
+		{Name: "Status", Path: "testdata/enums/enums.proto"},
+		{Name: "A_Kind", Path: "testdata/enums/enums.proto"},
+	}, pkg.Enums)
+}
+
+func TestHasEnums(t *testing.T) {
+	ctx := context.Background()
+
+	require.NoError(t, HasEnums(ctx, "testdata/enums", "Status", "A_Kind"))
+	require.EqualError(t, HasEnums(ctx, "testdata/enums", "Kind"), "invalid proto enum name Kind")
+}
+
 func TestLiquidity(t *testing.T) {
 	packages, err := Parse(context.Background(), nil, "testdata/liquidity")
 	require.NoError(t, err)
@@ -0,0 +1,277 @@
+// Package protodecode decodes protocol buffer encoded values without generated Go types,
+// using the message definitions of .proto files.
+package protodecode
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/jhump/protoreflect/desc"
+	"github.com/jhump/protoreflect/desc/protoparse"
+	"github.com/jhump/protoreflect/dynamic"
+
+	"github.com/ignite/cli/ignite/pkg/cosmosreflect"
+)
+
+const anyMessage = "google.protobuf.Any"
+
+var (
+	// ErrUnknownMessage is returned when a message is not defined in the schema.
+	ErrUnknownMessage = errors.New("unknown message")
+
+	// ErrNoMatch is returned when a value can't be decoded with any of the candidate messages.
+	ErrNoMatch = errors.New("value doesn't match any message")
+)
+
+// AmbiguousError is returned when a value can be decoded with more than one
+// of the candidate messages, so the message of the value can't be known.
+type AmbiguousError struct {
+	// Messages are the full names of the messages that decode the value.
+	Messages []string
+}
+
+func (e AmbiguousError) Error() string {
+	return fmt.Sprintf("value matches more than one message: %s", strings.Join(e.Messages, ", "))
+}
+
+// Schema keeps the message definitions used to decode values.
+type Schema struct {
+	messages map[string]*desc.MessageDescriptor
+}
+
+// NewSchema returns a new empty schema.
+func NewSchema() *Schema {
+	return &Schema{
+		messages: make(map[string]*desc.MessageDescriptor),
+	}
+}
+
+// AddFile adds the messages defined in a .proto file to the schema.
+// The imports of the file are resolved from the directory that contains the
+// directories of its package, like "proto" for "proto/cosmos/bank/v1beta1/bank.proto",
+// or from the proto files registered by the Go packages linked in the binary.
+func (s *Schema) AddFile(path string) error {
+	dir, name, err := importPath(path)
+	if err != nil {
+		return fmt.Errorf("cannot parse %s: %w", path, err)
+	}
+
+	fds, err := cosmosreflect.NewProtoParser(dir).ParseFiles(name)
+	if err != nil {
+		return fmt.Errorf("cannot parse %s: %w", path, err)
+	}
+
+	for _, fd := range fds {
+		s.addMessages(fd.GetMessageTypes())
+	}
+
+	return nil
+}
+
+func (s *Schema) addMessages(mds []*desc.MessageDescriptor) {
+	for _, md := range mds {
+		// Map entries are only used as values of map fields
+		if md.IsMapEntry() {
+			continue
+		}
+
+		s.messages[md.GetFullyQualifiedName()] = md
+		s.addMessages(md.GetNestedMessageTypes())
+	}
+}
+
+// importPath returns the directory used to resolve the imports of a proto file and
+// the name of the file relative to it. The directory is the one that contains the
+// directories of the package of the file, or the directory of the file when it's
+// not in the directories of its package.
+func importPath(path string) (dir, name string, err error) {
+	fds, err := protoparse.Parser{}.ParseFilesButDoNotLink(path)
+	if err != nil {
+		return "", "", err
+	}
+
+	dir, name = filepath.Dir(path), filepath.Base(path)
+	if pkg := fds[0].GetPackage(); pkg != "" {
+		pkgDir := filepath.Join(strings.Split(pkg, ".")...)
+		if strings.HasSuffix(dir, string(filepath.Separator)+pkgDir) {
+			dir = strings.TrimSuffix(dir, string(filepath.Separator)+pkgDir)
+			name = filepath.Join(pkgDir, name)
+		}
+	}
+
+	return dir, filepath.ToSlash(name), nil
+}
+
+// Messages returns the full names of the messages in the schema sorted by name.
+func (s *Schema) Messages() []string {
+	names := make([]string, 0, len(s.messages))
+	for name := range s.messages {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Decode decodes a proto encoded value of a message.
+// The decoded message uses the field names as keys. An error is returned when the
+// value contains fields that are not defined in the message or its nested messages.
+func (s *Schema) Decode(data []byte, message string) (map[string]interface{}, error) {
+	md, ok := s.messages[message]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownMessage, message)
+	}
+
+	m := dynamic.NewMessage(md)
+	if err := m.Unmarshal(data); err != nil {
+		return nil, fmt.Errorf("%s: %w", message, err)
+	}
+
+	if err := checkFields(m); err != nil {
+		return nil, err
+	}
+
+	return s.messageValue(m), nil
+}
+
+// Guess decodes a proto encoded value with the candidate message that decodes it,
+// returning the name of the message used. The candidates are full message names.
+// An AmbiguousError is returned when more than one candidate decodes the value,
+// and ErrNoMatch when none does. Empty values are not decoded because they
+// match any message.
+func (s *Schema) Guess(data []byte, candidates ...string) (message string, value map[string]interface{}, err error) {
+	if len(data) == 0 {
+		return "", nil, ErrNoMatch
+	}
+
+	var matches []string
+	for _, name := range candidates {
+		v, err := s.Decode(data, name)
+		if err != nil || len(v) == 0 {
+			continue
+		}
+
+		matches = append(matches, name)
+		message, value = name, v
+	}
+
+	switch len(matches) {
+	case 0:
+		return "", nil, ErrNoMatch
+	case 1:
+		return message, value, nil
+	default:
+		return "", nil, AmbiguousError{Messages: matches}
+	}
+}
+
+// checkFields returns an error when the message or one of its nested messages
+// contains fields that are not defined.
+func checkFields(m *dynamic.Message) error {
+	if unknown := m.GetUnknownFields(); len(unknown) > 0 {
+		sort.Slice(unknown, func(i, j int) bool { return unknown[i] < unknown[j] })
+		return fmt.Errorf("field %d is not defined in %s", unknown[0], m.GetMessageDescriptor().GetFullyQualifiedName())
+	}
+
+	for _, fd := range m.GetMessageDescriptor().GetFields() {
+		if fd.GetMessageType() == nil || !m.HasField(fd) {
+			continue
+		}
+
+		if err := checkValue(m.GetField(fd)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func checkValue(v interface{}) error {
+	switch v := v.(type) {
+	case *dynamic.Message:
+		return checkFields(v)
+	case []interface{}:
+		for _, e := range v {
+			if err := checkValue(e); err != nil {
+				return err
+			}
+		}
+	case map[interface{}]interface{}:
+		for _, e := range v {
+			if err := checkValue(e); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// messageValue returns the fields of a message that are set using the field names as keys.
+// The values of the Any messages are decoded when their message is defined in the schema.
+func (s *Schema) messageValue(m *dynamic.Message) map[string]interface{} {
+	md := m.GetMessageDescriptor()
+	if md.GetFullyQualifiedName() == anyMessage {
+		if v, ok := s.anyValue(m); ok {
+			return v
+		}
+	}
+
+	value := make(map[string]interface{})
+	for _, fd := range md.GetFields() {
+		if m.HasField(fd) {
+			value[fd.GetName()] = s.fieldValue(fd, m.GetField(fd))
+		}
+	}
+
+	return value
+}
+
+// anyValue decodes the value of an Any message with the message of its type URL.
+func (s *Schema) anyValue(m *dynamic.Message) (map[string]interface{}, bool) {
+	typeURL, _ := m.GetFieldByName("type_url").(string)
+	data, _ := m.GetFieldByName("value").([]byte)
+
+	v, err := s.Decode(data, typeURL[strings.LastIndex(typeURL, "/")+1:])
+	if err != nil {
+		return nil, false
+	}
+
+	v["@type"] = typeURL
+
+	return v, true
+}
+
+func (s *Schema) fieldValue(fd *desc.FieldDescriptor, v interface{}) interface{} {
+	switch v := v.(type) {
+	case *dynamic.Message:
+		return s.messageValue(v)
+	case []interface{}:
+		values := make([]interface{}, len(v))
+		for i, e := range v {
+			values[i] = s.fieldValue(fd, e)
+		}
+
+		return values
+	case map[interface{}]interface{}:
+		values := make(map[string]interface{}, len(v))
+		for k, e := range v {
+			values[fmt.Sprint(k)] = s.fieldValue(fd.GetMapValueType(), e)
+		}
+
+		return values
+	case []byte:
+		return base64.StdEncoding.EncodeToString(v)
+	case int32:
+		if e := fd.GetEnumType(); e != nil {
+			if ev := e.FindValueByNumber(v); ev != nil {
+				return ev.GetName()
+			}
+		}
+	}
+
+	return v
+}
@@ -0,0 +1,157 @@
+package protodecode_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protowire"
+
+	"github.com/ignite/cli/ignite/pkg/protodecode"
+)
+
+func newSchema(t *testing.T) *protodecode.Schema {
+	t.Helper()
+
+	s := protodecode.NewSchema()
+	require.NoError(t, s.AddFile("testdata/proto/mars/mars/planet.proto"))
+	require.NoError(t, s.AddFile("testdata/proto/mars/mars/params.proto"))
+	return s
+}
+
+func appendString(b []byte, num protowire.Number, s string) []byte {
+	b = protowire.AppendTag(b, num, protowire.BytesType)
+	return protowire.AppendString(b, s)
+}
+
+func appendVarint(b []byte, num protowire.Number, v uint64) []byte {
+	b = protowire.AppendTag(b, num, protowire.VarintType)
+	return protowire.AppendVarint(b, v)
+}
+
+func appendMessage(b []byte, num protowire.Number, m []byte) []byte {
+	b = protowire.AppendTag(b, num, protowire.BytesType)
+	return protowire.AppendBytes(b, m)
+}
+
+func encodePlanet() []byte {
+	var b []byte
+	b = appendString(b, 1, "mars")
+	b = appendVarint(b, 2, 3389)
+	b = appendMessage(b, 3, appendString(nil, 1, "phobos"))
+	b = appendMessage(b, 3, appendString(nil, 1, "deimos"))
+	b = appendMessage(b, 4, protowire.AppendVarint(protowire.AppendVarint(nil, 10), 20))
+	b = appendVarint(b, 5, 1)
+	b = appendMessage(b, 6, appendVarint(appendString(nil, 1, "iron"), 2, 42))
+	b = appendMessage(b, 7, appendString(appendString(nil, 1, "stake"), 2, "100"))
+	b = appendVarint(b, 8, 1)
+	b = appendMessage(b, 9, appendString(nil, 1, "stake"))
+	return b
+}
+
+func TestSchemaMessages(t *testing.T) {
+	s := newSchema(t)
+
+	require.Equal(t, []string{
+		"mars.mars.Params",
+		"mars.mars.Planet",
+		"mars.mars.Planet.Moon",
+	}, s.Messages())
+}
+
+func TestDecode(t *testing.T) {
+	// Arrange
+	s := newSchema(t)
+
+	// Act
+	v, err := s.Decode(encodePlanet(), "mars.mars.Planet")
+
+	// Assert
+	require.NoError(t, err)
+	require.Equal(t, map[string]interface{}{
+		"name":   "mars",
+		"radius": uint64(3389),
+		"moons": []interface{}{
+			map[string]interface{}{"name": "phobos"},
+			map[string]interface{}{"name": "deimos"},
+		},
+		"craters":   []interface{}{uint32(10), uint32(20)},
+		"status":    "STATUS_ACTIVE",
+		"resources": map[string]interface{}{"iron": int64(42)},
+		"price":     map[string]interface{}{"denom": "stake", "amount": "100"},
+		"habitable": true,
+		"params":    map[string]interface{}{"bond_denom": "stake"},
+	}, v)
+}
+
+func TestDecodeErrors(t *testing.T) {
+	s := newSchema(t)
+
+	cases := []struct {
+		name    string
+		data    []byte
+		message string
+		err     string
+	}{
+		{
+			name:    "unknown message",
+			message: "mars.mars.Moon",
+			err:     "unknown message: mars.mars.Moon",
+		},
+		{
+			name:    "unknown field",
+			data:    appendString(nil, 10, "foo"),
+			message: "mars.mars.Planet",
+			err:     "field 10 is not defined in mars.mars.Planet",
+		},
+		{
+			name:    "unknown field of nested message",
+			data:    appendMessage(nil, 3, appendString(nil, 2, "foo")),
+			message: "mars.mars.Planet",
+			err:     "field 2 is not defined in mars.mars.Planet.Moon",
+		},
+		{
+			name:    "wrong wire type",
+			data:    appendVarint(nil, 1, 1),
+			message: "mars.mars.Planet",
+			err:     "mars.mars.Planet: bad input; field mars.mars.Planet.name requires length-delimited wire type",
+		},
+		{
+			name:    "truncated value",
+			data:    appendString(nil, 1, "mars")[:3],
+			message: "mars.mars.Planet",
+			err:     "mars.mars.Planet: unexpected EOF",
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := s.Decode(tt.data, tt.message)
+
+			require.EqualError(t, err, tt.err)
+		})
+	}
+}
+
+func TestGuess(t *testing.T) {
+	s := newSchema(t)
+	candidates := []string{"mars.mars.Params", "mars.mars.Planet"}
+
+	// The planet has fields that are not defined in the params
+	message, v, err := s.Guess(encodePlanet(), candidates...)
+	require.NoError(t, err)
+	require.Equal(t, "mars.mars.Planet", message)
+	require.Equal(t, "mars", v["name"])
+
+	// The params also match the planet which has a string in field 1
+	_, _, err = s.Guess(appendString(nil, 1, "stake"), candidates...)
+	require.Equal(t, protodecode.AmbiguousError{Messages: candidates}, err)
+	require.EqualError(t, err, "value matches more than one message: mars.mars.Params, mars.mars.Planet")
+
+	// Empty values match any message
+	_, _, err = s.Guess(nil, candidates...)
+	require.ErrorIs(t, err, protodecode.ErrNoMatch)
+
+	// Values that don't match any message
+	_, _, err = s.Guess([]byte{0xff, 0xff}, candidates...)
+	require.ErrorIs(t, err, protodecode.ErrNoMatch)
+}
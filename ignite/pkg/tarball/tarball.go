@@ -4,8 +4,12 @@ import (
 	"archive/tar"
 	"compress/gzip"
 	"errors"
+	"fmt"
 	"io"
+	"io/fs"
+	"os"
 	"path/filepath"
+	"time"
 )
 
 var (
@@ -55,3 +59,87 @@ func ExtractFile(reader io.Reader, out io.Writer, fileName string) (string, erro
 		}
 	}
 }
+
+// Create writes a gzipped tarball with the content of dir to out.
+// The tarball is reproducible: the entries are sorted by name, their owner and
+// permissions are normalized and their modification time is set to modTime, so
+// the same content always produces the same tarball.
+func Create(out io.Writer, dir string, modTime time.Time) error {
+	gz, err := gzip.NewWriterLevel(out, gzip.BestCompression)
+	if err != nil {
+		return err
+	}
+
+	gz.ModTime = modTime
+	tw := tar.NewWriter(gz)
+
+	// WalkDir walks the files in lexical order
+	err = filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		name, err := filepath.Rel(dir, path)
+		if err != nil || name == "." {
+			return err
+		}
+
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+
+		header := &tar.Header{
+			Name:    filepath.ToSlash(name),
+			ModTime: modTime,
+			Mode:    0o644,
+		}
+
+		switch {
+		case d.IsDir():
+			header.Typeflag = tar.TypeDir
+			header.Name += "/"
+			header.Mode = 0o755
+		case info.Mode()&fs.ModeSymlink != 0:
+			header.Typeflag = tar.TypeSymlink
+			header.Mode = 0o777
+			if header.Linkname, err = os.Readlink(path); err != nil {
+				return err
+			}
+		case info.Mode().IsRegular():
+			header.Typeflag = tar.TypeReg
+			header.Size = info.Size()
+			if info.Mode()&0o111 != 0 {
+				header.Mode = 0o755
+			}
+		default:
+			return fmt.Errorf("%s: unsupported file type", name)
+		}
+
+		if err := tw.WriteHeader(header); err != nil {
+			return err
+		}
+
+		if header.Typeflag != tar.TypeReg {
+			return nil
+		}
+
+		f, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+
+		_, err = io.Copy(tw, f)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	if err := tw.Close(); err != nil {
+		return err
+	}
+
+	return gz.Close()
+}
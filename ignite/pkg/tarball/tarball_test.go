@@ -1,9 +1,16 @@
 package tarball
 
 import (
+	"archive/tar"
 	"bytes"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
 	"os"
+	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 )
@@ -95,3 +102,52 @@ func TestExtractFile(t *testing.T) {
 		})
 	}
 }
+
+func TestCreate(t *testing.T) {
+	// Arrange
+	modTime := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	createDir := func(fileTime time.Time) string {
+		dir := t.TempDir()
+		require.NoError(t, os.MkdirAll(filepath.Join(dir, "bin"), 0o700))
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "bin", "marsd"), []byte("binary"), 0o700))
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "README.md"), []byte("readme"), 0o600))
+		for _, name := range []string{"bin/marsd", "README.md", "bin"} {
+			require.NoError(t, os.Chtimes(filepath.Join(dir, name), fileTime, fileTime))
+		}
+		return dir
+	}
+
+	// Act
+	var first, second bytes.Buffer
+	err := Create(&first, createDir(time.Now()), modTime)
+	require.NoError(t, err)
+	err = Create(&second, createDir(time.Now().Add(time.Hour)), modTime)
+	require.NoError(t, err)
+
+	// Assert
+	require.Equal(t, first.Bytes(), second.Bytes())
+
+	gz, err := gzip.NewReader(bytes.NewReader(first.Bytes()))
+	require.NoError(t, err)
+	require.Equal(t, modTime, gz.ModTime.UTC())
+
+	var headers []string
+	tr := tar.NewReader(gz)
+	for {
+		h, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		require.NoError(t, err)
+		require.Equal(t, modTime, h.ModTime.UTC())
+		require.Zero(t, h.Uid)
+		headers = append(headers, fmt.Sprintf("%s %o", h.Name, h.Mode))
+	}
+	require.Equal(t, []string{"README.md 644", "bin/ 755", "bin/marsd 755"}, headers)
+
+	var buf bytes.Buffer
+	path, err := ExtractFile(bytes.NewReader(first.Bytes()), &buf, "marsd")
+	require.NoError(t, err)
+	require.Equal(t, "bin/marsd", path)
+	require.Equal(t, "binary", buf.String())
+}
@@ -3,12 +3,15 @@ package xgenny
 import (
 	"context"
 	"errors"
+	"fmt"
 	"os"
+	"path/filepath"
 	"strings"
 
 	"github.com/gobuffalo/genny/v2"
 	"github.com/gobuffalo/logger"
 	"github.com/gobuffalo/packd"
+	"github.com/pmezard/go-difflib/difflib"
 
 	"github.com/ignite/cli/ignite/pkg/placeholder"
 	"github.com/ignite/cli/ignite/pkg/validation"
@@ -84,6 +87,152 @@ func RunWithValidation(
 	return sm, nil
 }
 
+// DryRun runs the generators in memory, in the given order, without writing to
+// the file system. Generators see the files written by the previous ones.
+// Relative file names are resolved from root.
+// The returned source modification contains the unified diff of each created
+// and modified file, placeholder insertions included, with file names relative
+// to root.
+func DryRun(
+	ctx context.Context,
+	root string,
+	tracer *placeholder.Tracer,
+	gens ...*genny.Generator,
+) (SourceModification, error) {
+	return NewMemoryRunner(root).Run(ctx, tracer, gens...)
+}
+
+// MemoryRunner runs generators in memory without writing to the file system.
+// The files written by a run are kept in memory, so the generators of the
+// next runs see them.
+type MemoryRunner struct {
+	root  string
+	files map[string]string
+}
+
+// NewMemoryRunner creates a new memory runner that resolves relative file
+// names from root.
+func NewMemoryRunner(root string) *MemoryRunner {
+	return &MemoryRunner{
+		root:  root,
+		files: make(map[string]string),
+	}
+}
+
+// Run runs the generators in memory, in the given order. Generators see the
+// files written by the previous ones, including the ones from previous runs.
+// The returned source modification contains the files written by the run.
+// The unified diff of each file is made against its content in the file
+// system, so it includes the changes of the previous runs.
+func (m *MemoryRunner) Run(
+	ctx context.Context,
+	tracer *placeholder.Tracer,
+	gens ...*genny.Generator,
+) (sm SourceModification, err error) {
+	runner := DryRunner(ctx)
+	runner.Root = m.root
+	// Use absolute file names so the generators that modify files find the
+	// ones created in memory by the previous generators.
+	runner.FileFn = func(f genny.File) (genny.File, error) {
+		if filepath.IsAbs(f.Name()) {
+			return f, nil
+		}
+		return genny.NewFile(filepath.Join(m.root, f.Name()), f), nil
+	}
+	for name, content := range m.files {
+		runner.Disk.Add(genny.NewFileS(name, content))
+	}
+	for _, gen := range gens {
+		if err := runner.With(gen); err != nil {
+			return sm, err
+		}
+	}
+	if err := runner.Run(); err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return sm, &dryRunError{err}
+		}
+		return sm, err
+	}
+	if err := tracer.Err(); err != nil {
+		return sm, err
+	}
+
+	sm = NewSourceModification()
+	for _, file := range runner.Results().Files {
+		fileName := file.Name()
+		content := file.String()
+		if previous, ok := m.files[fileName]; ok && previous == content {
+			// the file was not written by this run
+			continue
+		}
+		m.files[fileName] = content
+
+		original, err := os.ReadFile(fileName)
+		created := os.IsNotExist(err)
+
+		//nolint:gocritic
+		if created {
+			sm.AppendCreatedFiles(fileName)
+		} else if err != nil {
+			return sm, err
+		} else {
+			sm.AppendModifiedFiles(fileName)
+		}
+
+		// Name the files relative to root in the diff, like git does, so it
+		// can be applied with "git apply" or "patch -p1" from root.
+		name, err := filepath.Rel(m.root, fileName)
+		if err != nil {
+			return sm, err
+		}
+		diff, err := unifiedDiff(name, string(original), content, created)
+		if err != nil {
+			return sm, err
+		}
+		if diff == "" {
+			continue
+		}
+		sm.SetDiff(fileName, diff)
+	}
+	return sm, nil
+}
+
+// unifiedDiff returns the unified diff between the original and the new
+// content of a file. The diff is empty when the content is unchanged.
+func unifiedDiff(fileName, original, content string, created bool) (string, error) {
+	from := "a/" + filepath.ToSlash(fileName)
+	if created {
+		from = "/dev/null"
+	}
+	diff, err := difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        splitLines(original),
+		B:        splitLines(content),
+		FromFile: from,
+		ToFile:   "b/" + filepath.ToSlash(fileName),
+		Context:  3,
+	})
+	if err != nil {
+		return "", fmt.Errorf("diff %s: %w", fileName, err)
+	}
+	return diff, nil
+}
+
+// splitLines splits a text into lines, each line keeps its line break.
+// A last line without line break gets the marker used by diff tools, so the
+// diff can still be applied.
+func splitLines(s string) []string {
+	if s == "" {
+		return nil
+	}
+	lines := strings.SplitAfter(s, "\n")
+	if last := len(lines) - 1; lines[last] == "" {
+		lines = lines[:last]
+	} else {
+		lines[last] += "\n\\ No newline at end of file\n"
+	}
+	return lines
+}
+
 // Box will mount each file in the Box and wrap it, already existing files are ignored.
 func Box(g *genny.Generator, box packd.Walker) error {
 	return box.Walk(func(path string, bf packd.File) error {
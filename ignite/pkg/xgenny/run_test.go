@@ -0,0 +1,134 @@
+package xgenny_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/gobuffalo/genny/v2"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ignite/cli/ignite/pkg/placeholder"
+	"github.com/ignite/cli/ignite/pkg/xgenny"
+)
+
+func TestDryRun(t *testing.T) {
+	// Arrange
+	root := t.TempDir()
+	existingPath := filepath.Join(root, "existing.txt")
+	err := os.WriteFile(existingPath, []byte("foo\nbar\n"), 0o644)
+	require.NoError(t, err)
+	createdPath := filepath.Join(root, "created.txt")
+
+	create := genny.New()
+	create.File(genny.NewFileS("created.txt", "hello\n"))
+
+	modify := genny.New()
+	modify.RunFn(func(r *genny.Runner) error {
+		for _, path := range []string{existingPath, createdPath} {
+			f, err := r.Disk.Find(path)
+			if err != nil {
+				return err
+			}
+			content := strings.Replace(f.String(), "\n", "\nplaceholder\n", 1)
+			if err := r.File(genny.NewFileS(path, content)); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+
+	// Act
+	sm, err := xgenny.DryRun(context.Background(), root, placeholder.New(), create, modify)
+
+	// Assert
+	require.NoError(t, err)
+	require.Equal(t, []string{createdPath}, sm.CreatedFiles())
+	require.Equal(t, []string{existingPath}, sm.ModifiedFiles())
+	require.Equal(t, "--- /dev/null\n"+
+		"+++ b/created.txt\n"+
+		"@@ -0,0 +1,2 @@\n"+
+		"+hello\n"+
+		"+placeholder\n"+
+		"--- a/existing.txt\n"+
+		"+++ b/existing.txt\n"+
+		"@@ -1,2 +1,3 @@\n"+
+		" foo\n"+
+		"+placeholder\n"+
+		" bar\n", sm.Diff())
+	// The file system is not modified
+	require.NoFileExists(t, createdPath)
+	content, err := os.ReadFile(existingPath)
+	require.NoError(t, err)
+	require.Equal(t, "foo\nbar\n", string(content))
+}
+
+func TestDryRunNoNewlineAtEndOfFile(t *testing.T) {
+	// Arrange
+	root := t.TempDir()
+	err := os.WriteFile(filepath.Join(root, "existing.txt"), []byte("foo\nbar"), 0o644)
+	require.NoError(t, err)
+	g := genny.New()
+	g.File(genny.NewFileS("existing.txt", "foo\nbar\nbaz"))
+
+	// Act
+	sm, err := xgenny.DryRun(context.Background(), root, placeholder.New(), g)
+
+	// Assert
+	require.NoError(t, err)
+	require.Equal(t, "--- a/existing.txt\n"+
+		"+++ b/existing.txt\n"+
+		"@@ -1,2 +1,3 @@\n"+
+		" foo\n"+
+		"-bar\n"+
+		"\\ No newline at end of file\n"+
+		"+bar\n"+
+		"+baz\n"+
+		"\\ No newline at end of file\n", sm.Diff())
+}
+
+func TestMemoryRunner(t *testing.T) {
+	// Arrange
+	root := t.TempDir()
+	createdPath := filepath.Join(root, "created.txt")
+	otherPath := filepath.Join(root, "other.txt")
+
+	create := genny.New()
+	create.File(genny.NewFileS("created.txt", "hello\n"))
+
+	modify := genny.New()
+	modify.RunFn(func(r *genny.Runner) error {
+		f, err := r.Disk.Find(createdPath)
+		if err != nil {
+			return err
+		}
+		if err := r.File(genny.NewFileS(createdPath, f.String()+"world\n")); err != nil {
+			return err
+		}
+		return r.File(genny.NewFileS(otherPath, "foo\n"))
+	})
+	runner := xgenny.NewMemoryRunner(root)
+
+	// Act
+	sm, err := runner.Run(context.Background(), placeholder.New(), create)
+	require.NoError(t, err)
+	newSm, err := runner.Run(context.Background(), placeholder.New(), modify)
+	require.NoError(t, err)
+	sm.Merge(newSm)
+
+	// Assert
+	require.ElementsMatch(t, []string{createdPath, otherPath}, sm.CreatedFiles())
+	require.Empty(t, sm.ModifiedFiles())
+	require.Equal(t, "--- /dev/null\n"+
+		"+++ b/created.txt\n"+
+		"@@ -0,0 +1,2 @@\n"+
+		"+hello\n"+
+		"+world\n"+
+		"--- /dev/null\n"+
+		"+++ b/other.txt\n"+
+		"@@ -0,0 +1 @@\n"+
+		"+foo\n", sm.Diff())
+	require.NoFileExists(t, createdPath)
+}
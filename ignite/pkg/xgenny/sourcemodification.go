@@ -1,15 +1,22 @@
 package xgenny
 
+import (
+	"sort"
+	"strings"
+)
+
 // SourceModification describes modified and created files in the source code after a run.
 type SourceModification struct {
 	modified map[string]struct{}
 	created  map[string]struct{}
+	diffs    map[string]string
 }
 
 func NewSourceModification() SourceModification {
 	return SourceModification{
 		make(map[string]struct{}),
 		make(map[string]struct{}),
+		make(map[string]string),
 	}
 }
 
@@ -51,8 +58,36 @@ func (sm *SourceModification) AppendCreatedFiles(createdFiles ...string) {
 	}
 }
 
+// SetDiff sets the unified diff of a file in the source modification.
+func (sm *SourceModification) SetDiff(file, diff string) {
+	sm.diffs[file] = diff
+}
+
+// Diff returns the unified diff of the files of the source modification,
+// sorted by file name.
+// Diffs are only available when the source modification comes from a dry run.
+func (sm SourceModification) Diff() string {
+	files := make([]string, 0, len(sm.diffs))
+	for file := range sm.diffs {
+		files = append(files, file)
+	}
+	sort.Strings(files)
+
+	var b strings.Builder
+	for _, file := range files {
+		b.WriteString(sm.diffs[file])
+	}
+	return b.String()
+}
+
 // Merge merges new source modification to an existing one.
+// The diff of a file in the new source modification replaces the existing one
+// because diffs are made against the file system, so the new diff already
+// includes the previous changes when both runs share the same MemoryRunner.
 func (sm *SourceModification) Merge(newSm SourceModification) {
 	sm.AppendModifiedFiles(newSm.ModifiedFiles()...)
 	sm.AppendCreatedFiles(newSm.CreatedFiles()...)
+	for file, diff := range newSm.diffs {
+		sm.SetDiff(file, diff)
+	}
 }
@@ -2,8 +2,11 @@ package xgit
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"errors"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
 	"strings"
@@ -144,6 +147,66 @@ func Clone(ctx context.Context, urlRef, dir string) error {
 	})
 }
 
+// HeadHash returns the hash of the commit that HEAD points to in the git
+// repository located at path.
+func HeadHash(path string) (string, error) {
+	repo, err := git.PlainOpenWithOptions(path, &defaultOpenOpts)
+	if err != nil {
+		return "", fmt.Errorf("open git repo %s: %w", path, err)
+	}
+	head, err := repo.Head()
+	if err != nil {
+		return "", fmt.Errorf("git head %s: %w", path, err)
+	}
+	return head.Hash().String(), nil
+}
+
+// SourceChecksum returns the SHA256 checksum of the files committed in the dir
+// directory of the git repository located at path, at the commit that HEAD points to.
+// The checksum only depends on the committed files, so it doesn't change
+// when the files in the working tree are modified, for example by a build.
+func SourceChecksum(path, dir string) (string, error) {
+	repo, err := git.PlainOpenWithOptions(path, &defaultOpenOpts)
+	if err != nil {
+		return "", fmt.Errorf("open git repo %s: %w", path, err)
+	}
+	head, err := repo.Head()
+	if err != nil {
+		return "", fmt.Errorf("git head %s: %w", path, err)
+	}
+	commit, err := repo.CommitObject(head.Hash())
+	if err != nil {
+		return "", fmt.Errorf("git commit %s: %w", head.Hash(), err)
+	}
+	tree, err := commit.Tree()
+	if err != nil {
+		return "", fmt.Errorf("git tree %s: %w", head.Hash(), err)
+	}
+	if dir = filepath.ToSlash(filepath.Clean(dir)); dir != "." {
+		if tree, err = tree.Tree(dir); err != nil {
+			return "", fmt.Errorf("git tree %s: %w", dir, err)
+		}
+	}
+
+	// Files are iterated in the order they are stored in the tree, which is sorted by name
+	h := sha256.New()
+	err = tree.Files().ForEach(func(f *object.File) error {
+		r, err := f.Reader()
+		if err != nil {
+			return err
+		}
+		defer r.Close()
+
+		fmt.Fprintf(h, "%s %s %d\n", f.Name, f.Mode, f.Size)
+		_, err = io.Copy(h, r)
+		return err
+	})
+	if err != nil {
+		return "", fmt.Errorf("git checksum %s: %w", path, err)
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
 // IsRepository checks if a path contains a Git repository.
 func IsRepository(path string) (bool, error) {
 	if _, err := git.PlainOpenWithOptions(path, &defaultOpenOpts); err != nil {
@@ -154,3 +217,17 @@ func IsRepository(path string) (bool, error) {
 	}
 	return true, nil
 }
+
+// RepositoryRoot returns the root directory of the git repository that
+// contains path.
+func RepositoryRoot(path string) (string, error) {
+	repo, err := git.PlainOpenWithOptions(path, &defaultOpenOpts)
+	if err != nil {
+		return "", fmt.Errorf("open git repo %s: %w", path, err)
+	}
+	wt, err := repo.Worktree()
+	if err != nil {
+		return "", fmt.Errorf("git worktree %s: %w", path, err)
+	}
+	return wt.Filesystem.Root(), nil
+}
@@ -229,6 +229,73 @@ func TestAreChangesCommitted(t *testing.T) {
 	}
 }
 
+func TestHeadHash(t *testing.T) {
+	// Arrange
+	dir := t.TempDir()
+	repo, err := git.PlainInit(dir, false)
+	require.NoError(t, err)
+	err = os.WriteFile(path.Join(dir, "foo"), []byte("hello"), 0o755)
+	require.NoError(t, err)
+	wt, err := repo.Worktree()
+	require.NoError(t, err)
+	_, err = wt.Add(".")
+	require.NoError(t, err)
+	hash, err := wt.Commit("First commit", &git.CommitOptions{
+		Author: &object.Signature{},
+	})
+	require.NoError(t, err)
+
+	// Act
+	res, err := xgit.HeadHash(dir)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, hash.String(), res)
+}
+
+func TestHeadHashNotRepository(t *testing.T) {
+	_, err := xgit.HeadHash(t.TempDir())
+
+	require.ErrorIs(t, err, git.ErrRepositoryNotExists)
+}
+
+func TestSourceChecksum(t *testing.T) {
+	// Arrange
+	dir := t.TempDir()
+	repo, err := git.PlainInit(dir, false)
+	require.NoError(t, err)
+	err = os.Mkdir(path.Join(dir, "src"), 0o755)
+	require.NoError(t, err)
+	err = os.WriteFile(path.Join(dir, "src", "foo"), []byte("hello"), 0o644)
+	require.NoError(t, err)
+	err = os.WriteFile(path.Join(dir, "bar"), []byte("hello"), 0o644)
+	require.NoError(t, err)
+	wt, err := repo.Worktree()
+	require.NoError(t, err)
+	_, err = wt.Add(".")
+	require.NoError(t, err)
+	_, err = wt.Commit("First commit", &git.CommitOptions{
+		Author: &object.Signature{},
+	})
+	require.NoError(t, err)
+	rootSum, err := xgit.SourceChecksum(dir, ".")
+	require.NoError(t, err)
+	srcSum, err := xgit.SourceChecksum(dir, "src")
+	require.NoError(t, err)
+
+	// Act: files that are not committed don't change the checksum
+	err = os.WriteFile(path.Join(dir, "src", "foo"), []byte("changed"), 0o644)
+	require.NoError(t, err)
+	err = os.WriteFile(path.Join(dir, "src", "baz"), []byte("hello"), 0o644)
+	require.NoError(t, err)
+	res, err := xgit.SourceChecksum(dir, "src")
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, srcSum, res)
+	assert.NotEqual(t, rootSum, srcSum)
+}
+
 func TestClone(t *testing.T) {
 	// Create a folder with content
 	notEmptyDir := t.TempDir()
@@ -432,3 +499,20 @@ func TestIsRepository(t *testing.T) {
 		})
 	}
 }
+
+func TestRepositoryRoot(t *testing.T) {
+	// Arrange
+	dir := t.TempDir()
+	_, err := git.PlainInit(dir, false)
+	require.NoError(t, err)
+	subdir := path.Join(dir, "foo", "bar")
+	err = os.MkdirAll(subdir, 0o755)
+	require.NoError(t, err)
+
+	// Act
+	root, err := xgit.RepositoryRoot(subdir)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, dir, root)
+}
@@ -1,5 +1,7 @@
 package yaml
 
+import "fmt"
+
 // Map defines a map type that uses strings as key value.
 // The map implements the Unmarshaller interface to convert
 // the unmarshalled map keys type from interface{} to string.
@@ -51,3 +53,26 @@ func convertMapKeys(raw map[interface{}]interface{}) map[string]interface{} {
 
 	return m
 }
+
+// ConvertValue converts the keys of the maps found in a value unmarshalled
+// from YAML from interface{} to string, so the value can be encoded as JSON.
+func ConvertValue(v interface{}) interface{} {
+	switch value := v.(type) {
+	case map[interface{}]interface{}:
+		m := make(map[string]interface{}, len(value))
+		for k, item := range value {
+			m[fmt.Sprint(k)] = ConvertValue(item)
+		}
+
+		return m
+	case []interface{}:
+		values := make([]interface{}, len(value))
+		for i, item := range value {
+			values[i] = ConvertValue(item)
+		}
+
+		return values
+	default:
+		return v
+	}
+}
@@ -42,3 +42,28 @@ func TestUnmarshalWithNativeMapType(t *testing.T) {
 	require.NotNil(t, output["foo"])
 	require.IsType(t, (map[interface{}]interface{})(nil), output["foo"])
 }
+
+func TestConvertValue(t *testing.T) {
+	// Arrange
+	input := `
+    - foo:
+        bar: [{baz: 1}]
+    - 2
+    `
+	var output interface{}
+	err := yaml.Unmarshal([]byte(input), &output)
+	require.NoError(t, err)
+
+	// Act
+	value := xyaml.ConvertValue(output)
+
+	// Assert
+	require.Equal(t, []interface{}{
+		map[string]interface{}{
+			"foo": map[string]interface{}{
+				"bar": []interface{}{map[string]interface{}{"baz": 1}},
+			},
+		},
+		2,
+	}, value)
+}
@@ -2,14 +2,15 @@ package chain
 
 import (
 	"context"
+	"crypto/ed25519"
 	"fmt"
-	"io"
 	"os"
 	"path/filepath"
 	"runtime"
+	"strconv"
 	"strings"
+	"time"
 
-	"github.com/moby/moby/pkg/archive"
 	"github.com/pkg/errors"
 
 	"github.com/ignite/cli/ignite/pkg/cache"
@@ -21,13 +22,17 @@ import (
 	"github.com/ignite/cli/ignite/pkg/events"
 	"github.com/ignite/cli/ignite/pkg/goanalysis"
 	"github.com/ignite/cli/ignite/pkg/gocmd"
+	"github.com/ignite/cli/ignite/pkg/gomodule"
+	"github.com/ignite/cli/ignite/pkg/tarball"
 	"github.com/ignite/cli/ignite/pkg/xstrings"
 )
 
 const (
 	releaseDir                   = "release"
 	releaseChecksumKey           = "release_checksum"
+	releaseSBOMFile              = "sbom.cdx.json"
 	modChecksumKey               = "go_mod_checksum"
+	sourceDateEpochEnv           = "SOURCE_DATE_EPOCH"
 	buildDirchangeCacheNamespace = "build.dirchange"
 )
 
@@ -98,18 +103,34 @@ func (c *Chain) build(
 // BuildRelease builds binaries for a release. targets is a list
 // of GOOS:GOARCH when provided. It defaults to your system when no targets provided.
 // prefix is used as prefix to tarballs containing each target.
+// The binaries and tarballs are reproducible, so building the same source code
+// produces the same release files. The release also contains an SBOM of the app
+// dependencies and, when signKeyPath is provided, the checksum file is signed
+// with the ed25519 private key in that path.
 func (c *Chain) BuildRelease(
 	ctx context.Context,
 	cacheStorage cache.Storage,
 	buildParams []string,
-	output, prefix string,
+	output, prefix, signKeyPath string,
 	targets ...string,
 ) (releasePath string, err error) {
-	if prefix == "" {
-		prefix = c.app.Name
+	prefix, targets = c.releaseDefaults(prefix, targets)
+
+	var signKey ed25519.PrivateKey
+	if signKeyPath != "" {
+		data, err := os.ReadFile(signKeyPath)
+		if err != nil {
+			return "", err
+		}
+
+		if signKey, err = checksum.ParsePrivateKey(data); err != nil {
+			return "", errors.Wrapf(err, "invalid sign key %s", signKeyPath)
+		}
 	}
-	if len(targets) == 0 {
-		targets = []string{gocmd.BuildTarget(runtime.GOOS, runtime.GOARCH)}
+
+	sourceDate, err := c.sourceDate()
+	if err != nil {
+		return "", err
 	}
 
 	// prepare for build.
@@ -122,6 +143,8 @@ func (c *Chain) BuildRelease(
 		return "", err
 	}
 
+	buildFlags = reproducibleBuildFlags(buildFlags)
+
 	binary, err := c.Binary()
 	if err != nil {
 		return "", err
@@ -169,13 +192,7 @@ func (c *Chain) BuildRelease(
 			return "", err
 		}
 
-		tarr, err := archive.Tar(out, archive.Gzip)
-		if err != nil {
-			return "", err
-		}
-
-		tarName := fmt.Sprintf("%s_%s_%s.tar.gz", prefix, goos, goarch)
-		tarPath := filepath.Join(releasePath, tarName)
+		tarPath := filepath.Join(releasePath, releaseTarName(prefix, goos, goarch))
 
 		tarf, err := os.Create(tarPath)
 		if err != nil {
@@ -183,16 +200,103 @@ func (c *Chain) BuildRelease(
 		}
 		defer tarf.Close()
 
-		if _, err := io.Copy(tarf, tarr); err != nil {
+		if err := tarball.Create(tarf, out, sourceDate); err != nil {
 			return "", err
 		}
 		tarf.Close()
 	}
 
+	if err := c.writeSBOM(filepath.Join(releasePath, releaseSBOMFile), sourceDate); err != nil {
+		return "", err
+	}
+
 	checksumPath := filepath.Join(releasePath, releaseChecksumKey)
 
 	// create a checksum.txt and return with the path to release dir.
-	return releasePath, checksum.Sum(releasePath, checksumPath)
+	if err := checksum.Sum(releasePath, checksumPath); err != nil {
+		return "", err
+	}
+
+	if signKey != nil {
+		if _, err := checksum.Sign(checksumPath, signKey); err != nil {
+			return "", err
+		}
+	}
+
+	return releasePath, nil
+}
+
+// releaseDefaults returns the release tarball prefix and the release targets
+// using the default values when they are not provided.
+func (c *Chain) releaseDefaults(prefix string, targets []string) (string, []string) {
+	if prefix == "" {
+		prefix = c.app.Name
+	}
+	if len(targets) == 0 {
+		targets = []string{gocmd.BuildTarget(runtime.GOOS, runtime.GOARCH)}
+	}
+
+	return prefix, targets
+}
+
+// releaseTarName returns the name of the release tarball of a target.
+func releaseTarName(prefix, goos, goarch string) string {
+	return fmt.Sprintf("%s_%s_%s.tar.gz", prefix, goos, goarch)
+}
+
+// writeSBOM writes the SBOM of the app dependencies defined in go.mod to path.
+func (c *Chain) writeSBOM(path string, timestamp time.Time) error {
+	modFile, err := gomodule.ParseAt(c.app.Path)
+	if err != nil {
+		return err
+	}
+
+	version := c.sourceVersion.tag
+	if version == "" {
+		version = c.sourceVersion.hash
+	}
+
+	data, err := gomodule.NewSBOM(modFile, version, timestamp).JSON()
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(path, data, 0o644)
+}
+
+// sourceDate returns the time used as modification time of the release files.
+// It defaults to the time of the last commit and can be overridden with the
+// SOURCE_DATE_EPOCH environment variable, as defined by the reproducible builds
+// specification.
+func (c *Chain) sourceDate() (time.Time, error) {
+	if epoch := os.Getenv(sourceDateEpochEnv); epoch != "" {
+		sec, err := strconv.ParseInt(epoch, 10, 64)
+		if err != nil {
+			return time.Time{}, errors.Wrapf(err, "invalid %s", sourceDateEpochEnv)
+		}
+
+		return time.Unix(sec, 0).UTC(), nil
+	}
+
+	if c.sourceVersion.time.IsZero() {
+		return time.Unix(0, 0).UTC(), nil
+	}
+
+	return c.sourceVersion.time, nil
+}
+
+// reproducibleBuildFlags changes the build flags so building the same source code
+// produces the same binary: the paths of the file system are removed from the
+// binary and the build ID is left empty.
+func reproducibleBuildFlags(flags []string) []string {
+	flags = append([]string{gocmd.FlagTrimPath}, flags...)
+	for i := 0; i < len(flags)-1; i++ {
+		if flags[i] == gocmd.FlagLdflags {
+			flags[i+1] = gocmd.Ldflags(flags[i+1], "-buildid=")
+		}
+	}
+
+	return flags
 }
 
 func (c *Chain) preBuild(
@@ -200,31 +304,11 @@ func (c *Chain) preBuild(
 	cacheStorage cache.Storage,
 	buildTags ...string,
 ) (buildFlags []string, err error) {
-	config, err := c.Config()
-	if err != nil {
-		return nil, err
-	}
-
-	chainID, err := c.ID()
+	buildFlags, err = c.buildFlags(buildTags...)
 	if err != nil {
 		return nil, err
 	}
 
-	ldFlags := config.Build.LDFlags
-	ldFlags = append(ldFlags,
-		fmt.Sprintf("-X github.com/cosmos/cosmos-sdk/version.Name=%s", xstrings.Title(c.app.Name)),
-		fmt.Sprintf("-X github.com/cosmos/cosmos-sdk/version.AppName=%sd", c.app.Name),
-		fmt.Sprintf("-X github.com/cosmos/cosmos-sdk/version.Version=%s", c.sourceVersion.tag),
-		fmt.Sprintf("-X github.com/cosmos/cosmos-sdk/version.Commit=%s", c.sourceVersion.hash),
-		fmt.Sprintf("-X github.com/cosmos/cosmos-sdk/version.BuildTags=%s", strings.Join(buildTags, ",")),
-		fmt.Sprintf("-X %s/cmd/%s/cmd.ChainID=%s", c.app.ImportPath, c.app.D(), chainID),
-	)
-	buildFlags = []string{
-		gocmd.FlagMod, gocmd.FlagModValueReadOnly,
-		gocmd.FlagTags, gocmd.Tags(buildTags...),
-		gocmd.FlagLdflags, gocmd.Ldflags(ldFlags...),
-	}
-
 	c.ev.Send("Installing dependencies...", events.ProgressUpdate())
 
 	// We do mod tidy before checking for checksum changes, because go.mod gets modified often
@@ -258,6 +342,35 @@ func (c *Chain) preBuild(
 	return buildFlags, nil
 }
 
+// buildFlags returns the go build flags to build the app binary.
+func (c *Chain) buildFlags(buildTags ...string) ([]string, error) {
+	config, err := c.Config()
+	if err != nil {
+		return nil, err
+	}
+
+	chainID, err := c.ID()
+	if err != nil {
+		return nil, err
+	}
+
+	ldFlags := config.Build.LDFlags
+	ldFlags = append(ldFlags,
+		fmt.Sprintf("-X github.com/cosmos/cosmos-sdk/version.Name=%s", xstrings.Title(c.app.Name)),
+		fmt.Sprintf("-X github.com/cosmos/cosmos-sdk/version.AppName=%sd", c.app.Name),
+		fmt.Sprintf("-X github.com/cosmos/cosmos-sdk/version.Version=%s", c.sourceVersion.tag),
+		fmt.Sprintf("-X github.com/cosmos/cosmos-sdk/version.Commit=%s", c.sourceVersion.hash),
+		fmt.Sprintf("-X github.com/cosmos/cosmos-sdk/version.BuildTags=%s", strings.Join(buildTags, ",")),
+		fmt.Sprintf("-X %s/cmd/%s/cmd.ChainID=%s", c.app.ImportPath, c.app.D(), chainID),
+	)
+
+	return []string{
+		gocmd.FlagMod, gocmd.FlagModValueReadOnly,
+		gocmd.FlagTags, gocmd.Tags(buildTags...),
+		gocmd.FlagLdflags, gocmd.Ldflags(ldFlags...),
+	}, nil
+}
+
 func (c *Chain) discoverMain(path string) (pkgPath string, err error) {
 	conf, err := c.Config()
 	if err != nil {
@@ -265,7 +378,7 @@ func (c *Chain) discoverMain(path string) (pkgPath string, err error) {
 	}
 
 	if conf.Build.Main != "" {
-		return filepath.Join(c.app.Path, conf.Build.Main), nil
+		return filepath.Join(path, conf.Build.Main), nil
 	}
 
 	path, err = goanalysis.DiscoverOneMain(path)
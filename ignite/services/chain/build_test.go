@@ -0,0 +1,76 @@
+package chain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestReproducibleBuildFlags(t *testing.T) {
+	// Arrange
+	flags := []string{"-mod", "readonly", "-ldflags", "-X main.Version=v1.0.0", "-tags", "ledger"}
+
+	// Act
+	flags = reproducibleBuildFlags(flags)
+
+	// Assert
+	require.Equal(t, []string{
+		"-trimpath",
+		"-mod",
+		"readonly",
+		"-ldflags",
+		"-X main.Version=v1.0.0 -buildid=",
+		"-tags",
+		"ledger",
+	}, flags)
+}
+
+func TestSourceDate(t *testing.T) {
+	commitTime := time.Date(2023, 5, 1, 10, 0, 0, 0, time.UTC)
+	cases := []struct {
+		name       string
+		epoch      string
+		commitTime time.Time
+		expected   time.Time
+		err        string
+	}{
+		{
+			name:       "commit time",
+			commitTime: commitTime,
+			expected:   commitTime,
+		},
+		{
+			name:     "no commit",
+			expected: time.Unix(0, 0).UTC(),
+		},
+		{
+			name:       "source date epoch",
+			epoch:      "1700000000",
+			commitTime: commitTime,
+			expected:   time.Unix(1700000000, 0).UTC(),
+		},
+		{
+			name:  "invalid source date epoch",
+			epoch: "yesterday",
+			err:   `invalid SOURCE_DATE_EPOCH: strconv.ParseInt: parsing "yesterday": invalid syntax`,
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv(sourceDateEpochEnv, tt.epoch)
+			c := &Chain{sourceVersion: version{time: tt.commitTime}}
+
+			date, err := c.sourceDate()
+
+			if tt.err != "" {
+				require.EqualError(t, err, tt.err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, date)
+		})
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"os"
 	"path/filepath"
+	"time"
 
 	"github.com/go-git/go-git/v5"
 
@@ -33,6 +34,7 @@ var appBackendSourceWatchPaths = []string{
 type version struct {
 	tag  string
 	hash string
+	time time.Time
 }
 
 // Chain provides programmatic access and tools for a Cosmos SDK blockchain.
@@ -51,6 +53,15 @@ type Chain struct {
 
 	ev          events.Bus
 	logOutputer uilog.Outputer
+
+	// binaryPath overrides the path of the app binary used to run the chain
+	// commands, for example to run a binary built from an older version.
+	// It's only set when the chain is created with withBinary.
+	binaryPath string
+
+	// newOptions are the options used to create the chain.
+	// They are used to create other chains for the same app.
+	newOptions []Option
 }
 
 // chainOptions holds user given options that overwrites chain's defaults.
@@ -146,6 +157,7 @@ func New(path string, options ...Option) (*Chain, error) {
 	c := &Chain{
 		app:            app,
 		serveRefresher: make(chan struct{}, 1),
+		newOptions:     options,
 	}
 
 	// Apply the options
@@ -166,6 +178,17 @@ func New(path string, options ...Option) (*Chain, error) {
 	return c, nil
 }
 
+// withBinary returns a chain for the same app, created with the same options,
+// that runs the commands with the binary instead of the app binary.
+// The chain itself is not modified.
+func (c *Chain) withBinary(binary string) (*Chain, error) {
+	options := append([]Option{}, c.newOptions...)
+	options = append(options, func(c *Chain) {
+		c.binaryPath = binary
+	})
+	return New(c.app.Path, options...)
+}
+
 func (c *Chain) appVersion() (v version, err error) {
 	ver, err := repoversion.Determine(c.app.Path)
 	if err != nil {
@@ -174,6 +197,7 @@ func (c *Chain) appVersion() (v version, err error) {
 
 	v.hash = ver.Hash
 	v.tag = ver.Tag
+	v.time = ver.Time
 
 	return v, nil
 }
@@ -187,7 +211,10 @@ func (c *Chain) RPCPublicAddress() (string, error) {
 		if err != nil {
 			return "", err
 		}
-		validator := conf.Validators[0]
+		validator, err := chainconfig.FirstValidator(conf)
+		if err != nil {
+			return "", err
+		}
 		servers, err := validator.GetServers()
 		if err != nil {
 			return "", err
@@ -240,6 +267,11 @@ func (c *Chain) ID() (string, error) {
 	return c.app.N(), nil
 }
 
+// AppPath returns the absolute path of the chain source code.
+func (c *Chain) AppPath() string {
+	return c.app.Path
+}
+
 // Name returns the chain's name.
 func (c *Chain) Name() string {
 	return c.app.N()
@@ -409,12 +441,37 @@ func (c *Chain) KeyringBackend() (chaincmd.KeyringBackend, error) {
 
 // Commands returns the runner execute commands on the chain's binary.
 func (c *Chain) Commands(ctx context.Context) (chaincmdrunner.Runner, error) {
-	id, err := c.ID()
+	home, err := c.Home()
 	if err != nil {
 		return chaincmdrunner.Runner{}, err
 	}
 
-	home, err := c.Home()
+	cfg, err := c.Config()
+	if err != nil {
+		return chaincmdrunner.Runner{}, err
+	}
+
+	servers := chainconfigv1.DefaultServers()
+	if len(cfg.Validators) > 0 {
+		validator, _ := chainconfig.FirstValidator(cfg)
+		servers, err = validator.GetServers()
+		if err != nil {
+			return chaincmdrunner.Runner{}, err
+		}
+	}
+
+	return c.commands(ctx, home, servers, c.app.D())
+}
+
+// commands returns a runner to execute commands on the chain's binary
+// for the node that uses the given home directory and servers.
+func (c *Chain) commands(
+	ctx context.Context,
+	home string,
+	servers chainconfigv1.Servers,
+	outputPrefix string,
+) (chaincmdrunner.Runner, error) {
+	id, err := c.ID()
 	if err != nil {
 		return chaincmdrunner.Runner{}, err
 	}
@@ -428,26 +485,15 @@ func (c *Chain) Commands(ctx context.Context) (chaincmdrunner.Runner, error) {
 	// find the binary path when the Go bin path is not part
 	// of the PATH environment variable.
 	binary = xexec.TryResolveAbsPath(binary)
-
-	backend, err := c.KeyringBackend()
-	if err != nil {
-		return chaincmdrunner.Runner{}, err
+	if c.binaryPath != "" {
+		binary = c.binaryPath
 	}
 
-	cfg, err := c.Config()
+	backend, err := c.KeyringBackend()
 	if err != nil {
 		return chaincmdrunner.Runner{}, err
 	}
 
-	servers := chainconfigv1.DefaultServers()
-	if len(cfg.Validators) > 0 {
-		validator, _ := chainconfig.FirstValidator(cfg)
-		servers, err = validator.GetServers()
-		if err != nil {
-			return chaincmdrunner.Runner{}, err
-		}
-	}
-
 	nodeAddr, err := xurl.TCP(servers.RPC.Address)
 	if err != nil {
 		return chaincmdrunner.Runner{}, err
@@ -467,7 +513,7 @@ func (c *Chain) Commands(ctx context.Context) (chaincmdrunner.Runner, error) {
 
 	// Enable command output only when CLI verbosity is enabled
 	if c.logOutputer != nil && c.logOutputer.Verbosity() == uilog.VerbosityVerbose {
-		out := c.logOutputer.NewOutput(c.app.D(), colors.Cyan)
+		out := c.logOutputer.NewOutput(outputPrefix, colors.Cyan)
 		ccrOptions = append(
 			ccrOptions,
 			chaincmdrunner.Stdout(out.Stdout()),
@@ -28,6 +28,27 @@ func TestSourceVersion(t *testing.T) {
 	})
 }
 
+func TestWithBinary(t *testing.T) {
+	// Arrange
+	c, err := New(
+		tempSource(t, "testdata/version/mars.v0.2.tar.gz"),
+		ID("mars-1"),
+		HomePath("/tmp/mars"),
+	)
+	require.NoError(t, err)
+
+	// Act
+	old, err := c.withBinary("/tmp/marsd")
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, "/tmp/marsd", old.binaryPath)
+	assert.Equal(t, c.app, old.app)
+	assert.Equal(t, c.options, old.options)
+	assert.Equal(t, c.sourceVersion, old.sourceVersion)
+	assert.Empty(t, c.binaryPath)
+}
+
 func tempSource(t *testing.T, tarPath string) (path string) {
 	f, err := os.Open(tarPath)
 	require.NoError(t, err)
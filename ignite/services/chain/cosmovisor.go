@@ -0,0 +1,169 @@
+package chain
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/ignite/cli/ignite/pkg/checksum"
+	"github.com/ignite/cli/ignite/pkg/gocmd"
+	"github.com/ignite/cli/ignite/pkg/tarball"
+)
+
+const (
+	cosmovisorDir             = "cosmovisor"
+	cosmovisorGenesisDir      = "genesis"
+	cosmovisorUpgradesDir     = "upgrades"
+	cosmovisorBinDir          = "bin"
+	cosmovisorUpgradeInfoFile = "upgrade-info.json"
+)
+
+// cosmovisorUpgradeNameRe matches the upgrade names, which are used as directory names.
+var cosmovisorUpgradeNameRe = regexp.MustCompile(`^[a-zA-Z0-9][a-zA-Z0-9_.-]*$`)
+
+// CosmovisorUpgradeInfo is the upgrade info used by cosmovisor to download
+// the binaries of an upgrade. It is the info of the upgrade plan.
+type CosmovisorUpgradeInfo struct {
+	// Binaries are the download URLs of the binaries indexed by "GOOS/GOARCH".
+	Binaries map[string]string `json:"binaries"`
+}
+
+// CosmovisorRelease creates a cosmovisor directory layout in the "cosmovisor" directory
+// of a release created with BuildRelease, using the same prefix and targets.
+// The layout contains a directory for each target with the binary in "genesis/bin",
+// or in "upgrades/<upgradeName>/bin" when an upgrade name is provided.
+// It also generates the "upgrade-info.json" file with the download URL and checksum of
+// the tarball of each target. The download URLs start with downloadURL, or with the
+// local release path when it is empty. The path of the cosmovisor directory is returned.
+func (c *Chain) CosmovisorRelease(
+	releasePath, prefix, upgradeName, downloadURL string,
+	targets ...string,
+) (cosmovisorPath string, err error) {
+	prefix, targets = c.releaseDefaults(prefix, targets)
+
+	targetDir, err := cosmovisorTargetDir(upgradeName)
+	if err != nil {
+		return "", err
+	}
+
+	binary, err := c.Binary()
+	if err != nil {
+		return "", err
+	}
+
+	if downloadURL == "" {
+		path, err := filepath.Abs(releasePath)
+		if err != nil {
+			return "", err
+		}
+
+		downloadURL = (&url.URL{Scheme: "file", Path: filepath.ToSlash(path)}).String()
+	}
+
+	f, err := os.Open(filepath.Join(releasePath, releaseChecksumKey))
+	if err != nil {
+		return "", errors.Wrap(err, "cannot read the release checksum file")
+	}
+	defer f.Close()
+
+	checksums, err := checksum.Parse(f)
+	if err != nil {
+		return "", err
+	}
+
+	cosmovisorPath = filepath.Join(releasePath, cosmovisorDir)
+	if err := os.RemoveAll(cosmovisorPath); err != nil {
+		return "", err
+	}
+
+	info := CosmovisorUpgradeInfo{Binaries: make(map[string]string)}
+	for _, t := range targets {
+		goos, goarch, err := gocmd.ParseTarget(t)
+		if err != nil {
+			return "", err
+		}
+
+		tarName := releaseTarName(prefix, goos, goarch)
+		sum, ok := checksums[tarName]
+		if !ok {
+			return "", fmt.Errorf("checksum of %s not found in the release checksum file", tarName)
+		}
+
+		binDir := filepath.Join(
+			cosmovisorPath,
+			fmt.Sprintf("%s_%s", goos, goarch),
+			targetDir,
+			cosmovisorBinDir,
+		)
+		if err := extractReleaseBinary(filepath.Join(releasePath, tarName), binDir, binary); err != nil {
+			return "", err
+		}
+
+		info.Binaries[fmt.Sprintf("%s/%s", goos, goarch)] = fmt.Sprintf(
+			"%s/%s?checksum=sha256:%s",
+			strings.TrimSuffix(downloadURL, "/"),
+			tarName,
+			sum,
+		)
+	}
+
+	data, err := json.MarshalIndent(info, "", "  ")
+	if err != nil {
+		return "", err
+	}
+
+	infoPath := filepath.Join(cosmovisorPath, cosmovisorUpgradeInfoFile)
+	if err := os.WriteFile(infoPath, data, 0o644); err != nil {
+		return "", err
+	}
+
+	return cosmovisorPath, nil
+}
+
+// cosmovisorTargetDir returns the cosmovisor directory of the binary, relative
+// to the cosmovisor root directory.
+func cosmovisorTargetDir(upgradeName string) (string, error) {
+	if upgradeName == "" {
+		return cosmovisorGenesisDir, nil
+	}
+
+	if !cosmovisorUpgradeNameRe.MatchString(upgradeName) {
+		return "", fmt.Errorf(
+			"invalid upgrade name %q: only letters, digits, '_', '.' and '-' are allowed",
+			upgradeName,
+		)
+	}
+
+	return filepath.Join(cosmovisorUpgradesDir, upgradeName), nil
+}
+
+// extractReleaseBinary extracts a binary from a release tarball into a directory.
+func extractReleaseBinary(tarPath, dir, binary string) error {
+	tarf, err := os.Open(tarPath)
+	if err != nil {
+		return err
+	}
+	defer tarf.Close()
+
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+
+	out, err := os.OpenFile(filepath.Join(dir, binary), os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0o755)
+	if err != nil {
+		return err
+	}
+	defer out.Close()
+
+	if _, err := tarball.ExtractFile(tarf, out, binary); err != nil {
+		return errors.Wrapf(err, "cannot extract %s from %s", binary, filepath.Base(tarPath))
+	}
+
+	return out.Close()
+}
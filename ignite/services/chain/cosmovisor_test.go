@@ -0,0 +1,114 @@
+package chain
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ignite/cli/ignite/pkg/checksum"
+	"github.com/ignite/cli/ignite/pkg/tarball"
+)
+
+func TestCosmovisorRelease(t *testing.T) {
+	c, err := New(tempSource(t, "testdata/version/mars.v0.2.tar.gz"))
+	require.NoError(t, err)
+
+	binary, err := c.Binary()
+	require.NoError(t, err)
+
+	targets := []string{"linux:amd64", "darwin:arm64"}
+
+	// Create a release with a tarball for each target
+	releasePath := t.TempDir()
+	for _, target := range []string{"linux_amd64", "darwin_arm64"} {
+		out := t.TempDir()
+		err := os.WriteFile(filepath.Join(out, binary), []byte(target), 0o755)
+		require.NoError(t, err)
+
+		tarf, err := os.Create(filepath.Join(releasePath, fmt.Sprintf("mars_%s.tar.gz", target)))
+		require.NoError(t, err)
+		require.NoError(t, tarball.Create(tarf, out, time.Unix(0, 0)))
+		require.NoError(t, tarf.Close())
+	}
+
+	checksumPath := filepath.Join(releasePath, releaseChecksumKey)
+	require.NoError(t, checksum.Sum(releasePath, checksumPath))
+
+	f, err := os.Open(checksumPath)
+	require.NoError(t, err)
+	defer f.Close()
+
+	checksums, err := checksum.Parse(f)
+	require.NoError(t, err)
+
+	cases := []struct {
+		name          string
+		upgradeName   string
+		downloadURL   string
+		binDir        string
+		urlPrefix     string
+		expectedError string
+	}{
+		{
+			name:      "genesis",
+			binDir:    "genesis/bin",
+			urlPrefix: "file://" + filepath.ToSlash(releasePath),
+		},
+		{
+			name:        "upgrade",
+			upgradeName: "v2",
+			downloadURL: "https://example.com/releases/v2/",
+			binDir:      "upgrades/v2/bin",
+			urlPrefix:   "https://example.com/releases/v2",
+		},
+		{
+			name:          "fail: upgrade name outside of the release",
+			upgradeName:   "../../x",
+			expectedError: `invalid upgrade name "../../x": only letters, digits, '_', '.' and '-' are allowed`,
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			path, err := c.CosmovisorRelease(releasePath, "mars", tt.upgradeName, tt.downloadURL, targets...)
+
+			// Assert
+			if tt.expectedError != "" {
+				require.EqualError(t, err, tt.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, filepath.Join(releasePath, "cosmovisor"), path)
+
+			for _, target := range []string{"linux_amd64", "darwin_arm64"} {
+				data, err := os.ReadFile(filepath.Join(path, target, tt.binDir, binary))
+				require.NoError(t, err)
+				require.Equal(t, target, string(data))
+			}
+
+			data, err := os.ReadFile(filepath.Join(path, "upgrade-info.json"))
+			require.NoError(t, err)
+
+			var info CosmovisorUpgradeInfo
+			require.NoError(t, json.Unmarshal(data, &info))
+			require.Equal(t, map[string]string{
+				"linux/amd64": fmt.Sprintf(
+					"%s/mars_linux_amd64.tar.gz?checksum=sha256:%s",
+					tt.urlPrefix,
+					checksums["mars_linux_amd64.tar.gz"],
+				),
+				"darwin/arm64": fmt.Sprintf(
+					"%s/mars_darwin_arm64.tar.gz?checksum=sha256:%s",
+					tt.urlPrefix,
+					checksums["mars_darwin_arm64.tar.gz"],
+				),
+			}, info.Binaries)
+		})
+	}
+}
@@ -4,12 +4,14 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"path/filepath"
 	"time"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/pkg/errors"
 
 	chainconfig "github.com/ignite/cli/ignite/config/chain"
+	"github.com/ignite/cli/ignite/pkg/cache"
 	chaincmdrunner "github.com/ignite/cli/ignite/pkg/chaincmd/runner"
 	"github.com/ignite/cli/ignite/pkg/cosmosfaucet"
 	"github.com/ignite/cli/ignite/pkg/xurl"
@@ -25,6 +27,9 @@ var (
 
 var envAPIAddress = os.Getenv("API_ADDRESS")
 
+// faucetLedgerFile is the name of the file where the faucet transfers are recorded.
+const faucetLedgerFile = "faucet.db"
+
 // Faucet returns the faucet for the chain or an error if the faucet
 // configuration is wrong or not configured (not enabled) at all.
 func (c *Chain) Faucet(ctx context.Context) (cosmosfaucet.Faucet, error) {
@@ -76,10 +81,22 @@ func (c *Chain) Faucet(ctx context.Context) (cosmosfaucet.Faucet, error) {
 		return cosmosfaucet.Faucet{}, fmt.Errorf("invalid host api address format: %w", err)
 	}
 
+	// keep track of the faucet transfers in disk so the limits survive restarts
+	savePath, err := c.chainSavePath()
+	if err != nil {
+		return cosmosfaucet.Faucet{}, err
+	}
+
+	ledgerStorage, err := cache.NewStorage(filepath.Join(savePath, faucetLedgerFile))
+	if err != nil {
+		return cosmosfaucet.Faucet{}, err
+	}
+
 	faucetOptions := []cosmosfaucet.Option{
 		cosmosfaucet.Account(*conf.Faucet.Name, "", ""),
 		cosmosfaucet.ChainID(id),
 		cosmosfaucet.OpenAPI(apiAddress),
+		cosmosfaucet.WithLedger(cosmosfaucet.NewCacheLedger(ledgerStorage)),
 	}
 
 	// parse coins to pass to the faucet as coins.
@@ -115,6 +132,10 @@ func (c *Chain) Faucet(ctx context.Context) (cosmosfaucet.Faucet, error) {
 		faucetOptions = append(faucetOptions, cosmosfaucet.RefreshWindow(rateLimitWindow))
 	}
 
+	if conf.Faucet.ChallengeDifficulty > 0 {
+		faucetOptions = append(faucetOptions, cosmosfaucet.ChallengeDifficulty(conf.Faucet.ChallengeDifficulty))
+	}
+
 	// init the faucet with options and return.
 	return cosmosfaucet.New(ctx, commands, faucetOptions...)
 }
@@ -0,0 +1,15 @@
+package chain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	chainconfig "github.com/ignite/cli/ignite/config/chain"
+	"github.com/ignite/cli/ignite/pkg/cosmosfaucet"
+)
+
+func TestFaucetMaxChallengeDifficulty(t *testing.T) {
+	// The config must accept the same difficulties as the faucet
+	require.EqualValues(t, cosmosfaucet.MaxChallengeDifficulty, chainconfig.MaxFaucetChallengeDifficulty)
+}
@@ -0,0 +1,463 @@
+package chain
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/bech32"
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+	distrtypes "github.com/cosmos/cosmos-sdk/x/distribution/types"
+	stakingtypes "github.com/cosmos/cosmos-sdk/x/staking/types"
+	"github.com/otiai10/copy"
+	"github.com/pkg/errors"
+
+	chainconfig "github.com/ignite/cli/ignite/config/chain"
+	"github.com/ignite/cli/ignite/pkg/events"
+)
+
+const (
+	// forkGovPeriod is the deposit and voting period of the governance
+	// proposals in a chain forked from an exported genesis.
+	forkGovPeriod = "60s"
+
+	// forkUnbondingTime is the unbonding time of the staking module in a
+	// chain forked from an exported genesis.
+	forkUnbondingTime = "60s"
+)
+
+// InitFromGenesis initializes the chain using the state of an exported genesis,
+// for example one exported from a mainnet or testnet node.
+// The validator set of the exported genesis is replaced by the validators
+// defined in the config, the accounts of the config are added to the state and
+// the governance and unbonding periods are shortened for local development.
+// The post init hooks run once the genesis is forked.
+func (c *Chain) InitFromGenesis(ctx context.Context, path string) error {
+	exported, err := readGenesis(path)
+	if err != nil {
+		return errors.Wrapf(err, "cannot read the genesis %s", path)
+	}
+
+	conf, err := c.Config()
+	if err != nil {
+		return &CannotBuildAppError{err}
+	}
+
+	if _, err := chainconfig.FirstValidator(conf); err != nil {
+		return err
+	}
+
+	// Initialize the chain from the config to get the validators gentxs and
+	// the accounts to inject into the exported state. The post init hooks
+	// run once the genesis is forked.
+	if conf, err = c.init(ctx, InitArgsAll); err != nil {
+		return err
+	}
+
+	c.ev.Send("Forking the exported genesis...", events.ProgressStart())
+
+	genesisPath, err := c.GenesisPath()
+	if err != nil {
+		return err
+	}
+
+	local, err := readGenesis(genesisPath)
+	if err != nil {
+		return err
+	}
+
+	if err := forkGenesis(exported, local); err != nil {
+		return errors.Wrapf(err, "cannot fork the genesis %s", path)
+	}
+
+	if err := patchGenesis(exported, conf); err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(exported, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	if err := os.WriteFile(genesisPath, data, 0o644); err != nil {
+		return err
+	}
+
+	nodes, err := c.validatorNodes(ctx, conf)
+	if err != nil {
+		return err
+	}
+
+	for _, node := range nodes[1:] {
+		if err := copy.Copy(genesisPath, nodeGenesisPath(node.home)); err != nil {
+			return err
+		}
+	}
+
+	// Keep the forked genesis as the exported one so serve imports
+	// the forked state when the app is rebuilt
+	exportedGenesisPath, err := c.exportedGenesisPath()
+	if err != nil {
+		return err
+	}
+
+	if err := copy.Copy(genesisPath, exportedGenesisPath); err != nil {
+		return err
+	}
+
+	if err := nodes[0].commands.ValidateGenesis(ctx); err != nil {
+		return errors.Wrap(err, "the forked genesis is not valid")
+	}
+
+	c.ev.Send("Genesis forked", events.ProgressFinish())
+
+	return c.runHooks(ctx, conf, chainconfig.HookPostInit)
+}
+
+func readGenesis(path string) (map[string]interface{}, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	// Keep the numbers as they are to avoid losing precision
+	d := json.NewDecoder(bytes.NewReader(data))
+	d.UseNumber()
+
+	var genesis map[string]interface{}
+	if err := d.Decode(&genesis); err != nil {
+		return nil, err
+	}
+
+	return genesis, nil
+}
+
+// forkGenesis rewrites the exported genesis to start a local chain with
+// the validators and accounts of the local genesis.
+// The local genesis must contain the gentxs of the local validators.
+func forkGenesis(exported, local map[string]interface{}) error {
+	appState := genesisField(exported, "app_state")
+	if appState == nil {
+		return errors.New("app state is missing")
+	}
+
+	localAppState := genesisField(local, "app_state")
+
+	exported["chain_id"] = local["chain_id"]
+	exported["genesis_time"] = local["genesis_time"]
+	exported["initial_height"] = "1"
+	exported["app_hash"] = ""
+
+	// The validator set is created from the gentxs of the local validators
+	exported["validators"] = []interface{}{}
+	appState["genutil"] = localAppState["genutil"]
+
+	if err := forkStaking(appState, localAppState); err != nil {
+		return err
+	}
+
+	forkSlashing(appState)
+	forkGov(appState)
+
+	communityPool, err := forkDistribution(appState)
+	if err != nil {
+		return err
+	}
+
+	if err := forkBank(appState, localAppState, communityPool); err != nil {
+		return err
+	}
+
+	return forkAuth(appState, localAppState)
+}
+
+// forkStaking removes the validators and delegations from the staking state.
+func forkStaking(appState, localAppState map[string]interface{}) error {
+	staking := genesisField(appState, "staking")
+	params := genesisField(staking, "params")
+	if params == nil {
+		return errors.New("staking params are missing")
+	}
+
+	bondDenom := params["bond_denom"]
+	localBondDenom := genesisField(localAppState, "staking", "params")["bond_denom"]
+	if bondDenom != localBondDenom {
+		return fmt.Errorf(
+			"the bond denom of the genesis is %q but the validators bond %q",
+			bondDenom,
+			localBondDenom,
+		)
+	}
+
+	for _, name := range []string{
+		"validators",
+		"delegations",
+		"unbonding_delegations",
+		"redelegations",
+		"last_validator_powers",
+	} {
+		staking[name] = []interface{}{}
+	}
+
+	staking["last_total_power"] = "0"
+	staking["exported"] = false
+	params["unbonding_time"] = forkUnbondingTime
+
+	return nil
+}
+
+// forkSlashing removes the signing info of the removed validators.
+func forkSlashing(appState map[string]interface{}) {
+	slashing := genesisField(appState, "slashing")
+	if slashing == nil {
+		return
+	}
+
+	slashing["signing_infos"] = []interface{}{}
+	slashing["missed_blocks"] = []interface{}{}
+}
+
+// forkGov shortens the deposit and voting periods of the governance proposals.
+// Both the params of gov v1 and the legacy ones are supported.
+func forkGov(appState map[string]interface{}) {
+	gov := genesisField(appState, "gov")
+
+	if params := genesisField(gov, "params"); params != nil {
+		params["max_deposit_period"] = forkGovPeriod
+		params["voting_period"] = forkGovPeriod
+	}
+
+	if params := genesisField(gov, "deposit_params"); params != nil {
+		params["max_deposit_period"] = forkGovPeriod
+	}
+
+	if params := genesisField(gov, "voting_params"); params != nil {
+		params["voting_period"] = forkGovPeriod
+	}
+}
+
+// forkDistribution removes the rewards of the removed validators and their
+// delegators. It returns the community pool which becomes the only
+// funds that the distribution module holds.
+func forkDistribution(appState map[string]interface{}) (sdk.Coins, error) {
+	distr := genesisField(appState, "distribution")
+	if distr == nil {
+		return nil, nil
+	}
+
+	for _, name := range []string{
+		"outstanding_rewards",
+		"validator_accumulated_commissions",
+		"validator_historical_rewards",
+		"validator_current_rewards",
+		"delegator_starting_infos",
+		"validator_slash_events",
+	} {
+		distr[name] = []interface{}{}
+	}
+
+	distr["previous_proposer"] = ""
+
+	var communityPool sdk.DecCoins
+	if err := convertJSON(genesisField(distr, "fee_pool")["community_pool"], &communityPool); err != nil {
+		return nil, errors.Wrap(err, "invalid community pool")
+	}
+
+	coins, _ := communityPool.TruncateDecimal()
+
+	return coins, nil
+}
+
+// forkBank removes the balances of the staking pools, sets the balance of the
+// distribution module to the community pool and adds the balances of the local
+// genesis. The supply is recomputed by the bank module from the balances.
+func forkBank(appState, localAppState map[string]interface{}, communityPool sdk.Coins) error {
+	bank := genesisField(appState, "bank")
+	if bank == nil {
+		return errors.New("bank state is missing")
+	}
+
+	var (
+		bondedPool    = authtypes.NewModuleAddress(stakingtypes.BondedPoolName)
+		notBondedPool = authtypes.NewModuleAddress(stakingtypes.NotBondedPoolName)
+		distrModule   = authtypes.NewModuleAddress(distrtypes.ModuleName)
+		distrFound    bool
+		balances      []interface{}
+		indexes       = make(map[string]int)
+	)
+
+	exportedBalances, _ := bank["balances"].([]interface{})
+	for _, b := range exportedBalances {
+		balance, _ := b.(map[string]interface{})
+		address, _ := balance["address"].(string)
+
+		_, addr, err := bech32.DecodeAndConvert(address)
+		if err != nil {
+			return errors.Wrapf(err, "invalid balance address %s", address)
+		}
+
+		switch {
+		case bytes.Equal(addr, bondedPool), bytes.Equal(addr, notBondedPool):
+			continue
+		case bytes.Equal(addr, distrModule):
+			distrFound = true
+			balance["coins"] = coinsJSON(communityPool)
+		}
+
+		indexes[address] = len(balances)
+		balances = append(balances, balance)
+	}
+
+	localBalances, _ := genesisField(localAppState, "bank")["balances"].([]interface{})
+	for _, b := range localBalances {
+		balance, _ := b.(map[string]interface{})
+		address, _ := balance["address"].(string)
+
+		i, ok := indexes[address]
+		if !ok {
+			indexes[address] = len(balances)
+			balances = append(balances, balance)
+			continue
+		}
+
+		var coins, localCoins sdk.Coins
+		if err := convertJSON(balances[i].(map[string]interface{})["coins"], &coins); err != nil {
+			return errors.Wrapf(err, "invalid balance of %s", address)
+		}
+
+		if err := convertJSON(balance["coins"], &localCoins); err != nil {
+			return errors.Wrapf(err, "invalid balance of %s", address)
+		}
+
+		balances[i].(map[string]interface{})["coins"] = coinsJSON(coins.Add(localCoins...))
+	}
+
+	if !distrFound && !communityPool.IsZero() && len(localBalances) > 0 {
+		address, _ := localBalances[0].(map[string]interface{})["address"].(string)
+		prefix, _, err := bech32.DecodeAndConvert(address)
+		if err != nil {
+			return err
+		}
+
+		distrAddress, err := sdk.Bech32ifyAddressBytes(prefix, distrModule)
+		if err != nil {
+			return err
+		}
+
+		balances = append(balances, map[string]interface{}{
+			"address": distrAddress,
+			"coins":   coinsJSON(communityPool),
+		})
+	}
+
+	bank["balances"] = balances
+	bank["supply"] = []interface{}{}
+
+	return nil
+}
+
+// forkAuth adds the accounts of the local genesis that don't exist in the
+// exported state. The accounts are numbered after the existing ones.
+func forkAuth(appState, localAppState map[string]interface{}) error {
+	auth := genesisField(appState, "auth")
+	if auth == nil {
+		return errors.New("auth state is missing")
+	}
+
+	var (
+		accounts, _ = auth["accounts"].([]interface{})
+		addresses   = make(map[string]bool)
+		next        uint64
+	)
+
+	for _, a := range accounts {
+		account, _ := a.(map[string]interface{})
+		base := baseAccount(account)
+		if base == nil {
+			continue
+		}
+
+		address, _ := base["address"].(string)
+		addresses[address] = true
+
+		if n, err := strconv.ParseUint(fmt.Sprint(base["account_number"]), 10, 64); err == nil && n >= next {
+			next = n + 1
+		}
+	}
+
+	localAccounts, _ := genesisField(localAppState, "auth")["accounts"].([]interface{})
+	for _, a := range localAccounts {
+		account, _ := a.(map[string]interface{})
+		base := baseAccount(account)
+		if base == nil {
+			continue
+		}
+
+		address, _ := base["address"].(string)
+		if addresses[address] {
+			continue
+		}
+
+		base["account_number"] = strconv.FormatUint(next, 10)
+		next++
+
+		accounts = append(accounts, account)
+	}
+
+	auth["accounts"] = accounts
+
+	return nil
+}
+
+// baseAccount returns the base account of a genesis account.
+// Module and vesting accounts embed the base account.
+func baseAccount(account map[string]interface{}) map[string]interface{} {
+	if _, ok := account["address"]; ok {
+		return account
+	}
+
+	if base := genesisField(account, "base_account"); base != nil {
+		return base
+	}
+
+	return genesisField(account, "base_vesting_account", "base_account")
+}
+
+// genesisField returns the object found following the keys or nil
+// when the object doesn't exist.
+func genesisField(m map[string]interface{}, keys ...string) map[string]interface{} {
+	for _, k := range keys {
+		if m == nil {
+			return nil
+		}
+
+		m, _ = m[k].(map[string]interface{})
+	}
+
+	return m
+}
+
+func convertJSON(in, out interface{}) error {
+	data, err := json.Marshal(in)
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(data, out)
+}
+
+func coinsJSON(coins sdk.Coins) []interface{} {
+	list := make([]interface{}, len(coins))
+	for i, c := range coins {
+		list[i] = map[string]interface{}{
+			"denom":  c.Denom,
+			"amount": c.Amount.String(),
+		}
+	}
+
+	return list
+}
@@ -0,0 +1,191 @@
+package chain
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+	distrtypes "github.com/cosmos/cosmos-sdk/x/distribution/types"
+	stakingtypes "github.com/cosmos/cosmos-sdk/x/staking/types"
+	"github.com/stretchr/testify/require"
+
+	chainconfig "github.com/ignite/cli/ignite/config/chain"
+)
+
+// genesisAddress returns an address derived from name like module addresses.
+func genesisAddress(t *testing.T, name string) string {
+	t.Helper()
+
+	address, err := sdk.Bech32ifyAddressBytes("cosmos", authtypes.NewModuleAddress(name))
+	require.NoError(t, err)
+	return address
+}
+
+func decodeGenesis(t *testing.T, s string) map[string]interface{} {
+	t.Helper()
+
+	var genesis map[string]interface{}
+	require.NoError(t, json.Unmarshal([]byte(s), &genesis))
+	return genesis
+}
+
+func TestForkGenesis(t *testing.T) {
+	// Arrange
+	var (
+		bondedPool = genesisAddress(t, stakingtypes.BondedPoolName)
+		distr      = genesisAddress(t, distrtypes.ModuleName)
+		alice      = genesisAddress(t, "alice")
+		bob        = genesisAddress(t, "bob")
+		whale      = genesisAddress(t, "whale")
+	)
+	exported := decodeGenesis(t, fmt.Sprintf(`{
+		"chain_id": "mainnet-1",
+		"genesis_time": "2023-01-01T00:00:00Z",
+		"initial_height": "1234",
+		"validators": [{"name": "mainnet-validator"}],
+		"app_state": {
+			"auth": {"accounts": [
+				{"@type": "/cosmos.auth.v1beta1.BaseAccount", "address": %[3]q, "account_number": "7"},
+				{"@type": "/cosmos.auth.v1beta1.ModuleAccount", "base_account": {"address": %[1]q, "account_number": "3"}},
+				{"@type": "/cosmos.auth.v1beta1.BaseAccount", "address": %[4]q, "account_number": "12"}
+			]},
+			"bank": {
+				"balances": [
+					{"address": %[1]q, "coins": [{"denom": "stake", "amount": "5000"}]},
+					{"address": %[2]q, "coins": [{"denom": "stake", "amount": "120"}]},
+					{"address": %[3]q, "coins": [{"denom": "stake", "amount": "10"}]},
+					{"address": %[4]q, "coins": [{"denom": "stake", "amount": "1000000"}]}
+				],
+				"supply": [{"denom": "stake", "amount": "1005130"}]
+			},
+			"distribution": {
+				"fee_pool": {"community_pool": [{"denom": "stake", "amount": "99.750000000000000000"}]},
+				"outstanding_rewards": [{"validator_address": "cosmosvaloper1"}],
+				"previous_proposer": "cosmosvalcons1"
+			},
+			"gov": {"params": {"voting_period": "1209600s", "max_deposit_period": "1209600s"}},
+			"slashing": {"signing_infos": [{"address": "cosmosvalcons1"}], "missed_blocks": [{"address": "cosmosvalcons1"}]},
+			"staking": {
+				"params": {"bond_denom": "stake", "unbonding_time": "1814400s"},
+				"validators": [{"operator_address": "cosmosvaloper1"}],
+				"delegations": [{"delegator_address": %[4]q}],
+				"last_total_power": "5000",
+				"exported": true
+			}
+		}
+	}`, bondedPool, distr, alice, whale))
+	local := decodeGenesis(t, fmt.Sprintf(`{
+		"chain_id": "mars",
+		"genesis_time": "2023-06-01T00:00:00Z",
+		"app_state": {
+			"auth": {"accounts": [
+				{"@type": "/cosmos.auth.v1beta1.BaseAccount", "address": %[1]q, "account_number": "0"},
+				{"@type": "/cosmos.auth.v1beta1.BaseAccount", "address": %[2]q, "account_number": "1"}
+			]},
+			"bank": {"balances": [
+				{"address": %[1]q, "coins": [{"denom": "stake", "amount": "200"}, {"denom": "token", "amount": "20"}]},
+				{"address": %[2]q, "coins": [{"denom": "stake", "amount": "100"}]}
+			]},
+			"genutil": {"gen_txs": [{"body": "alice"}]},
+			"staking": {"params": {"bond_denom": "stake"}}
+		}
+	}`, alice, bob))
+
+	// Act
+	err := forkGenesis(exported, local)
+
+	// Assert
+	require.NoError(t, err)
+	expected := decodeGenesis(t, fmt.Sprintf(`{
+		"chain_id": "mars",
+		"genesis_time": "2023-06-01T00:00:00Z",
+		"initial_height": "1",
+		"app_hash": "",
+		"validators": [],
+		"app_state": {
+			"auth": {"accounts": [
+				{"@type": "/cosmos.auth.v1beta1.BaseAccount", "address": %[2]q, "account_number": "7"},
+				{"@type": "/cosmos.auth.v1beta1.ModuleAccount", "base_account": {"address": %[4]q, "account_number": "3"}},
+				{"@type": "/cosmos.auth.v1beta1.BaseAccount", "address": %[5]q, "account_number": "12"},
+				{"@type": "/cosmos.auth.v1beta1.BaseAccount", "address": %[3]q, "account_number": "13"}
+			]},
+			"bank": {
+				"balances": [
+					{"address": %[1]q, "coins": [{"denom": "stake", "amount": "99"}]},
+					{"address": %[2]q, "coins": [{"denom": "stake", "amount": "210"}, {"denom": "token", "amount": "20"}]},
+					{"address": %[5]q, "coins": [{"denom": "stake", "amount": "1000000"}]},
+					{"address": %[3]q, "coins": [{"denom": "stake", "amount": "100"}]}
+				],
+				"supply": []
+			},
+			"distribution": {
+				"fee_pool": {"community_pool": [{"denom": "stake", "amount": "99.750000000000000000"}]},
+				"outstanding_rewards": [],
+				"validator_accumulated_commissions": [],
+				"validator_historical_rewards": [],
+				"validator_current_rewards": [],
+				"delegator_starting_infos": [],
+				"validator_slash_events": [],
+				"previous_proposer": ""
+			},
+			"genutil": {"gen_txs": [{"body": "alice"}]},
+			"gov": {"params": {"voting_period": "60s", "max_deposit_period": "60s"}},
+			"slashing": {"signing_infos": [], "missed_blocks": []},
+			"staking": {
+				"params": {"bond_denom": "stake", "unbonding_time": "60s"},
+				"validators": [],
+				"delegations": [],
+				"unbonding_delegations": [],
+				"redelegations": [],
+				"last_validator_powers": [],
+				"last_total_power": "0",
+				"exported": false
+			}
+		}
+	}`, distr, alice, bob, bondedPool, whale))
+	expectedJSON, err := json.Marshal(expected)
+	require.NoError(t, err)
+	actualJSON, err := json.Marshal(exported)
+	require.NoError(t, err)
+	require.JSONEq(t, string(expectedJSON), string(actualJSON))
+}
+
+func TestForkGenesisBondDenomMismatch(t *testing.T) {
+	exported := decodeGenesis(t, `{"app_state": {"staking": {"params": {"bond_denom": "uatom"}}}}`)
+	local := decodeGenesis(t, `{"app_state": {"staking": {"params": {"bond_denom": "stake"}}}}`)
+
+	err := forkGenesis(exported, local)
+
+	require.EqualError(t, err, `the bond denom of the genesis is "uatom" but the validators bond "stake"`)
+}
+
+func TestInitFromGenesisWithoutValidators(t *testing.T) {
+	// Arrange
+	dir := t.TempDir()
+	configPath := filepath.Join(dir, "config.yml")
+	genesisPath := filepath.Join(dir, "genesis.json")
+	config := `
+version: 1
+accounts:
+  - name: alice
+    coins: ["100000000stake"]
+`
+	require.NoError(t, os.WriteFile(configPath, []byte(config), 0o644))
+	require.NoError(t, os.WriteFile(genesisPath, []byte(`{"app_state":{}}`), 0o644))
+
+	c, err := New(tempSource(t, "testdata/version/mars.v0.2.tar.gz"), ConfigFile(configPath))
+	require.NoError(t, err)
+
+	// Act
+	err = c.InitFromGenesis(context.Background(), genesisPath)
+
+	// Assert
+	var validationErr *chainconfig.ValidationError
+	require.ErrorAs(t, err, &validationErr)
+	require.EqualError(t, err, "config is not valid: at least one validator is required")
+}
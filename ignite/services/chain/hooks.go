@@ -0,0 +1,187 @@
+package chain
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	rpchttp "github.com/cometbft/cometbft/rpc/client/http"
+	"github.com/pkg/errors"
+
+	chainconfig "github.com/ignite/cli/ignite/config/chain"
+	"github.com/ignite/cli/ignite/pkg/cliui/colors"
+	"github.com/ignite/cli/ignite/pkg/cliui/icons"
+	"github.com/ignite/cli/ignite/pkg/cmdrunner"
+	"github.com/ignite/cli/ignite/pkg/cmdrunner/step"
+	"github.com/ignite/cli/ignite/pkg/events"
+	"github.com/ignite/cli/ignite/pkg/xexec"
+	"github.com/ignite/cli/ignite/pkg/xurl"
+)
+
+const hookOutputPrefix = "hook"
+
+// HookData is the chain info available to the templates of the hook commands.
+type HookData struct {
+	// ChainID is the ID of the chain.
+	ChainID string
+
+	// Home is the home directory of the first validator node.
+	Home string
+
+	// Binary is the path of the app binary.
+	Binary string
+
+	// RPC is the RPC address of the first validator node.
+	RPC string
+
+	// API is the API address of the first validator node.
+	API string
+
+	// GRPC is the gRPC address of the first validator node.
+	GRPC string
+}
+
+func (c *Chain) hookData(cfg *chainconfig.Config) (HookData, error) {
+	id, err := c.ID()
+	if err != nil {
+		return HookData{}, err
+	}
+
+	home, err := c.Home()
+	if err != nil {
+		return HookData{}, err
+	}
+
+	binary, err := c.Binary()
+	if err != nil {
+		return HookData{}, err
+	}
+
+	// The binary might not be installed yet when the chain is initialized
+	binary = xexec.TryResolveAbsPath(binary)
+	if c.binaryPath != "" {
+		binary = c.binaryPath
+	}
+
+	validator, err := chainconfig.FirstValidator(cfg)
+	if err != nil {
+		return HookData{}, err
+	}
+
+	servers, err := validator.GetServers()
+	if err != nil {
+		return HookData{}, err
+	}
+
+	return HookData{
+		ChainID: id,
+		Home:    home,
+		Binary:  binary,
+		RPC:     servers.RPC.Address,
+		API:     servers.API.Address,
+		GRPC:    servers.GRPC.Address,
+	}, nil
+}
+
+// runHooks executes the shell commands of the hooks with the given name
+// defined in the config, one after the other, from the app directory.
+func (c *Chain) runHooks(ctx context.Context, cfg *chainconfig.Config, name string) error {
+	hooks, err := chainconfig.Hooks(cfg, name)
+	if err != nil {
+		return err
+	}
+
+	if len(hooks) == 0 {
+		return nil
+	}
+
+	data, err := c.hookData(cfg)
+	if err != nil {
+		return err
+	}
+
+	var stdout, stderr io.Writer = os.Stdout, os.Stderr
+	if c.logOutputer != nil {
+		out := c.logOutputer.NewOutput(hookOutputPrefix, colors.Yellow)
+		stdout, stderr = out.Stdout(), out.Stderr()
+	}
+
+	for _, h := range hooks {
+		var command bytes.Buffer
+		if err := h.Command.Execute(&command, data); err != nil {
+			return errors.Wrapf(err, "cannot render %s", h.Field)
+		}
+
+		c.ev.Send(
+			fmt.Sprintf("Running %s hook: %s", name, colors.Faint(command.String())),
+			events.Icon(icons.Bullet),
+		)
+
+		var errb bytes.Buffer
+		err := cmdrunner.
+			New(
+				cmdrunner.DefaultStdout(stdout),
+				cmdrunner.DefaultStderr(io.MultiWriter(stderr, &errb)),
+				cmdrunner.DefaultWorkdir(c.app.Path),
+			).
+			Run(ctx, step.New(step.Exec("sh", "-c", command.String())))
+		if err != nil {
+			return errors.Wrapf(err, "%s hook failed: %s", h.Field, errb.String())
+		}
+	}
+
+	return nil
+}
+
+// runPostStartHooks executes the post start hooks once the first
+// validator node commits its first block. The chain keeps running
+// when a hook fails, so the error is only reported by the caller.
+func (c *Chain) runPostStartHooks(ctx context.Context, cfg *chainconfig.Config) error {
+	validator, err := chainconfig.FirstValidator(cfg)
+	if err != nil {
+		return err
+	}
+
+	servers, err := validator.GetServers()
+	if err != nil {
+		return err
+	}
+
+	rpcAddr, err := xurl.HTTP(servers.RPC.Address)
+	if err != nil {
+		return err
+	}
+
+	if err := waitFirstBlock(ctx, rpcAddr); err != nil {
+		return err
+	}
+
+	return c.runHooks(ctx, cfg, chainconfig.HookPostStart)
+}
+
+// waitFirstBlock waits until the node with the given RPC address commits its first block.
+func waitFirstBlock(ctx context.Context, rpcAddr string) error {
+	client, err := rpchttp.New(rpcAddr, "/websocket")
+	if err != nil {
+		return err
+	}
+
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+
+	for {
+		// Ignore the errors because the node might not be ready yet
+		if status, err := client.Status(ctx); err == nil && status.SyncInfo.LatestBlockHeight > 0 {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,50 @@
+package chain
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	chainconfig "github.com/ignite/cli/ignite/config/chain"
+)
+
+func TestRunHooks(t *testing.T) {
+	// Arrange
+	home := t.TempDir()
+
+	c, err := New(tempSource(t, "testdata/version/mars.v0.2.tar.gz"), HomePath(home))
+	require.NoError(t, err)
+
+	cfg, err := chainconfig.Parse(strings.NewReader(`
+version: 1
+accounts:
+  - name: alice
+    coins: ["100000000stake"]
+validators:
+  - name: alice
+    bonded: 100000000stake
+hooks:
+  pre_start:
+    - echo "{{.Home}} {{.RPC}}" > hook.txt
+    - echo "{{.GRPC}}" >> hook.txt
+  post_start:
+    - exit 1
+`))
+	require.NoError(t, err)
+
+	// Act
+	err = c.runHooks(context.Background(), cfg, chainconfig.HookPreStart)
+
+	// Assert
+	require.NoError(t, err)
+	data, err := os.ReadFile(filepath.Join(c.app.Path, "hook.txt"))
+	require.NoError(t, err)
+	require.Equal(t, home+" 0.0.0.0:26657\n0.0.0.0:9090\n", string(data))
+
+	err = c.runHooks(context.Background(), cfg, chainconfig.HookPostStart)
+	require.ErrorContains(t, err, "hooks.post_start[0] hook failed")
+}
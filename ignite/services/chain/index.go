@@ -0,0 +1,148 @@
+package chain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/ignite/cli/ignite/pkg/cliui/icons"
+	"github.com/ignite/cli/ignite/pkg/cosmosclient"
+	"github.com/ignite/cli/ignite/pkg/cosmostxcollector"
+	"github.com/ignite/cli/ignite/pkg/cosmostxcollector/adapter"
+	"github.com/ignite/cli/ignite/pkg/events"
+)
+
+const (
+	// DefaultIndexPollInterval is the default time to wait before checking for new blocks to index.
+	DefaultIndexPollInterval = time.Second * 5
+
+	// indexDatabaseFile is the name of the default SQLite database file used to index the chain.
+	indexDatabaseFile = "index.db"
+)
+
+type indexOptions struct {
+	fromHeight   int64
+	pollInterval time.Duration
+}
+
+// IndexOption provides options for the index command.
+type IndexOption func(*indexOptions)
+
+// IndexFromHeight sets the block height to start indexing from when the data backend is empty.
+func IndexFromHeight(height int64) IndexOption {
+	return func(o *indexOptions) {
+		o.fromHeight = height
+	}
+}
+
+// IndexPollInterval sets the time to wait before checking for new blocks.
+func IndexPollInterval(d time.Duration) IndexOption {
+	return func(o *indexOptions) {
+		o.pollInterval = d
+	}
+}
+
+// IndexDatabasePath returns the path to the default SQLite database file used to index the chain.
+// Creates the parent directory if it doesn't exist.
+func (c *Chain) IndexDatabasePath() (string, error) {
+	savePath, err := c.chainSavePath()
+	if err != nil {
+		return "", err
+	}
+
+	if err := os.MkdirAll(savePath, 0o700); err != nil {
+		return "", err
+	}
+
+	return filepath.Join(savePath, indexDatabaseFile), nil
+}
+
+// Index collects the transactions and events of a running chain and saves them into a data backend.
+// Indexing resumes after the latest block saved in the data backend and keeps following
+// the new blocks until the context is canceled.
+func (c *Chain) Index(ctx context.Context, client cosmostxcollector.TXsCollector, db adapter.Adapter, options ...IndexOption) error {
+	o := indexOptions{
+		pollInterval: DefaultIndexPollInterval,
+	}
+
+	// apply the options
+	for _, apply := range options {
+		apply(&o)
+	}
+
+	c.ev.Send("Initializing the index database", events.ProgressStart())
+
+	if err := db.Init(ctx); err != nil {
+		return fmt.Errorf("failed to initialize the index database: %w", err)
+	}
+
+	savedHeight, err := indexedHeight(ctx, db)
+	if err != nil {
+		return fmt.Errorf("failed to read the latest indexed height: %w", err)
+	}
+
+	// Resume from the block after the latest one saved in the data backend
+	fromHeight := savedHeight + 1
+	if savedHeight == 0 && o.fromHeight > 0 {
+		fromHeight = o.fromHeight
+	}
+
+	c.ev.Send(fmt.Sprintf("Indexing blocks from height %d", fromHeight), events.ProgressUpdate())
+
+	// The collector saves the transactions of each block in a single database
+	// transaction and then saves a checkpoint so stopping the indexer is safe
+	collector := cosmostxcollector.New(indexSaver{db, c.ev}, client)
+	err = collector.Collect(
+		ctx,
+		fromHeight,
+		cosmosclient.CollectFollow(),
+		cosmosclient.CollectPollInterval(o.pollInterval),
+	)
+
+	// Canceling the context is the expected way to stop indexing
+	if err != nil && !errors.Is(ctx.Err(), context.Canceled) {
+		return fmt.Errorf("failed to index blocks: %w", err)
+	}
+
+	c.ev.Send("Indexer stopped", events.ProgressFinish(), events.Icon(icons.OK))
+
+	return nil
+}
+
+// indexedHeight returns the height of the latest block saved in the data backend.
+func indexedHeight(ctx context.Context, db adapter.Adapter) (int64, error) {
+	if checkpointer, ok := db.(adapter.Checkpointer); ok {
+		return checkpointer.GetCheckpoint(ctx)
+	}
+
+	return db.GetLatestHeight(ctx)
+}
+
+// indexSaver saves the indexed transactions and reports the indexing progress.
+type indexSaver struct {
+	adapter.Adapter
+
+	ev events.Bus
+}
+
+// SaveCheckpoint saves the checkpoint when the data backend supports it.
+// The checkpoint is saved after the transactions of each block are saved.
+func (s indexSaver) SaveCheckpoint(ctx context.Context, height int64) error {
+	if checkpointer, ok := s.Adapter.(adapter.Checkpointer); ok {
+		if err := checkpointer.SaveCheckpoint(ctx, height); err != nil {
+			return err
+		}
+	}
+
+	s.ev.Send(fmt.Sprintf("Indexed block %d", height), events.ProgressUpdate())
+
+	return nil
+}
+
+// GetCheckpoint returns the checkpoint when the data backend supports it.
+func (s indexSaver) GetCheckpoint(ctx context.Context) (int64, error) {
+	return indexedHeight(ctx, s.Adapter)
+}
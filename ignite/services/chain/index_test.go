@@ -0,0 +1,158 @@
+package chain
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	ctypes "github.com/cometbft/cometbft/rpc/core/types"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ignite/cli/ignite/pkg/cosmosclient"
+	"github.com/ignite/cli/ignite/pkg/cosmostxcollector/adapter"
+)
+
+// testIndexClient is a client for a chain that has a transaction in each block.
+// The client collects the blocks until the latest height and then cancels the
+// context to simulate that the indexer is stopped while following new blocks.
+type testIndexClient struct {
+	latestHeight int64
+	cancel       context.CancelFunc
+	err          error
+
+	// collectedFrom contains the height used in each transactions collection.
+	collectedFrom []int64
+}
+
+func (c *testIndexClient) CollectBlockTXs(
+	ctx context.Context,
+	fromHeight int64,
+	bc chan<- cosmosclient.BlockTXs,
+	_ ...cosmosclient.CollectTXsOption,
+) error {
+	defer close(bc)
+
+	c.collectedFrom = append(c.collectedFrom, fromHeight)
+
+	if c.err != nil {
+		return c.err
+	}
+
+	for height := fromHeight; height <= c.latestHeight; height++ {
+		bc <- cosmosclient.BlockTXs{
+			Height: height,
+			TXs:    []cosmosclient.TX{{Raw: &ctypes.ResultTx{Height: height}}},
+		}
+	}
+
+	c.cancel()
+
+	return ctx.Err()
+}
+
+// testIndexDB is a data backend that keeps the saved transactions in memory.
+type testIndexDB struct {
+	adapter.Adapter
+
+	heights    []int64
+	checkpoint int64
+}
+
+func (db *testIndexDB) Init(context.Context) error {
+	return nil
+}
+
+func (db *testIndexDB) Save(_ context.Context, txs []cosmosclient.TX) error {
+	for _, tx := range txs {
+		db.heights = append(db.heights, tx.Raw.Height)
+	}
+
+	return nil
+}
+
+func (db *testIndexDB) SaveCheckpoint(_ context.Context, height int64) error {
+	db.checkpoint = height
+	return nil
+}
+
+func (db *testIndexDB) GetCheckpoint(context.Context) (int64, error) {
+	return db.checkpoint, nil
+}
+
+func TestIndex(t *testing.T) {
+	cases := []struct {
+		name           string
+		checkpoint     int64
+		latestHeight   int64
+		options        []IndexOption
+		wantHeights    []int64
+		wantFrom       int64
+		wantCheckpoint int64
+	}{
+		{
+			name:           "empty data backend",
+			latestHeight:   3,
+			wantHeights:    []int64{1, 2, 3},
+			wantFrom:       1,
+			wantCheckpoint: 3,
+		},
+		{
+			name:           "resume from checkpoint",
+			checkpoint:     2,
+			latestHeight:   3,
+			wantHeights:    []int64{3},
+			wantFrom:       3,
+			wantCheckpoint: 3,
+		},
+		{
+			name:           "start from height",
+			latestHeight:   5,
+			options:        []IndexOption{IndexFromHeight(4)},
+			wantHeights:    []int64{4, 5},
+			wantFrom:       4,
+			wantCheckpoint: 5,
+		},
+		{
+			name:           "ignore start height when resuming",
+			checkpoint:     4,
+			latestHeight:   5,
+			options:        []IndexOption{IndexFromHeight(2)},
+			wantHeights:    []int64{5},
+			wantFrom:       5,
+			wantCheckpoint: 5,
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			client := &testIndexClient{latestHeight: tt.latestHeight, cancel: cancel}
+			db := &testIndexDB{checkpoint: tt.checkpoint}
+
+			// Act
+			err := (&Chain{}).Index(ctx, client, db, tt.options...)
+
+			// Assert
+			require.NoError(t, err)
+			require.Equal(t, tt.wantHeights, db.heights)
+			require.Equal(t, []int64{tt.wantFrom}, client.collectedFrom)
+			require.Equal(t, tt.wantCheckpoint, db.checkpoint)
+		})
+	}
+}
+
+func TestIndexError(t *testing.T) {
+	// Arrange
+	wantErr := errors.New("failed")
+	client := &testIndexClient{err: wantErr}
+	db := &testIndexDB{}
+
+	// Act
+	err := (&Chain{}).Index(context.Background(), client, db)
+
+	// Assert
+	require.ErrorIs(t, err, wantErr)
+}
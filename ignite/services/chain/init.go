@@ -2,6 +2,8 @@ package chain
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"os"
 	"path/filepath"
 	"strings"
@@ -9,6 +11,7 @@ import (
 	"github.com/imdario/mergo"
 
 	chainconfig "github.com/ignite/cli/ignite/config/chain"
+	"github.com/ignite/cli/ignite/config/chain/base"
 	chaincmdrunner "github.com/ignite/cli/ignite/pkg/chaincmd/runner"
 	"github.com/ignite/cli/ignite/pkg/cliui/view/accountview"
 	"github.com/ignite/cli/ignite/pkg/confile"
@@ -49,19 +52,32 @@ var (
 
 // Init initializes the chain and accounts.
 func (c *Chain) Init(ctx context.Context, args InitArgs) error {
-	if err := c.InitChain(ctx, args.InitConfiguration, args.InitGenesis); err != nil {
+	conf, err := c.init(ctx, args)
+	if err != nil {
 		return err
 	}
 
+	return c.runHooks(ctx, conf, chainconfig.HookPostInit)
+}
+
+// init initializes the chain and accounts without running the post init hooks.
+func (c *Chain) init(ctx context.Context, args InitArgs) (*chainconfig.Config, error) {
+	if err := c.InitChain(ctx, args.InitConfiguration, args.InitGenesis); err != nil {
+		return nil, err
+	}
+
+	conf, err := c.Config()
+	if err != nil {
+		return nil, &CannotBuildAppError{err}
+	}
+
 	if args.InitAccounts {
-		conf, err := c.Config()
-		if err != nil {
-			return &CannotBuildAppError{err}
+		if err := c.InitAccounts(ctx, conf); err != nil {
+			return nil, err
 		}
-
-		return c.InitAccounts(ctx, conf)
 	}
-	return nil
+
+	return conf, nil
 }
 
 // InitChain initializes the chain.
@@ -90,12 +106,9 @@ func (c *Chain) InitChain(ctx context.Context, initConfiguration, initGenesis bo
 		return err
 	}
 
-	var conf *chainconfig.Config
-	if initConfiguration || initGenesis {
-		conf, err = c.Config()
-		if err != nil {
-			return err
-		}
+	conf, err := c.Config()
+	if err != nil {
+		return err
 	}
 
 	// ovewrite app config files with the values defined in Ignite's config file
@@ -105,6 +118,13 @@ func (c *Chain) InitChain(ctx context.Context, initConfiguration, initGenesis bo
 		}
 	}
 
+	// init the nodes for the rest of the validators
+	if len(conf.Validators) > 1 {
+		if err := c.initValidatorNodes(ctx, conf, initConfiguration); err != nil {
+			return err
+		}
+	}
+
 	if initGenesis {
 		// make sure that chain id given during chain.New() has the most priority.
 		if conf.Genesis != nil {
@@ -115,6 +135,10 @@ func (c *Chain) InitChain(ctx context.Context, initConfiguration, initGenesis bo
 		if err := c.UpdateGenesisFile(conf.Genesis); err != nil {
 			return err
 		}
+
+		if err := c.PatchGenesisFile(conf); err != nil {
+			return err
+		}
 	}
 
 	return nil
@@ -131,6 +155,10 @@ func (c *Chain) InitAccounts(ctx context.Context, cfg *chainconfig.Config) error
 
 	var accounts accountview.Accounts
 
+	// keys keeps the accounts with a mnemonic so their keys
+	// can be recovered in the keyring of other validator nodes
+	keys := make(map[string]base.Account)
+
 	// add accounts from config into genesis
 	for _, account := range cfg.Accounts {
 		var generatedAccount chaincmdrunner.Account
@@ -143,6 +171,12 @@ func (c *Chain) InitAccounts(ctx context.Context, cfg *chainconfig.Config) error
 				return err
 			}
 			accountAddress = generatedAccount.Address
+
+			keys[account.Name] = base.Account{
+				Name:     account.Name,
+				Mnemonic: generatedAccount.Mnemonic,
+				CoinType: account.CoinType,
+			}
 		}
 
 		coins := strings.Join(account.Coins, ",")
@@ -163,9 +197,15 @@ func (c *Chain) InitAccounts(ctx context.Context, cfg *chainconfig.Config) error
 
 	c.ev.SendView(accounts, events.ProgressFinish())
 
-	// 0 length validator set when using network config
-	if len(cfg.Validators) != 0 {
-		_, err = c.IssueGentx(ctx, createValidatorFromConfig(cfg))
+	switch n := len(cfg.Validators); {
+	case n == 0:
+		// 0 length validator set when using network config
+	case n == 1:
+		_, err = c.IssueGentx(ctx, createValidatorFromConfig(cfg.Validators[0]))
+	default:
+		c.ev.Send(fmt.Sprintf("Initializing %d validator nodes...", n), events.ProgressUpdate())
+
+		err = c.issueValidatorsGentxs(ctx, cfg, keys)
 	}
 
 	return err
@@ -229,6 +269,62 @@ func (c Chain) UpdateGenesisFile(data map[string]interface{}) error {
 	return cf.Save(genesis)
 }
 
+// PatchGenesisFile applies the genesis patches defined in the config to the chain genesis.
+func (c Chain) PatchGenesisFile(conf *chainconfig.Config) error {
+	path, err := c.GenesisPath()
+	if err != nil {
+		return err
+	}
+
+	genesis := make(map[string]interface{})
+	cf := confile.New(confile.DefaultJSONEncodingCreator, path)
+	if err := cf.Load(&genesis); err != nil {
+		return err
+	}
+
+	if err := patchGenesis(genesis, conf); err != nil {
+		return err
+	}
+
+	return cf.Save(genesis)
+}
+
+// patchGenesis applies the genesis patches defined in the config to a genesis.
+func patchGenesis(genesis map[string]interface{}, conf *chainconfig.Config) error {
+	patches, err := chainconfig.GenesisPatches(conf)
+	if err != nil {
+		return err
+	}
+
+	var doc interface{} = genesis
+	for _, p := range patches {
+		if doc, err = p.Apply(doc); err != nil {
+			return fmt.Errorf("cannot apply genesis patch %s (%s): %w", p.Field, p.Operation, err)
+		}
+	}
+
+	patched, ok := doc.(map[string]interface{})
+	if !ok {
+		return errors.New("genesis patches must not replace the genesis with a value that is not an object")
+	}
+
+	// Update the genesis in place when a patch replaces the whole genesis
+	values := make(map[string]interface{}, len(patched))
+	for k, v := range patched {
+		values[k] = v
+	}
+
+	for k := range genesis {
+		delete(genesis, k)
+	}
+
+	for k, v := range values {
+		genesis[k] = v
+	}
+
+	return nil
+}
+
 type Validator struct {
 	Name                    string
 	Moniker                 string
@@ -253,9 +349,7 @@ type Account struct {
 	Coins    string
 }
 
-func createValidatorFromConfig(conf *chainconfig.Config) (validator Validator) {
-	// Currently, we support the config file with one valid validator.
-	validatorFromConfig := conf.Validators[0]
+func createValidatorFromConfig(validatorFromConfig chainconfig.Validator) (validator Validator) {
 	validator.Name = validatorFromConfig.Name
 	validator.StakingAmount = validatorFromConfig.Bonded
 
@@ -0,0 +1,56 @@
+package chain
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	chainconfig "github.com/ignite/cli/ignite/config/chain"
+)
+
+func TestPatchGenesis(t *testing.T) {
+	conf, err := chainconfig.Parse(strings.NewReader(`
+version: 1
+accounts:
+  - name: alice
+    coins: ["100000000stake"]
+validators:
+  - name: alice
+    bonded: 100000000stake
+genesis_patches:
+  staking:
+    unbonding_time: 60s
+  operations:
+    - op: add
+      path: /app_state/bank/send_enabled/-
+      value: {denom: stake, enabled: false}
+`))
+	require.NoError(t, err)
+
+	t.Run("patch genesis", func(t *testing.T) {
+		genesis := decodeGenesis(t, `{"app_state": {
+			"bank": {"send_enabled": []},
+			"staking": {"params": {"unbonding_time": "1814400s"}}
+		}}`)
+
+		err := patchGenesis(genesis, conf)
+
+		require.NoError(t, err)
+		require.Equal(t, decodeGenesis(t, `{"app_state": {
+			"bank": {"send_enabled": [{"denom": "stake", "enabled": false}]},
+			"staking": {"params": {"unbonding_time": "60s"}}
+		}}`), genesis)
+	})
+
+	t.Run("invalid path", func(t *testing.T) {
+		genesis := decodeGenesis(t, `{"app_state": {
+			"staking": {"params": {"unbonding_time": "1814400s"}}
+		}}`)
+
+		err := patchGenesis(genesis, conf)
+
+		require.EqualError(t, err, "cannot apply genesis patch genesis_patches.operations[0] "+
+			"(add /app_state/bank/send_enabled/-): path not found: /app_state/bank")
+	})
+}
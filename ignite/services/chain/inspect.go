@@ -0,0 +1,271 @@
+package chain
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/ignite/cli/ignite/pkg/cache"
+	"github.com/ignite/cli/ignite/pkg/cosmosanalysis/module"
+	"github.com/ignite/cli/ignite/pkg/cosmosstore"
+	"github.com/ignite/cli/ignite/pkg/gomodule"
+	"github.com/ignite/cli/ignite/pkg/protodecode"
+)
+
+const (
+	nodeDataDir = "data"
+	sdkImport   = "github.com/cosmos/cosmos-sdk"
+)
+
+// StateEntry is a key of a module store with its value.
+type StateEntry struct {
+	// Key is the key of the entry in the store.
+	Key []byte `json:"key"`
+
+	// Value is the raw value of the entry.
+	Value []byte `json:"value,omitempty"`
+
+	// Type is the full name of the proto message used to decode the value,
+	// it is empty when the value couldn't be decoded.
+	Type string `json:"type,omitempty"`
+
+	// Decoded is the value decoded with the proto message.
+	Decoded map[string]interface{} `json:"decoded,omitempty"`
+
+	// Candidates are the full names of the proto messages that can decode the
+	// value when there is more than one, so the value is not decoded.
+	Candidates []string `json:"candidates,omitempty"`
+}
+
+// StateChange is a change of a module store key between two heights.
+type StateChange struct {
+	// Key is the changed key.
+	Key []byte `json:"key"`
+
+	// Old is the entry at the first height, it is nil when the key was added.
+	Old *StateEntry `json:"old,omitempty"`
+
+	// New is the entry at the second height, it is nil when the key was removed.
+	New *StateEntry `json:"new,omitempty"`
+}
+
+// StateInspector reads the module stores of the local node while it is stopped.
+type StateInspector struct {
+	db     *cosmosstore.DB
+	schema *protodecode.Schema
+
+	// types are the full names of the proto messages of each proto package.
+	types map[string][]string
+}
+
+// InspectState opens the application database of the local node to inspect the
+// module stores. When decode is true the proto files of the app modules and of the
+// Cosmos SDK modules are discovered to decode the store values.
+// The node must be stopped and the inspector must be closed after use.
+func (c *Chain) InspectState(ctx context.Context, cacheStorage cache.Storage, decode bool) (*StateInspector, error) {
+	home, err := c.Home()
+	if err != nil {
+		return nil, err
+	}
+
+	i := &StateInspector{
+		schema: protodecode.NewSchema(),
+		types:  make(map[string][]string),
+	}
+
+	if decode {
+		modules, err := c.discoverStateModules(ctx, cacheStorage)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, m := range modules {
+			if err := i.addModule(m); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if i.db, err = cosmosstore.Open(filepath.Join(home, nodeDataDir)); err != nil {
+		return nil, err
+	}
+
+	return i, nil
+}
+
+// discoverStateModules discovers the modules of the app and of the Cosmos SDK version it uses.
+func (c *Chain) discoverStateModules(ctx context.Context, cacheStorage cache.Storage) ([]module.Module, error) {
+	conf, err := c.Config()
+	if err != nil {
+		return nil, err
+	}
+
+	modules, err := module.Discover(ctx, c.app.Path, c.app.Path, conf.Build.Proto.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	modFile, err := gomodule.ParseAt(c.app.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	// Use the replacement of the SDK module when there is one
+	sdkPath := sdkImport
+	for _, r := range modFile.Replace {
+		if r.Old.Path == sdkImport {
+			sdkPath = r.New.Path
+			break
+		}
+	}
+
+	deps, err := gomodule.ResolveDependencies(modFile)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, dep := range gomodule.FilterVersions(deps, sdkPath) {
+		path, err := gomodule.LocatePath(ctx, cacheStorage, c.app.Path, dep)
+		if err != nil {
+			return nil, err
+		}
+
+		sdkModules, err := module.Discover(ctx, c.app.Path, path, "")
+		if err != nil {
+			return nil, err
+		}
+
+		modules = append(modules, sdkModules...)
+	}
+
+	return modules, nil
+}
+
+// addModule adds the proto files and messages of a module to the decoding schema.
+func (i *StateInspector) addModule(m module.Module) error {
+	if _, ok := i.types[m.Pkg.Name]; ok {
+		return nil
+	}
+
+	for _, f := range m.Pkg.Files {
+		if err := i.schema.AddFile(f.Path); err != nil {
+			return err
+		}
+	}
+
+	var types []string
+	for _, msg := range m.Pkg.Messages {
+		// Transactions, queries and events are not saved in the stores
+		if strings.HasPrefix(msg.Name, "Msg") ||
+			strings.HasPrefix(msg.Name, "Query") ||
+			strings.HasPrefix(msg.Name, "Event") {
+			continue
+		}
+
+		types = append(types, m.Pkg.Name+"."+msg.Name)
+	}
+
+	i.types[m.Pkg.Name] = types
+
+	return nil
+}
+
+// Close closes the application database.
+func (i *StateInspector) Close() error {
+	return i.db.Close()
+}
+
+// LatestHeight returns the latest height committed by the node.
+func (i *StateInspector) LatestHeight() (int64, error) {
+	return i.db.LatestHeight()
+}
+
+// Stores returns the names of the module stores at a height.
+// The latest height is used when height is zero.
+func (i *StateInspector) Stores(height int64) ([]string, error) {
+	return i.db.Stores(height)
+}
+
+// Entries returns the entries of a module store at a height with a key that starts
+// with prefix. No more than limit entries are returned unless limit is zero.
+// The latest height is used when height is zero.
+func (i *StateInspector) Entries(store string, height int64, prefix []byte, limit int) ([]StateEntry, error) {
+	var entries []StateEntry
+
+	err := i.db.Iterate(store, height, prefix, func(key, value []byte) bool {
+		entries = append(entries, i.entry(store, key, value))
+		return limit == 0 || len(entries) < limit
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return entries, nil
+}
+
+// Diff returns the changes of the keys of a module store that start with prefix
+// between two heights.
+func (i *StateInspector) Diff(store string, from, to int64, prefix []byte) ([]StateChange, error) {
+	changes, err := i.db.Diff(store, from, to, prefix)
+	if err != nil {
+		return nil, err
+	}
+
+	stateChanges := make([]StateChange, len(changes))
+	for n, c := range changes {
+		stateChanges[n].Key = c.Key
+
+		if c.Old != nil {
+			e := i.entry(store, c.Key, c.Old)
+			stateChanges[n].Old = &e
+		}
+
+		if c.New != nil {
+			e := i.entry(store, c.Key, c.New)
+			stateChanges[n].New = &e
+		}
+	}
+
+	return stateChanges, nil
+}
+
+// entry creates a store entry decoding the value with the messages of the
+// modules that match the store.
+func (i *StateInspector) entry(store string, key, value []byte) StateEntry {
+	e := StateEntry{
+		Key:   key,
+		Value: value,
+	}
+
+	var (
+		ambiguousErr protodecode.AmbiguousError
+		err          error
+	)
+	e.Type, e.Decoded, err = i.schema.Guess(value, i.candidates(store)...)
+	if errors.As(err, &ambiguousErr) {
+		e.Candidates = ambiguousErr.Messages
+	}
+
+	return e
+}
+
+// candidates returns the messages of the proto packages that match a store name,
+// like "cosmos.bank.v1beta1" for the "bank" store.
+func (i *StateInspector) candidates(store string) []string {
+	var candidates []string
+	for pkg, types := range i.types {
+		for _, name := range strings.Split(pkg, ".") {
+			if name == store {
+				candidates = append(candidates, types...)
+				break
+			}
+		}
+	}
+
+	// Sort the candidates so values are always decoded with the same message
+	sort.Strings(candidates)
+
+	return candidates
+}
@@ -0,0 +1,77 @@
+package chain
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protowire"
+
+	"github.com/ignite/cli/ignite/pkg/cosmosanalysis/module"
+	"github.com/ignite/cli/ignite/pkg/protoanalysis"
+	"github.com/ignite/cli/ignite/pkg/protodecode"
+)
+
+const marsProto = `syntax = "proto3";
+package mars.mars;
+
+message Planet {
+  string name = 1;
+  uint64 radius = 2;
+}
+
+message Moon {
+  string name = 1;
+}
+
+message MsgCreatePlanet {
+  string creator = 1;
+  string name = 2;
+}
+`
+
+func TestStateInspectorEntry(t *testing.T) {
+	// Arrange
+	protoPath := filepath.Join(t.TempDir(), "planet.proto")
+	require.NoError(t, os.WriteFile(protoPath, []byte(marsProto), 0o644))
+
+	i := &StateInspector{
+		schema: protodecode.NewSchema(),
+		types:  make(map[string][]string),
+	}
+
+	err := i.addModule(module.Module{
+		Name: "mars",
+		Pkg: protoanalysis.Package{
+			Name:     "mars.mars",
+			Files:    protoanalysis.Files{{Path: protoPath}},
+			Messages: []protoanalysis.Message{{Name: "Planet"}, {Name: "Moon"}, {Name: "MsgCreatePlanet"}},
+		},
+	})
+	require.NoError(t, err)
+
+	value := protowire.AppendTag(nil, 1, protowire.BytesType)
+	value = protowire.AppendString(value, "mars")
+	value = protowire.AppendTag(value, 2, protowire.VarintType)
+	value = protowire.AppendVarint(value, 3389)
+
+	moon := protowire.AppendTag(nil, 1, protowire.BytesType)
+	moon = protowire.AppendString(moon, "phobos")
+
+	// Act
+	planet := i.entry("mars", []byte("planet/mars"), value)
+	unknown := i.entry("bank", []byte("balance"), value)
+	ambiguous := i.entry("mars", []byte("moon/phobos"), moon)
+
+	// Assert
+	require.Equal(t, []string{"mars.mars.Moon", "mars.mars.Planet"}, i.candidates("mars"))
+	require.Equal(t, "mars.mars.Planet", planet.Type)
+	require.Equal(t, map[string]interface{}{"name": "mars", "radius": uint64(3389)}, planet.Decoded)
+	require.Empty(t, unknown.Type)
+	require.Nil(t, unknown.Decoded)
+	require.Equal(t, value, unknown.Value)
+	require.Empty(t, ambiguous.Type)
+	require.Nil(t, ambiguous.Decoded)
+	require.Equal(t, []string{"mars.mars.Moon", "mars.mars.Planet"}, ambiguous.Candidates)
+}
@@ -0,0 +1,304 @@
+package chain
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/otiai10/copy"
+	"github.com/pelletier/go-toml"
+
+	chainconfig "github.com/ignite/cli/ignite/config/chain"
+	"github.com/ignite/cli/ignite/config/chain/base"
+	chaincmdrunner "github.com/ignite/cli/ignite/pkg/chaincmd/runner"
+)
+
+// validatorNode holds the information required to run the node of a validator.
+type validatorNode struct {
+	// validator is the validator config.
+	validator chainconfig.Validator
+
+	// home is the data directory of the validator's node.
+	home string
+
+	// commands runs commands on the validator's node.
+	commands chaincmdrunner.Runner
+}
+
+// validatorNodes returns the nodes of the validators defined in the config.
+// The first node is always the node of the first validator, which uses
+// the chain's home and is the one used to share the genesis and
+// to serve the faucet and the public addresses.
+func (c *Chain) validatorNodes(ctx context.Context, cfg *chainconfig.Config) ([]validatorNode, error) {
+	nodes := make([]validatorNode, len(cfg.Validators))
+	for i, validator := range cfg.Validators {
+		home, err := c.validatorHome(cfg, i)
+		if err != nil {
+			return nil, err
+		}
+
+		servers, err := validator.GetServers()
+		if err != nil {
+			return nil, err
+		}
+
+		// Keep the default output prefix for the first validator
+		prefix := c.app.D()
+		if i > 0 {
+			prefix = fmt.Sprintf("%s-%s", prefix, validator.Name)
+		}
+
+		commands, err := c.commands(ctx, home, servers, prefix)
+		if err != nil {
+			return nil, err
+		}
+
+		nodes[i] = validatorNode{
+			validator: validator,
+			home:      home,
+			commands:  commands,
+		}
+	}
+
+	return nodes, nil
+}
+
+// validatorHome returns the home directory of the validator with the given index.
+// The first validator uses the chain's home while the other validators use their
+// configured home or a directory named after them next to the chain's home.
+func (c *Chain) validatorHome(cfg *chainconfig.Config, index int) (string, error) {
+	home, err := c.Home()
+	if err != nil {
+		return "", err
+	}
+
+	if index == 0 {
+		return home, nil
+	}
+
+	validator := cfg.Validators[index]
+	if validator.Home != "" {
+		return os.ExpandEnv(validator.Home), nil
+	}
+
+	return fmt.Sprintf("%s-%s", home, validator.Name), nil
+}
+
+// initValidatorNodes initializes the data directories of the nodes of all
+// the validators but the first one, which is initialized as the chain's node.
+func (c *Chain) initValidatorNodes(ctx context.Context, cfg *chainconfig.Config, initConfiguration bool) error {
+	nodes, err := c.validatorNodes(ctx, cfg)
+	if err != nil {
+		return err
+	}
+
+	for i, node := range nodes {
+		if i == 0 {
+			continue
+		}
+
+		// cleanup persistent data from previous `serve`.
+		if err := os.RemoveAll(node.home); err != nil {
+			return err
+		}
+
+		if err := node.commands.Init(ctx, validatorMoniker(node.validator)); err != nil {
+			return err
+		}
+
+		if initConfiguration {
+			if err := c.configureValidator(node.home, node.validator); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// issueValidatorsGentxs generates the gentxs for all the validators defined in the
+// config and collects them into the genesis that is shared between all the nodes.
+// Validators other than the first one require their account to be defined in the
+// config so their keys can be recovered in the keyring of their own node.
+func (c *Chain) issueValidatorsGentxs(ctx context.Context, cfg *chainconfig.Config, keys map[string]base.Account) error {
+	nodes, err := c.validatorNodes(ctx, cfg)
+	if err != nil {
+		return err
+	}
+
+	genesisPath, err := c.GenesisPath()
+	if err != nil {
+		return err
+	}
+
+	gentxsPath, err := c.GentxsPath()
+	if err != nil {
+		return err
+	}
+
+	for i, node := range nodes {
+		// The gentx of the first validator is created in the chain's home
+		if i == 0 {
+			if _, err := c.Gentx(ctx, node.commands, createValidatorFromConfig(node.validator)); err != nil {
+				return err
+			}
+
+			continue
+		}
+
+		key, ok := keys[node.validator.Name]
+		if !ok {
+			return &chainconfig.ValidationError{Message: fmt.Sprintf(
+				"validator '%s' requires an account with the same name and without address", node.validator.Name,
+			)}
+		}
+
+		// The node needs the genesis accounts to be able to generate the gentx
+		if err := copy.Copy(genesisPath, nodeGenesisPath(node.home)); err != nil {
+			return err
+		}
+
+		if _, err := node.commands.AddAccount(ctx, key.Name, key.Mnemonic, key.CoinType); err != nil {
+			return err
+		}
+
+		gentxPath, err := c.Gentx(ctx, node.commands, createValidatorFromConfig(node.validator))
+		if err != nil {
+			return err
+		}
+
+		if err := copy.Copy(gentxPath, filepath.Join(gentxsPath, filepath.Base(gentxPath))); err != nil {
+			return err
+		}
+	}
+
+	if err := nodes[0].commands.CollectGentxs(ctx); err != nil {
+		return err
+	}
+
+	// Share the final genesis with the rest of the nodes
+	for _, node := range nodes[1:] {
+		if err := copy.Copy(genesisPath, nodeGenesisPath(node.home)); err != nil {
+			return err
+		}
+	}
+
+	return c.connectValidatorNodes(ctx, nodes)
+}
+
+// connectValidatorNodes configures each validator node to use
+// the rest of the nodes as persistent peers.
+func (c *Chain) connectValidatorNodes(ctx context.Context, nodes []validatorNode) error {
+	peers := make([]string, len(nodes))
+	for i, node := range nodes {
+		nodeID, err := node.commands.ShowNodeID(ctx)
+		if err != nil {
+			return err
+		}
+
+		servers, err := node.validator.GetServers()
+		if err != nil {
+			return err
+		}
+
+		addr, err := localPeerAddress(servers.P2P.Address)
+		if err != nil {
+			return err
+		}
+
+		peers[i] = fmt.Sprintf("%s@%s", nodeID, addr)
+	}
+
+	for i, node := range nodes {
+		var nodePeers []string
+		for j, peer := range peers {
+			if i != j {
+				nodePeers = append(nodePeers, peer)
+			}
+		}
+
+		path := filepath.Join(node.home, "config/config.toml")
+		tmConfig, err := toml.LoadFile(path)
+		if err != nil {
+			return err
+		}
+
+		// All nodes run in the same host
+		tmConfig.Set("p2p.persistent_peers", strings.Join(nodePeers, ","))
+		tmConfig.Set("p2p.allow_duplicate_ip", true)
+		tmConfig.Set("p2p.addr_book_strict", false)
+
+		file, err := os.OpenFile(path, os.O_RDWR|os.O_TRUNC, 0o644)
+		if err != nil {
+			return err
+		}
+
+		_, err = tmConfig.WriteTo(file)
+		file.Close()
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// resetValidatorNodes resets the database of the validator nodes
+// and restores the state from the exported genesis.
+func (c *Chain) resetValidatorNodes(ctx context.Context, cfg *chainconfig.Config) error {
+	nodes, err := c.validatorNodes(ctx, cfg)
+	if err != nil {
+		return err
+	}
+
+	exportGenesisPath, err := c.exportedGenesisPath()
+	if err != nil {
+		return err
+	}
+
+	for _, node := range nodes {
+		if err := node.commands.UnsafeReset(ctx); err != nil {
+			return err
+		}
+
+		if err := copy.Copy(exportGenesisPath, nodeGenesisPath(node.home)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func nodeGenesisPath(home string) string {
+	return filepath.Join(home, "config/genesis.json")
+}
+
+// localPeerAddress returns the address to reach a local node from its P2P listen address.
+func localPeerAddress(laddr string) (string, error) {
+	// Remove the protocol prefix when present
+	if i := strings.Index(laddr, "://"); i != -1 {
+		laddr = laddr[i+3:]
+	}
+
+	host, port, err := net.SplitHostPort(laddr)
+	if err != nil {
+		return "", fmt.Errorf("invalid p2p address format %s: %w", laddr, err)
+	}
+
+	if host == "" || host == "0.0.0.0" {
+		host = "127.0.0.1"
+	}
+
+	return net.JoinHostPort(host, port), nil
+}
+
+func validatorMoniker(validator chainconfig.Validator) string {
+	if validator.Gentx != nil && validator.Gentx.Moniker != "" {
+		return validator.Gentx.Moniker
+	}
+
+	return validator.Name
+}
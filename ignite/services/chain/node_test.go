@@ -0,0 +1,63 @@
+package chain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	chainconfig "github.com/ignite/cli/ignite/config/chain"
+)
+
+func TestValidatorHome(t *testing.T) {
+	c := &Chain{options: chainOptions{homePath: "/tmp/.mars"}}
+	cfg := &chainconfig.Config{
+		Validators: []chainconfig.Validator{
+			{Name: "alice"},
+			{Name: "bob"},
+			{Name: "carol", Home: "/tmp/carol"},
+		},
+	}
+
+	tests := []struct {
+		index int
+		want  string
+	}{
+		{index: 0, want: "/tmp/.mars"},
+		{index: 1, want: "/tmp/.mars-bob"},
+		{index: 2, want: "/tmp/carol"},
+	}
+
+	for _, tt := range tests {
+		home, err := c.validatorHome(cfg, tt.index)
+		require.NoError(t, err)
+		require.Equal(t, tt.want, home)
+	}
+}
+
+func TestLocalPeerAddress(t *testing.T) {
+	tests := []struct {
+		name    string
+		laddr   string
+		want    string
+		wantErr bool
+	}{
+		{name: "any address", laddr: "0.0.0.0:26656", want: "127.0.0.1:26656"},
+		{name: "with protocol", laddr: "tcp://0.0.0.0:26666", want: "127.0.0.1:26666"},
+		{name: "custom host", laddr: "192.168.1.10:26656", want: "192.168.1.10:26656"},
+		{name: "empty host", laddr: ":26656", want: "127.0.0.1:26656"},
+		{name: "invalid", laddr: "26656", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			addr, err := localPeerAddress(tt.laddr)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tt.want, addr)
+		})
+	}
+}
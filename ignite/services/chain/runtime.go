@@ -43,33 +43,51 @@ func (c Chain) Start(ctx context.Context, runner chaincmdrunner.Runner, cfg *cha
 		return err
 	}
 
+	return c.startValidator(ctx, runner, cfg, validator)
+}
+
+// startValidator starts the node of a validator using the runner of its home.
+// The start flags and environment variables defined in the config are used
+// to start the node.
+func (c Chain) startValidator(
+	ctx context.Context,
+	runner chaincmdrunner.Runner,
+	cfg *chainconfig.Config,
+	validator chainconfig.Validator,
+) error {
 	servers, err := validator.GetServers()
 	if err != nil {
 		return err
 	}
 
-	err = runner.Start(ctx, "--pruning", "nothing", "--grpc.address", servers.GRPC.Address)
+	args := append([]string{"--pruning", "nothing", "--grpc.address", servers.GRPC.Address}, cfg.Start.Flags...)
+	err = runner.StartWithEnv(ctx, chainconfig.StartEnv(cfg), args...)
 
 	return &CannotStartAppError{runner.Cmd().Name(), err}
 }
 
 // Configure sets the runtime configurations files for a chain (app.toml, client.toml, config.toml).
 func (c Chain) Configure(homePath string, cfg *chainconfig.Config) error {
-	if err := c.appTOML(homePath, cfg); err != nil {
-		return err
-	}
-	if err := c.clientTOML(homePath, cfg); err != nil {
+	validator, err := chainconfig.FirstValidator(cfg)
+	if err != nil {
 		return err
 	}
-	return c.configTOML(homePath, cfg)
+
+	return c.configureValidator(homePath, validator)
 }
 
-func (c Chain) appTOML(homePath string, cfg *chainconfig.Config) error {
-	validator, err := chainconfig.FirstValidator(cfg)
-	if err != nil {
+// configureValidator sets the runtime configurations files for the node of a validator.
+func (c Chain) configureValidator(homePath string, validator chainconfig.Validator) error {
+	if err := c.appTOML(homePath, validator); err != nil {
+		return err
+	}
+	if err := c.clientTOML(homePath, validator); err != nil {
 		return err
 	}
+	return c.configTOML(homePath, validator)
+}
 
+func (c Chain) appTOML(homePath string, validator chainconfig.Validator) error {
 	// TODO find a better way in order to not delete comments in the toml.yml
 	path := filepath.Join(homePath, "config/app.toml")
 	appConfig, err := toml.LoadFile(path)
@@ -115,12 +133,7 @@ func (c Chain) appTOML(homePath string, cfg *chainconfig.Config) error {
 	return err
 }
 
-func (c Chain) configTOML(homePath string, cfg *chainconfig.Config) error {
-	validator, err := chainconfig.FirstValidator(cfg)
-	if err != nil {
-		return err
-	}
-
+func (c Chain) configTOML(homePath string, validator chainconfig.Validator) error {
 	// TODO find a better way in order to not delete comments in the toml.yml
 	path := filepath.Join(homePath, "config/config.toml")
 	tmConfig, err := toml.LoadFile(path)
@@ -166,12 +179,7 @@ func (c Chain) configTOML(homePath string, cfg *chainconfig.Config) error {
 	return err
 }
 
-func (c Chain) clientTOML(homePath string, cfg *chainconfig.Config) error {
-	validator, err := chainconfig.FirstValidator(cfg)
-	if err != nil {
-		return err
-	}
-
+func (c Chain) clientTOML(homePath string, validator chainconfig.Validator) error {
 	path := filepath.Join(homePath, "config/client.toml")
 	tmConfig, err := toml.LoadFile(path)
 	if os.IsNotExist(err) {
@@ -10,7 +10,6 @@ import (
 	"regexp"
 	"strings"
 
-	"github.com/otiai10/copy"
 	"github.com/pkg/errors"
 	"golang.org/x/sync/errgroup"
 
@@ -70,6 +69,10 @@ type serveOptions struct {
 	quitOnFail      bool
 	generateClients bool
 	buildTags       []string
+	upgradeFrom     string
+	upgradeName     string
+	test            bool
+	testSimBlocks   int
 }
 
 func newServeOption() serveOptions {
@@ -124,6 +127,26 @@ func BuildTags(buildTags ...string) ServeOption {
 	}
 }
 
+// ServeUpgrade rehearses a software upgrade named upgradeName. The chain is
+// started with the app built from the fromRef git ref and upgraded to the app
+// built from the working tree.
+func ServeUpgrade(fromRef, upgradeName string) ServeOption {
+	return func(c *serveOptions) {
+		c.upgradeFrom = fromRef
+		c.upgradeName = upgradeName
+	}
+}
+
+// ServeTest runs the tests of the packages affected by the source changes after
+// each rebuild. The simulation tests of the app are also run with simBlocks blocks
+// when it is greater than zero.
+func ServeTest(simBlocks int) ServeOption {
+	return func(c *serveOptions) {
+		c.test = true
+		c.testSimBlocks = simBlocks
+	}
+}
+
 // Serve serves an app.
 func (c *Chain) Serve(ctx context.Context, cacheStorage cache.Storage, options ...ServeOption) error {
 	serveOptions := newServeOption()
@@ -147,6 +170,15 @@ func (c *Chain) Serve(ctx context.Context, cacheStorage cache.Storage, options .
 		return err
 	}
 
+	if serveOptions.upgradeFrom != "" {
+		return c.serveUpgrade(ctx, cacheStorage, serveOptions)
+	}
+
+	var tester *serveTester
+	if serveOptions.test {
+		tester = newServeTester(c, serveOptions.testSimBlocks)
+	}
+
 	// start serving components.
 	g, ctx := errgroup.WithContext(ctx)
 
@@ -189,6 +221,7 @@ func (c *Chain) Serve(ctx context.Context, cacheStorage cache.Storage, options .
 					shouldReset,
 					serveOptions.skipProto,
 					serveOptions.generateClients,
+					tester,
 				)
 				serveOptions.resetOnce = false
 
@@ -318,6 +351,7 @@ func (c *Chain) serve(
 	cacheStorage cache.Storage,
 	buildTags []string,
 	forceReset, skipProto, generateClients bool,
+	tester *serveTester,
 ) error {
 	conf, err := c.Config()
 	if err != nil {
@@ -402,6 +436,11 @@ func (c *Chain) serve(
 		if err := c.build(ctx, cacheStorage, buildTags, "", skipProto, generateClients, true); err != nil {
 			return err
 		}
+
+		// test the changes while the app starts
+		if tester != nil {
+			go tester.run(ctx)
+		}
 	}
 
 	// init phase
@@ -419,11 +458,7 @@ func (c *Chain) serve(
 		// we reset the chain database and import the genesis state
 		c.ev.Send("Existent genesis detected, restoring the database...", events.ProgressUpdate())
 
-		if err := commands.UnsafeReset(ctx); err != nil {
-			return err
-		}
-
-		if err := c.importChainState(); err != nil {
+		if err := c.resetValidatorNodes(ctx, conf); err != nil {
 			return err
 		}
 	} else {
@@ -466,15 +501,34 @@ func (c *Chain) serve(
 }
 
 func (c *Chain) start(ctx context.Context, cfg *chainconfig.Config) error {
-	commands, err := c.Commands(ctx)
+	nodes, err := c.validatorNodes(ctx, cfg)
 	if err != nil {
 		return err
 	}
 
+	if err := c.runHooks(ctx, cfg, chainconfig.HookPreStart); err != nil {
+		return err
+	}
+
 	g, ctx := errgroup.WithContext(ctx)
 
-	// start the blockchain.
-	g.Go(func() error { return c.Start(ctx, commands, cfg) })
+	// start the blockchain nodes, one for each validator.
+	for _, node := range nodes {
+		node := node
+		g.Go(func() error { return c.startValidator(ctx, node.commands, cfg, node.validator) })
+	}
+
+	// run the post start hooks once the chain produces blocks.
+	// A failing hook is reported but it doesn't stop the chain.
+	if len(cfg.Hooks.PostStart) > 0 {
+		g.Go(func() error {
+			if err := c.runPostStartHooks(ctx, cfg); err != nil && ctx.Err() == nil {
+				c.ev.SendError(err)
+			}
+
+			return nil
+		})
+	}
 
 	// start the faucet if enabled.
 	faucet, err := c.Faucet(ctx)
@@ -525,6 +579,21 @@ func (c *Chain) start(ctx context.Context, cfg *chainconfig.Config) error {
 		events.Icon(icons.Earth),
 	)
 
+	// inform about the nodes of the rest of the validators
+	for _, node := range nodes[1:] {
+		servers, err := node.validator.GetServers()
+		if err != nil {
+			return err
+		}
+
+		rpcAddr, _ := xurl.HTTP(servers.RPC.Address)
+
+		c.ev.Send(
+			fmt.Sprintf("Tendermint node (%s): %s", node.validator.Name, rpcAddr),
+			events.Icon(icons.Earth),
+		)
+	}
+
 	if isFaucetEnabled {
 		faucetAddr, _ := xurl.HTTP(chainconfig.FaucetHost(cfg))
 
@@ -542,6 +611,13 @@ func (c *Chain) start(ctx context.Context, cfg *chainconfig.Config) error {
 		events.Icon(icons.Bullet),
 		events.Group(EvtGroupPath),
 	)
+	for _, node := range nodes[1:] {
+		c.ev.Send(
+			fmt.Sprintf("Data directory (%s): %s", node.validator.Name, colors.Faint(node.home)),
+			events.Icon(icons.Bullet),
+			events.Group(EvtGroupPath),
+		)
+	}
 	c.ev.Send(
 		fmt.Sprintf("App binary: %s", colors.Faint(appBin)),
 		events.Icon(icons.Bullet),
@@ -573,20 +649,6 @@ func (c *Chain) saveChainState(ctx context.Context, commands chaincmdrunner.Runn
 	return commands.Export(ctx, genesisPath)
 }
 
-// importChainState imports the saved genesis in chain config to use it as the genesis.
-func (c *Chain) importChainState() error {
-	exportGenesisPath, err := c.exportedGenesisPath()
-	if err != nil {
-		return err
-	}
-	genesisPath, err := c.GenesisPath()
-	if err != nil {
-		return err
-	}
-
-	return copy.Copy(exportGenesisPath, genesisPath)
-}
-
 // chainSavePath returns the path where the chain state is saved.
 // Creates the path if it doesn't exist.
 func (c *Chain) chainSavePath() (string, error) {
@@ -0,0 +1,304 @@
+package chain
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/otiai10/copy"
+	"github.com/pkg/errors"
+
+	"github.com/ignite/cli/ignite/pkg/events"
+)
+
+const (
+	// snapshotsDir is the name of the directory where the chain snapshots are saved.
+	snapshotsDir = "snapshots"
+
+	// snapshotGenesis is the name of the exported genesis file of a snapshot.
+	snapshotGenesis = "genesis.json"
+
+	// snapshotNodesDir is the name of the directory of a snapshot that keeps
+	// a copy of the data directory of each validator node.
+	snapshotNodesDir = "nodes"
+)
+
+// ErrSnapshotNotFound is returned when a snapshot with a given name doesn't exist.
+var ErrSnapshotNotFound = errors.New("snapshot not found")
+
+var snapshotNameRe = regexp.MustCompile(`^[a-zA-Z0-9][a-zA-Z0-9_.-]*$`)
+
+// Snapshot is a named copy of the state of a chain.
+type Snapshot struct {
+	// Name of the snapshot.
+	Name string
+
+	// CreatedAt is the time when the snapshot was saved.
+	CreatedAt time.Time
+
+	// Path is the directory where the snapshot is saved.
+	Path string
+}
+
+// SaveSnapshot exports the genesis of the chain and copies the data directory
+// of each validator node into a new snapshot with the given name.
+// The chain must not be running while the snapshot is saved.
+func (c *Chain) SaveSnapshot(ctx context.Context, name string) error {
+	store, err := c.snapshotStore()
+	if err != nil {
+		return err
+	}
+
+	cfg, err := c.Config()
+	if err != nil {
+		return err
+	}
+
+	nodes, err := c.validatorNodes(ctx, cfg)
+	if err != nil {
+		return err
+	}
+
+	c.ev.Send(fmt.Sprintf("Saving snapshot %s...", name), events.ProgressStart())
+
+	export := func(path string) error {
+		return nodes[0].commands.Export(ctx, path)
+	}
+	if err := store.save(name, export, nodeHomes(nodes)); err != nil {
+		return err
+	}
+
+	c.ev.Send(fmt.Sprintf("Snapshot %s saved", name), events.ProgressFinish())
+
+	return nil
+}
+
+// Snapshots returns the snapshots saved for the chain, the oldest first.
+func (c *Chain) Snapshots() ([]Snapshot, error) {
+	store, err := c.snapshotStore()
+	if err != nil {
+		return nil, err
+	}
+
+	return store.list()
+}
+
+// RestoreSnapshot replaces the data directory of each validator node with
+// the copy kept in the snapshot with the given name.
+// The exported genesis of the snapshot also replaces the genesis saved by
+// serve, so the restored state is imported when the app is rebuilt.
+func (c *Chain) RestoreSnapshot(ctx context.Context, name string) error {
+	store, err := c.snapshotStore()
+	if err != nil {
+		return err
+	}
+
+	cfg, err := c.Config()
+	if err != nil {
+		return err
+	}
+
+	nodes, err := c.validatorNodes(ctx, cfg)
+	if err != nil {
+		return err
+	}
+
+	genesisPath, err := c.exportedGenesisPath()
+	if err != nil {
+		return err
+	}
+
+	c.ev.Send(fmt.Sprintf("Restoring snapshot %s...", name), events.ProgressStart())
+
+	if err := store.restore(name, nodeHomes(nodes), genesisPath); err != nil {
+		return err
+	}
+
+	c.ev.Send(fmt.Sprintf("Snapshot %s restored", name), events.ProgressFinish())
+
+	return nil
+}
+
+// DeleteSnapshot deletes the snapshot with the given name.
+func (c *Chain) DeleteSnapshot(name string) error {
+	store, err := c.snapshotStore()
+	if err != nil {
+		return err
+	}
+
+	return store.delete(name)
+}
+
+func (c *Chain) snapshotStore() (snapshotStore, error) {
+	savePath, err := c.chainSavePath()
+	if err != nil {
+		return "", err
+	}
+
+	return snapshotStore(filepath.Join(savePath, snapshotsDir)), nil
+}
+
+// nodeHomes returns the data directory of the nodes by validator name.
+func nodeHomes(nodes []validatorNode) map[string]string {
+	homes := make(map[string]string, len(nodes))
+	for _, node := range nodes {
+		homes[node.validator.Name] = node.home
+	}
+
+	return homes
+}
+
+// snapshotStore is the directory where the snapshots of a chain are saved.
+// Each snapshot is a directory that contains the exported genesis and a copy
+// of the data directory of each validator node, named after the validator.
+type snapshotStore string
+
+func (s snapshotStore) path(name string) (string, error) {
+	if !snapshotNameRe.MatchString(name) {
+		return "", fmt.Errorf(
+			"invalid snapshot name %q: only letters, digits, '_', '.' and '-' are allowed",
+			name,
+		)
+	}
+
+	return filepath.Join(string(s), name), nil
+}
+
+// lookup returns the path of an existing snapshot.
+func (s snapshotStore) lookup(name string) (string, error) {
+	path, err := s.path(name)
+	if err != nil {
+		return "", err
+	}
+
+	if _, err := os.Stat(path); err != nil {
+		if os.IsNotExist(err) {
+			return "", fmt.Errorf("%w: %s", ErrSnapshotNotFound, name)
+		}
+
+		return "", err
+	}
+
+	return path, nil
+}
+
+// save creates a snapshot calling export to write the genesis and copying the
+// node homes. The snapshot is written to a temporary directory first so a
+// failure doesn't leave a partial snapshot behind.
+func (s snapshotStore) save(name string, export func(path string) error, homes map[string]string) error {
+	path, err := s.path(name)
+	if err != nil {
+		return err
+	}
+
+	if _, err := os.Stat(path); err == nil {
+		return fmt.Errorf("snapshot %q already exists", name)
+	} else if !os.IsNotExist(err) {
+		return err
+	}
+
+	if err := os.MkdirAll(string(s), 0o700); err != nil {
+		return err
+	}
+
+	tmpPath, err := os.MkdirTemp(string(s), "."+name+"-")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(tmpPath)
+
+	if err := export(filepath.Join(tmpPath, snapshotGenesis)); err != nil {
+		return errors.Wrap(err, "cannot export the chain state")
+	}
+
+	for validator, home := range homes {
+		if err := copy.Copy(home, filepath.Join(tmpPath, snapshotNodesDir, validator)); err != nil {
+			return err
+		}
+	}
+
+	return os.Rename(tmpPath, path)
+}
+
+func (s snapshotStore) list() ([]Snapshot, error) {
+	entries, err := os.ReadDir(string(s))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	var snapshots []Snapshot
+	for _, e := range entries {
+		// Skip the snapshots that are being saved
+		if !e.IsDir() || strings.HasPrefix(e.Name(), ".") {
+			continue
+		}
+
+		path := filepath.Join(string(s), e.Name())
+		info, err := os.Stat(filepath.Join(path, snapshotGenesis))
+		if err != nil {
+			return nil, err
+		}
+
+		snapshots = append(snapshots, Snapshot{
+			Name:      e.Name(),
+			CreatedAt: info.ModTime(),
+			Path:      path,
+		})
+	}
+
+	sort.SliceStable(snapshots, func(i, j int) bool {
+		return snapshots[i].CreatedAt.Before(snapshots[j].CreatedAt)
+	})
+
+	return snapshots, nil
+}
+
+// restore replaces the node homes with the copies kept in the snapshot and
+// copies the exported genesis of the snapshot to genesisPath.
+func (s snapshotStore) restore(name string, homes map[string]string, genesisPath string) error {
+	path, err := s.lookup(name)
+	if err != nil {
+		return err
+	}
+
+	// Check that the snapshot contains all the nodes before removing any data
+	for validator := range homes {
+		if _, err := os.Stat(filepath.Join(path, snapshotNodesDir, validator)); err != nil {
+			if os.IsNotExist(err) {
+				return fmt.Errorf("snapshot %q doesn't contain the node of validator %s", name, validator)
+			}
+
+			return err
+		}
+	}
+
+	for validator, home := range homes {
+		if err := os.RemoveAll(home); err != nil {
+			return err
+		}
+
+		if err := copy.Copy(filepath.Join(path, snapshotNodesDir, validator), home); err != nil {
+			return err
+		}
+	}
+
+	return copy.Copy(filepath.Join(path, snapshotGenesis), genesisPath)
+}
+
+func (s snapshotStore) delete(name string) error {
+	path, err := s.lookup(name)
+	if err != nil {
+		return err
+	}
+
+	return os.RemoveAll(path)
+}
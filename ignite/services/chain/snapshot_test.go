@@ -0,0 +1,128 @@
+package chain
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func writeNodeHome(t *testing.T, home, content string) {
+	t.Helper()
+
+	require.NoError(t, os.MkdirAll(filepath.Join(home, "data"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(home, "data", "state.db"), []byte(content), 0o644))
+}
+
+func readNodeHome(t *testing.T, home string) string {
+	t.Helper()
+
+	content, err := os.ReadFile(filepath.Join(home, "data", "state.db"))
+	require.NoError(t, err)
+	return string(content)
+}
+
+func TestSnapshotStore(t *testing.T) {
+	// Arrange
+	var (
+		dir   = t.TempDir()
+		store = snapshotStore(filepath.Join(dir, "snapshots"))
+		homes = map[string]string{
+			"alice": filepath.Join(dir, ".mars"),
+			"bob":   filepath.Join(dir, ".mars-bob"),
+		}
+		genesisPath = filepath.Join(dir, "exported_genesis.json")
+		export      = func(path string) error {
+			return os.WriteFile(path, []byte("genesis"), 0o644)
+		}
+	)
+	writeNodeHome(t, homes["alice"], "alice-1")
+	writeNodeHome(t, homes["bob"], "bob-1")
+
+	// Act
+	err := store.save("setup", export, homes)
+
+	// Assert
+	require.NoError(t, err)
+	snapshots, err := store.list()
+	require.NoError(t, err)
+	require.Len(t, snapshots, 1)
+	require.Equal(t, "setup", snapshots[0].Name)
+	require.Equal(t, filepath.Join(string(store), "setup"), snapshots[0].Path)
+
+	// Arrange: change the state of the nodes
+	writeNodeHome(t, homes["alice"], "alice-2")
+	require.NoError(t, os.RemoveAll(homes["bob"]))
+
+	// Act
+	err = store.restore("setup", homes, genesisPath)
+
+	// Assert
+	require.NoError(t, err)
+	require.Equal(t, "alice-1", readNodeHome(t, homes["alice"]))
+	require.Equal(t, "bob-1", readNodeHome(t, homes["bob"]))
+	genesis, err := os.ReadFile(genesisPath)
+	require.NoError(t, err)
+	require.Equal(t, "genesis", string(genesis))
+
+	// Act
+	err = store.delete("setup")
+
+	// Assert
+	require.NoError(t, err)
+	snapshots, err = store.list()
+	require.NoError(t, err)
+	require.Empty(t, snapshots)
+}
+
+func TestSnapshotStoreErrors(t *testing.T) {
+	dir := t.TempDir()
+	store := snapshotStore(dir)
+	homes := map[string]string{"alice": filepath.Join(dir, ".mars")}
+	writeNodeHome(t, homes["alice"], "alice")
+	export := func(path string) error {
+		return os.WriteFile(path, []byte("genesis"), 0o644)
+	}
+	require.NoError(t, store.save("setup", export, homes))
+
+	t.Run("invalid name", func(t *testing.T) {
+		err := store.save("../setup", export, homes)
+		require.EqualError(t, err, `invalid snapshot name "../setup": only letters, digits, '_', '.' and '-' are allowed`)
+	})
+
+	t.Run("already exists", func(t *testing.T) {
+		err := store.save("setup", export, homes)
+		require.EqualError(t, err, `snapshot "setup" already exists`)
+	})
+
+	t.Run("export fails", func(t *testing.T) {
+		err := store.save("broken", func(string) error { return errors.New("oops") }, homes)
+		require.EqualError(t, err, "cannot export the chain state: oops")
+
+		// The partial snapshot is removed
+		snapshots, err := store.list()
+		require.NoError(t, err)
+		require.Len(t, snapshots, 1)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		err := store.restore("missing", homes, filepath.Join(dir, "genesis.json"))
+		require.ErrorIs(t, err, ErrSnapshotNotFound)
+
+		err = store.delete("missing")
+		require.ErrorIs(t, err, ErrSnapshotNotFound)
+	})
+
+	t.Run("missing validator node", func(t *testing.T) {
+		bobHome := filepath.Join(dir, ".mars-bob")
+		writeNodeHome(t, bobHome, "bob")
+
+		err := store.restore("setup", map[string]string{"alice": homes["alice"], "bob": bobHome}, filepath.Join(dir, "genesis.json"))
+
+		require.EqualError(t, err, `snapshot "setup" doesn't contain the node of validator bob`)
+		// The data of the nodes is not modified
+		require.Equal(t, "bob", readNodeHome(t, bobHome))
+	})
+}
@@ -0,0 +1,175 @@
+package chain
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/pkg/errors"
+
+	"github.com/ignite/cli/ignite/pkg/cliui/colors"
+	"github.com/ignite/cli/ignite/pkg/cliui/icons"
+	"github.com/ignite/cli/ignite/pkg/events"
+	"github.com/ignite/cli/ignite/pkg/gocmd"
+	"github.com/ignite/cli/ignite/pkg/gotest"
+)
+
+const (
+	// simulationTestFile is the file of the simulation tests scaffolded in the app package.
+	simulationTestFile = "app/simulation_test.go"
+
+	// simulationTestRun selects the simulation tests of the app package.
+	simulationTestRun = "^TestApp"
+
+	// simulationBlockSize is the number of operations of each simulated block.
+	simulationBlockSize = 10
+
+	// maxTestOutputLines is the maximum number of output lines displayed for a failed package.
+	maxTestOutputLines = 20
+)
+
+// serveTester runs the tests of the packages affected by the source changes
+// after the app is rebuilt by serve.
+type serveTester struct {
+	chain     *Chain
+	simBlocks int
+
+	// mu makes sure a single test run updates the checksums at a time.
+	mu sync.Mutex
+
+	// checksums are the checksums of the packages that were tested by import path.
+	checksums map[string]string
+}
+
+func newServeTester(c *Chain, simBlocks int) *serveTester {
+	return &serveTester{
+		chain:     c,
+		simBlocks: simBlocks,
+		checksums: make(map[string]string),
+	}
+}
+
+// run runs the tests of the packages that changed since the last run and of the
+// packages affected by them. All the packages are tested on the first run.
+// The simulation tests are also run when the number of simulation blocks is set.
+// The results are sent as test events until the context is canceled.
+func (t *serveTester) run(ctx context.Context) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	err := t.test(ctx)
+	if err != nil && ctx.Err() == nil {
+		t.send(fmt.Sprintf("Cannot run the tests: %s", err), icons.NotOK)
+	}
+}
+
+func (t *serveTester) test(ctx context.Context) error {
+	appPath := t.chain.app.Path
+
+	pkgs, err := gotest.List(ctx, appPath)
+	if err != nil {
+		return err
+	}
+
+	// Find the packages that changed since the last run
+	var changed []string
+	checksums := make(map[string]string, len(pkgs))
+	for _, p := range pkgs {
+		sum, err := p.Checksum()
+		if err != nil {
+			return err
+		}
+
+		checksums[p.ImportPath] = sum
+		if t.checksums[p.ImportPath] != sum {
+			changed = append(changed, p.ImportPath)
+		}
+	}
+
+	affected := gotest.Affected(pkgs, changed...)
+	if len(affected) == 0 {
+		t.send("No tests affected by the changes", icons.Info)
+	} else {
+		t.send(fmt.Sprintf("Testing %d affected packages...", len(affected)), icons.Bullet)
+
+		results, err := gotest.Run(ctx, appPath, affected, nil)
+		if err != nil {
+			return err
+		}
+
+		t.sendResults(results)
+	}
+
+	if err := t.simulate(ctx); err != nil {
+		return err
+	}
+
+	// Don't keep the checksums of runs that were canceled by new changes
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	t.checksums = checksums
+
+	return nil
+}
+
+// simulate runs the simulation tests of the app with a small number of blocks.
+func (t *serveTester) simulate(ctx context.Context) error {
+	if t.simBlocks <= 0 {
+		return nil
+	}
+
+	appPath := t.chain.app.Path
+	if _, err := os.Stat(filepath.Join(appPath, simulationTestFile)); os.IsNotExist(err) {
+		t.send("No simulation tests found in the app", icons.Info)
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	t.send(fmt.Sprintf("Running the simulation tests with %d blocks...", t.simBlocks), icons.Bullet)
+
+	results, err := gotest.Run(ctx, appPath, []string{"./" + filepath.Dir(simulationTestFile)}, []string{
+		gocmd.FlagRun, simulationTestRun,
+		"-Enabled=true",
+		"-Commit=true",
+		fmt.Sprintf("-NumBlocks=%d", t.simBlocks),
+		fmt.Sprintf("-BlockSize=%d", simulationBlockSize),
+	})
+	if err != nil {
+		return errors.Wrap(err, "simulation")
+	}
+
+	t.sendResults(results)
+
+	return nil
+}
+
+func (t *serveTester) sendResults(results []gotest.Result) {
+	for _, r := range results {
+		if r.Passed {
+			t.send(fmt.Sprintf("%s %s", r.Package, colors.Faint(r.Elapsed.String())), icons.OK)
+			continue
+		}
+
+		output := r.Output
+		if len(output) > maxTestOutputLines {
+			output = output[len(output)-maxTestOutputLines:]
+		}
+
+		msg := colors.Error(r.Package)
+		if len(output) > 0 {
+			msg += "\n" + colors.Faint(strings.Join(output, "\n"))
+		}
+
+		t.send(msg, icons.NotOK)
+	}
+}
+
+func (t *serveTester) send(msg, icon string) {
+	t.chain.ev.Send(msg, events.Icon(icon), events.Group(events.GroupTest))
+}
@@ -0,0 +1,588 @@
+package chain
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/bech32"
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+	govtypes "github.com/cosmos/cosmos-sdk/x/gov/types"
+	govv1 "github.com/cosmos/cosmos-sdk/x/gov/types/v1"
+	upgradetypes "github.com/cosmos/cosmos-sdk/x/upgrade/types"
+	"github.com/pkg/errors"
+
+	chainconfig "github.com/ignite/cli/ignite/config/chain"
+	"github.com/ignite/cli/ignite/pkg/cache"
+	"github.com/ignite/cli/ignite/pkg/cliui/icons"
+	"github.com/ignite/cli/ignite/pkg/cosmosaccount"
+	"github.com/ignite/cli/ignite/pkg/cosmosclient"
+	"github.com/ignite/cli/ignite/pkg/events"
+	"github.com/ignite/cli/ignite/pkg/gocmd"
+	"github.com/ignite/cli/ignite/pkg/xgit"
+	"github.com/ignite/cli/ignite/pkg/xurl"
+)
+
+const (
+	// upgradeGovPeriod is the deposit and voting period of the governance
+	// proposals in the chain used to rehearse an upgrade.
+	upgradeGovPeriod = "15s"
+
+	// upgradeHeightOffset is the number of blocks between the upgrade proposal
+	// submission and the upgrade height. It leaves time for the voting period to end.
+	upgradeHeightOffset = 30
+
+	// upgradeResumeTimeout is the time to wait for the upgraded chain to
+	// produce the first block after the upgrade height.
+	upgradeResumeTimeout = time.Minute
+)
+
+var upgradePanicRe = regexp.MustCompile(`(?m)(?:CONSENSUS FAILURE!!!.*?err="?([^"\n]+)|^panic: (.+)$)`)
+
+// CannotUpgradeAppError is returned when the chain doesn't resume producing
+// blocks after the binary built from the working tree replaces the old one.
+type CannotUpgradeAppError struct {
+	// AppName is the name of the app binary.
+	AppName string
+
+	// UpgradeName is the name of the upgrade plan.
+	UpgradeName string
+
+	// Height is the upgrade height.
+	Height int64
+
+	Err error
+}
+
+func (e *CannotUpgradeAppError) Error() string {
+	msg := fmt.Sprintf("cannot upgrade %s to %q at height %d", e.AppName, e.UpgradeName, e.Height)
+	if p := e.Panic(); p != "" {
+		return fmt.Sprintf("%s, the upgrade panicked: %s", msg, p)
+	}
+
+	return fmt.Sprintf("%s:\n%s", msg, e.Err)
+}
+
+func (e *CannotUpgradeAppError) Unwrap() error {
+	return e.Err
+}
+
+// Panic returns the panic message found in the logs of the app or an empty
+// string when the app didn't panic, for example when a store migration fails.
+func (e *CannotUpgradeAppError) Panic() string {
+	if e.Err == nil {
+		return ""
+	}
+
+	m := upgradePanicRe.FindStringSubmatch(e.Err.Error())
+	if m == nil {
+		return ""
+	}
+	if m[1] != "" {
+		return m[1]
+	}
+
+	return m[2]
+}
+
+// serveUpgrade rehearses an in-place software upgrade. It starts a new chain
+// with the binary built from the upgradeFrom git ref, upgrades the chain through
+// a software upgrade proposal voted by the validators and swaps the binary with
+// the one built from the working tree at the upgrade height.
+// The upgraded chain keeps running until the context is canceled.
+func (c *Chain) serveUpgrade(ctx context.Context, cacheStorage cache.Storage, o serveOptions) error {
+	conf, err := c.Config()
+	if err != nil {
+		return &CannotBuildAppError{err}
+	}
+
+	c.ev.Send("Building the app from the working tree...", events.ProgressStart())
+
+	if err := c.build(ctx, cacheStorage, o.buildTags, "", o.skipProto, o.generateClients, false); err != nil {
+		return err
+	}
+
+	c.ev.Send(fmt.Sprintf("Building the app from %s...", o.upgradeFrom), events.ProgressUpdate())
+
+	buildDir, err := os.MkdirTemp("", "ignite-upgrade-")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(buildDir)
+
+	oldBinary, err := c.buildRef(ctx, o.upgradeFrom, buildDir, o.buildTags)
+	if err != nil {
+		return &CannotBuildAppError{err}
+	}
+
+	// The old binary runs the chain until the upgrade height
+	old, err := c.withBinary(oldBinary)
+	if err != nil {
+		return err
+	}
+
+	c.ev.Send("Initializing the app...", events.ProgressUpdate())
+
+	if err := old.Init(ctx, InitArgsAll); err != nil {
+		return err
+	}
+
+	nodes, err := old.validatorNodes(ctx, conf)
+	if err != nil {
+		return err
+	}
+
+	if err := c.shortenGovPeriods(conf, nodes); err != nil {
+		return err
+	}
+
+	return c.rehearseUpgrade(ctx, o.upgradeName, upgradeSteps{
+		startOld: func(ctx context.Context) error {
+			return old.start(ctx, conf)
+		},
+		propose: func(ctx context.Context, nodeErr <-chan error) (int64, error) {
+			return c.proposeUpgrade(ctx, conf, nodes, o.upgradeName, nodeErr)
+		},
+		waitHalt: func(ctx context.Context, nodeErr <-chan error) error {
+			return waitUpgradeHalt(ctx, nodes[0].home, o.upgradeName, nodeErr)
+		},
+		startNew: func(ctx context.Context) error {
+			return c.start(ctx, conf)
+		},
+		waitResume: func(ctx context.Context, height int64, nodeErr <-chan error) error {
+			return c.waitUpgradeResume(ctx, conf, height, nodeErr)
+		},
+	})
+}
+
+// upgradeSteps are the steps of an upgrade rehearsal.
+// The node errors are the results of the chain started by the previous step.
+type upgradeSteps struct {
+	// startOld runs the chain with the old binary until the context is canceled.
+	startOld func(ctx context.Context) error
+
+	// propose submits and votes the upgrade proposal and returns the upgrade height.
+	propose func(ctx context.Context, nodeErr <-chan error) (int64, error)
+
+	// waitHalt waits until the chain halts at the upgrade height.
+	waitHalt func(ctx context.Context, nodeErr <-chan error) error
+
+	// startNew runs the chain with the new binary until the context is canceled.
+	startNew func(ctx context.Context) error
+
+	// waitResume waits until the chain commits a block after the upgrade height.
+	waitResume func(ctx context.Context, height int64, nodeErr <-chan error) error
+}
+
+// rehearseUpgrade runs the chain with the old binary until it halts at the upgrade
+// height, stops it and runs the chain with the new binary once the old nodes exited.
+// The upgraded chain keeps running until the context is canceled.
+func (c *Chain) rehearseUpgrade(ctx context.Context, name string, steps upgradeSteps) error {
+	oldCtx, stopOld := context.WithCancel(ctx)
+	defer stopOld()
+
+	oldErr := make(chan error, 1)
+	go func() { oldErr <- steps.startOld(oldCtx) }()
+
+	height, err := steps.propose(ctx, oldErr)
+	if err != nil {
+		return err
+	}
+
+	c.ev.Send(
+		fmt.Sprintf("Waiting for the chain to halt at height %d...", height),
+		events.ProgressStart(),
+	)
+
+	if err := steps.waitHalt(ctx, oldErr); err != nil {
+		return err
+	}
+
+	// Stop the old nodes and wait until they exit
+	stopOld()
+	<-oldErr
+
+	c.ev.Send(
+		fmt.Sprintf("Chain halted at height %d, starting the upgraded app...", height),
+		events.ProgressUpdate(),
+	)
+
+	newErr := make(chan error, 1)
+	newCtx, stopNew := context.WithCancel(ctx)
+	defer stopNew()
+
+	go func() { newErr <- steps.startNew(newCtx) }()
+
+	if err := steps.waitResume(ctx, height, newErr); err != nil {
+		// Stop the upgraded nodes to collect their logs
+		stopNew()
+		if startErr := <-newErr; startErr != nil && !errors.Is(startErr, context.Canceled) {
+			err = startErr
+		}
+
+		return &CannotUpgradeAppError{
+			AppName:     c.app.D(),
+			UpgradeName: name,
+			Height:      height,
+			Err:         err,
+		}
+	}
+
+	c.ev.Send(
+		fmt.Sprintf("Upgrade %q applied at height %d, blocks resumed", name, height),
+		events.Icon(icons.OK),
+		events.ProgressFinish(),
+	)
+
+	return <-newErr
+}
+
+// buildRef builds the app binary from the source code of a git ref and
+// returns the binary path. The git repository of the app is cloned into dir,
+// the app can be in a subdirectory of the repository.
+func (c *Chain) buildRef(ctx context.Context, ref, dir string, buildTags []string) (string, error) {
+	repoPath, err := xgit.RepositoryRoot(c.app.Path)
+	if err != nil {
+		return "", err
+	}
+
+	appDir, err := filepath.Rel(repoPath, c.app.Path)
+	if err != nil {
+		return "", err
+	}
+
+	clonePath := filepath.Join(dir, "src")
+	if err := xgit.Clone(ctx, fmt.Sprintf("%s@%s", repoPath, ref), clonePath); err != nil {
+		return "", errors.Wrapf(err, "cannot checkout %s", ref)
+	}
+
+	srcPath := filepath.Join(clonePath, appDir)
+
+	buildFlags, err := c.buildFlags(buildTags...)
+	if err != nil {
+		return "", err
+	}
+
+	binary, err := c.Binary()
+	if err != nil {
+		return "", err
+	}
+
+	mainPath, err := c.discoverMain(srcPath)
+	if err != nil {
+		return "", err
+	}
+
+	binDir := filepath.Join(dir, "bin")
+	if err := gocmd.BuildPath(ctx, binDir, binary, mainPath, buildFlags); err != nil {
+		return "", err
+	}
+
+	return filepath.Join(binDir, binary), nil
+}
+
+// shortenGovPeriods changes the governance params of the genesis shared by the
+// nodes so the upgrade proposal can be deposited and voted in a few seconds.
+func (c *Chain) shortenGovPeriods(conf *chainconfig.Config, nodes []validatorNode) error {
+	validator, err := chainconfig.FirstValidator(conf)
+	if err != nil {
+		return err
+	}
+
+	bonded, err := sdk.ParseCoinNormalized(validator.Bonded)
+	if err != nil {
+		return err
+	}
+
+	err = c.UpdateGenesisFile(map[string]interface{}{
+		"app_state": map[string]interface{}{
+			"gov": map[string]interface{}{
+				"params": map[string]interface{}{
+					"min_deposit": []interface{}{
+						map[string]interface{}{"denom": bonded.Denom, "amount": "1"},
+					},
+					"max_deposit_period": upgradeGovPeriod,
+					"voting_period":      upgradeGovPeriod,
+				},
+			},
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	genesisPath, err := c.GenesisPath()
+	if err != nil {
+		return err
+	}
+
+	genesis, err := os.ReadFile(genesisPath)
+	if err != nil {
+		return err
+	}
+
+	for _, node := range nodes[1:] {
+		if err := os.WriteFile(nodeGenesisPath(node.home), genesis, 0o644); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// proposeUpgrade submits a software upgrade proposal with the first validator
+// and votes yes with all of them. Returns the upgrade height.
+func (c *Chain) proposeUpgrade(
+	ctx context.Context,
+	conf *chainconfig.Config,
+	nodes []validatorNode,
+	name string,
+	nodeErr <-chan error,
+) (int64, error) {
+	c.ev.Send("Waiting for the chain to start...", events.ProgressUpdate())
+
+	clients := make([]cosmosclient.Client, len(nodes))
+	for i, node := range nodes {
+		client, err := c.upgradeClient(ctx, conf, node, nodeErr)
+		if err != nil {
+			return 0, err
+		}
+
+		clients[i] = client
+	}
+
+	proposer, err := clients[0].Account(nodes[0].validator.Name)
+	if err != nil {
+		return 0, err
+	}
+
+	proposerAddr, err := clients[0].Address(proposer.Name)
+	if err != nil {
+		return 0, err
+	}
+
+	prefix, _, err := bech32.DecodeAndConvert(proposerAddr)
+	if err != nil {
+		return 0, err
+	}
+
+	authority, err := sdk.Bech32ifyAddressBytes(prefix, authtypes.NewModuleAddress(govtypes.ModuleName))
+	if err != nil {
+		return 0, err
+	}
+
+	height, err := clients[0].LatestBlockHeight(ctx)
+	if err != nil {
+		return 0, err
+	}
+	height += upgradeHeightOffset
+
+	bonded, err := sdk.ParseCoinNormalized(nodes[0].validator.Bonded)
+	if err != nil {
+		return 0, err
+	}
+
+	msg, err := govv1.NewMsgSubmitProposal(
+		[]sdk.Msg{&upgradetypes.MsgSoftwareUpgrade{
+			Authority: authority,
+			Plan: upgradetypes.Plan{
+				Name:   name,
+				Height: height,
+			},
+		}},
+		sdk.NewCoins(sdk.NewInt64Coin(bonded.Denom, 1)),
+		proposerAddr,
+		"",
+		fmt.Sprintf("Upgrade %s", name),
+		fmt.Sprintf("Software upgrade %s rehearsed with Ignite", name),
+	)
+	if err != nil {
+		return 0, err
+	}
+
+	c.ev.Send(
+		fmt.Sprintf("Submitting the upgrade proposal for height %d...", height),
+		events.ProgressUpdate(),
+	)
+
+	resp, err := clients[0].BroadcastTx(ctx, proposer, msg)
+	if err != nil {
+		return 0, errors.Wrap(err, "cannot submit the upgrade proposal")
+	}
+
+	var proposal govv1.MsgSubmitProposalResponse
+	if err := resp.Decode(&proposal); err != nil {
+		return 0, err
+	}
+
+	c.ev.Send(
+		fmt.Sprintf("Voting the upgrade proposal %d...", proposal.ProposalId),
+		events.ProgressUpdate(),
+	)
+
+	for i, node := range nodes {
+		voter, err := clients[i].Account(node.validator.Name)
+		if err != nil {
+			return 0, err
+		}
+
+		voterAddr, err := clients[i].Address(voter.Name)
+		if err != nil {
+			return 0, err
+		}
+
+		vote := &govv1.MsgVote{
+			ProposalId: proposal.ProposalId,
+			Voter:      voterAddr,
+			Option:     govv1.OptionYes,
+		}
+		if _, err := clients[i].BroadcastTx(ctx, voter, vote); err != nil {
+			return 0, errors.Wrapf(err, "validator %s cannot vote the upgrade proposal", node.validator.Name)
+		}
+	}
+
+	return height, nil
+}
+
+// upgradeClient returns a client for the node of a validator.
+// It waits until the node is reachable or returns the node error when the
+// node fails to start.
+func (c *Chain) upgradeClient(
+	ctx context.Context,
+	conf *chainconfig.Config,
+	node validatorNode,
+	nodeErr <-chan error,
+) (cosmosclient.Client, error) {
+	servers, err := node.validator.GetServers()
+	if err != nil {
+		return cosmosclient.Client{}, err
+	}
+
+	rpcAddr, err := xurl.HTTP(servers.RPC.Address)
+	if err != nil {
+		return cosmosclient.Client{}, err
+	}
+
+	account, err := node.commands.ShowAccount(ctx, node.validator.Name)
+	if err != nil {
+		return cosmosclient.Client{}, err
+	}
+
+	prefix, _, err := bech32.DecodeAndConvert(account.Address)
+	if err != nil {
+		return cosmosclient.Client{}, err
+	}
+
+	backend, err := c.KeyringBackend()
+	if err != nil {
+		return cosmosclient.Client{}, err
+	}
+
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+
+	for {
+		client, err := cosmosclient.New(
+			ctx,
+			cosmosclient.WithNodeAddress(rpcAddr),
+			cosmosclient.WithHome(node.home),
+			cosmosclient.WithKeyringBackend(cosmosaccount.KeyringBackend(backend)),
+			cosmosclient.WithAddressPrefix(prefix),
+		)
+		if err == nil {
+			// The first block must be committed to be able to broadcast txs
+			if height, err := client.LatestBlockHeight(ctx); err == nil && height > 0 {
+				return client, nil
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return cosmosclient.Client{}, ctx.Err()
+		case err := <-nodeErr:
+			return cosmosclient.Client{}, err
+		case <-ticker.C:
+		}
+	}
+}
+
+// waitUpgradeHalt waits until the node that uses home halts because the
+// upgrade with the given name is needed.
+// Nodes write the upgrade info file to their data directory when they halt.
+func waitUpgradeHalt(ctx context.Context, home, name string, nodeErr <-chan error) error {
+	path := filepath.Join(home, "data", upgradetypes.UpgradeInfoFilename)
+
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+
+	for {
+		data, err := os.ReadFile(path)
+		if err == nil {
+			var plan upgradetypes.Plan
+			if err := json.Unmarshal(data, &plan); err != nil {
+				return errors.Wrapf(err, "cannot read %s", path)
+			}
+
+			if plan.Name != name {
+				return fmt.Errorf("chain halted for upgrade %q instead of %q", plan.Name, name)
+			}
+
+			return nil
+		} else if !os.IsNotExist(err) {
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case err := <-nodeErr:
+			return err
+		case <-ticker.C:
+		}
+	}
+}
+
+// waitUpgradeResume waits until the upgraded chain commits the block that
+// follows the upgrade height.
+func (c *Chain) waitUpgradeResume(
+	ctx context.Context,
+	conf *chainconfig.Config,
+	height int64,
+	nodeErr <-chan error,
+) error {
+	ctx, cancel := context.WithTimeout(ctx, upgradeResumeTimeout)
+	defer cancel()
+
+	nodes, err := c.validatorNodes(ctx, conf)
+	if err != nil {
+		return err
+	}
+
+	client, err := c.upgradeClient(ctx, conf, nodes[0], nodeErr)
+	if err != nil {
+		return err
+	}
+
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+
+	for {
+		// Ignore the errors because the node might not be ready yet
+		if latest, err := client.LatestBlockHeight(ctx); err == nil && latest > height {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return errors.Wrap(ctx.Err(), "blocks didn't resume after the upgrade height")
+		case err := <-nodeErr:
+			return err
+		case <-ticker.C:
+		}
+	}
+}
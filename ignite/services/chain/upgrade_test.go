@@ -0,0 +1,141 @@
+package chain
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCannotUpgradeAppErrorPanic(t *testing.T) {
+	cases := []struct {
+		name     string
+		logs     string
+		expected string
+	}{
+		{
+			name: "consensus failure",
+			logs: "INF applying upgrade \"v2\" at height: 42\n" +
+				"ERR CONSENSUS FAILURE!!! err=\"store migration failed: missing key\" module=consensus\n",
+			expected: "store migration failed: missing key",
+		},
+		{
+			name:     "panic",
+			logs:     "INF starting node\npanic: UPGRADE \"v2\" NEEDED at height: 42\n\ngoroutine 1 [running]:\n",
+			expected: "UPGRADE \"v2\" NEEDED at height: 42",
+		},
+		{
+			name: "no panic",
+			logs: "INF starting node\nERR dial tcp: connection refused\n",
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			err := &CannotUpgradeAppError{
+				AppName:     "mars",
+				UpgradeName: "v2",
+				Height:      42,
+				Err:         errors.New(tt.logs),
+			}
+
+			require.Equal(t, tt.expected, err.Panic())
+		})
+	}
+}
+
+func TestRehearseUpgrade(t *testing.T) {
+	cases := []struct {
+		name          string
+		resumeErr     error
+		newNodeErr    error
+		expectedError string
+	}{
+		{
+			name: "ok",
+		},
+		{
+			name:          "fail: blocks don't resume",
+			resumeErr:     errors.New("blocks didn't resume after the upgrade height"),
+			newNodeErr:    errors.New("panic: store migration failed"),
+			expectedError: `cannot upgrade marsd to "v2" at height 42, the upgrade panicked: store migration failed`,
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			var (
+				c          = &Chain{app: App{Name: "mars"}}
+				mu         sync.Mutex
+				steps      []string
+				oldStarted = make(chan struct{})
+				newStarted = make(chan struct{})
+			)
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			record := func(step string) {
+				mu.Lock()
+				defer mu.Unlock()
+				steps = append(steps, step)
+			}
+
+			// Act
+			err := c.rehearseUpgrade(ctx, "v2", upgradeSteps{
+				startOld: func(ctx context.Context) error {
+					record("start old")
+					close(oldStarted)
+					<-ctx.Done()
+					record("stop old")
+					return ctx.Err()
+				},
+				propose: func(context.Context, <-chan error) (int64, error) {
+					<-oldStarted
+					record("propose")
+					return 42, nil
+				},
+				waitHalt: func(context.Context, <-chan error) error {
+					record("halt")
+					return nil
+				},
+				startNew: func(ctx context.Context) error {
+					record("start new")
+					close(newStarted)
+					<-ctx.Done()
+					record("stop new")
+					return tt.newNodeErr
+				},
+				waitResume: func(_ context.Context, height int64, _ <-chan error) error {
+					<-newStarted
+					require.EqualValues(t, 42, height)
+					record("resume")
+
+					// Stop serving the upgraded chain
+					if tt.resumeErr == nil {
+						cancel()
+					}
+					return tt.resumeErr
+				},
+			})
+
+			// Assert
+			if tt.expectedError != "" {
+				require.EqualError(t, err, tt.expectedError)
+			} else {
+				require.NoError(t, err)
+			}
+			require.Equal(t, []string{
+				"start old",
+				"propose",
+				"halt",
+				"stop old",
+				"start new",
+				"resume",
+				"stop new",
+			}, steps)
+		})
+	}
+}
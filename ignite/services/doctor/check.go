@@ -0,0 +1,164 @@
+package doctor
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ignite/cli/ignite/pkg/cache"
+)
+
+// Status is the status of a check result.
+type Status int
+
+const (
+	// StatusPass indicates that the check didn't find any problem.
+	StatusPass Status = iota
+
+	// StatusWarn indicates that the check found a problem that
+	// doesn't prevent the chain from working.
+	StatusWarn
+
+	// StatusFail indicates that the check found a problem that
+	// must be solved for the chain to work.
+	StatusFail
+)
+
+// String returns the name of the status.
+func (s Status) String() string {
+	switch s {
+	case StatusPass:
+		return "pass"
+	case StatusWarn:
+		return "warn"
+	case StatusFail:
+		return "fail"
+	default:
+		return fmt.Sprintf("Status(%d)", int(s))
+	}
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (s Status) MarshalText() ([]byte, error) {
+	return []byte(s.String()), nil
+}
+
+// FixFunc fixes the problem found by a check.
+type FixFunc func(context.Context) error
+
+// Result is the result of running a check.
+type Result struct {
+	// Status is the status of the check.
+	Status Status
+
+	// Message describes the result of the check.
+	Message string
+
+	// Fix fixes the problem found by the check.
+	// It is nil when the problem can't be fixed automatically.
+	Fix FixFunc
+}
+
+// Pass returns a passing check result.
+func Pass(format string, a ...interface{}) Result {
+	return Result{Status: StatusPass, Message: fmt.Sprintf(format, a...)}
+}
+
+// Warn returns a warning check result.
+func Warn(format string, a ...interface{}) Result {
+	return Result{Status: StatusWarn, Message: fmt.Sprintf(format, a...)}
+}
+
+// Fail returns a failing check result.
+func Fail(format string, a ...interface{}) Result {
+	return Result{Status: StatusFail, Message: fmt.Sprintf(format, a...)}
+}
+
+// WithFix returns a copy of the result that can be fixed automatically using fix.
+func (r Result) WithFix(fix FixFunc) Result {
+	r.Fix = fix
+	return r
+}
+
+// Env is the environment where the checks run.
+type Env struct {
+	// AppPath is the path of the blockchain app.
+	AppPath string
+
+	// CacheStorage is the Ignite cache storage.
+	CacheStorage cache.Storage
+
+	// KeyringHome is the directory of the Ignite accounts keyring.
+	KeyringHome string
+}
+
+// Check diagnoses a part of the development environment.
+type Check struct {
+	// Name is the unique name of the check.
+	Name string
+
+	// Run runs the check.
+	Run func(context.Context, Env) Result
+}
+
+// Registry keeps the checks run by the doctor.
+type Registry struct {
+	checks []Check
+}
+
+// NewRegistry returns a new registry with checks.
+func NewRegistry(checks ...Check) (*Registry, error) {
+	r := &Registry{}
+	if err := r.Register(checks...); err != nil {
+		return nil, err
+	}
+
+	return r, nil
+}
+
+// DefaultRegistry returns a registry with the default checks.
+func DefaultRegistry() *Registry {
+	r, err := NewRegistry(DefaultChecks()...)
+	if err != nil {
+		panic(err)
+	}
+
+	return r
+}
+
+// Register adds checks to the registry.
+// The checks run in the same order they are registered.
+func (r *Registry) Register(checks ...Check) error {
+	for _, c := range checks {
+		if c.Name == "" {
+			return fmt.Errorf("check name is required")
+		}
+
+		if c.Run == nil {
+			return fmt.Errorf("check %q must have a run function", c.Name)
+		}
+
+		if _, ok := r.Get(c.Name); ok {
+			return fmt.Errorf("check %q is already registered", c.Name)
+		}
+
+		r.checks = append(r.checks, c)
+	}
+
+	return nil
+}
+
+// Get returns a check by its name.
+func (r *Registry) Get(name string) (Check, bool) {
+	for _, c := range r.checks {
+		if c.Name == name {
+			return c, true
+		}
+	}
+
+	return Check{}, false
+}
+
+// Checks returns the registered checks.
+func (r *Registry) Checks() []Check {
+	return r.checks
+}
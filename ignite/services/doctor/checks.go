@@ -0,0 +1,377 @@
+package doctor
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/gobuffalo/genny/v2"
+	"golang.org/x/mod/semver"
+
+	chainconfig "github.com/ignite/cli/ignite/config/chain"
+	"github.com/ignite/cli/ignite/pkg/availableport"
+	"github.com/ignite/cli/ignite/pkg/cache"
+	"github.com/ignite/cli/ignite/pkg/cosmosaccount"
+	"github.com/ignite/cli/ignite/pkg/cosmosanalysis"
+	appanalysis "github.com/ignite/cli/ignite/pkg/cosmosanalysis/app"
+	"github.com/ignite/cli/ignite/pkg/cosmosgen"
+	"github.com/ignite/cli/ignite/pkg/gocmd"
+	"github.com/ignite/cli/ignite/pkg/goenv"
+	"github.com/ignite/cli/ignite/pkg/gomodule"
+	"github.com/ignite/cli/ignite/pkg/gomodulepath"
+	"github.com/ignite/cli/ignite/templates/app"
+	"github.com/ignite/cli/ignite/templates/module"
+)
+
+// Names of the default checks.
+const (
+	CheckGoVersion     = "go version"
+	CheckToolsFile     = "dependency tools"
+	CheckProtocPlugins = "protoc plugins"
+	CheckConfig        = "config file"
+	CheckAppFile       = "app.go placeholders"
+	CheckPorts         = "ports"
+	CheckKeyring       = "accounts keyring"
+	CheckCache         = "cache"
+)
+
+const toolsGoFile = "tools/tools.go"
+
+// appPlaceholders are the placeholders of app.go used to scaffold modules.
+var appPlaceholders = []string{
+	module.PlaceholderSgAppModuleImport,
+	module.PlaceholderSgAppModuleBasic,
+	module.PlaceholderSgAppKeeperDeclaration,
+	module.PlaceholderSgAppStoreKey,
+	module.PlaceholderSgAppKeeperDefinition,
+	module.PlaceholderSgAppAppModule,
+	module.PlaceholderSgAppInitGenesis,
+	module.PlaceholderSgAppBeginBlockers,
+	module.PlaceholderSgAppEndBlockers,
+	module.PlaceholderSgAppParamSubspace,
+	module.PlaceholderSgAppMaccPerms,
+}
+
+// DefaultChecks returns the checks run by default by the doctor.
+func DefaultChecks() []Check {
+	return []Check{
+		{Name: CheckGoVersion, Run: checkGoVersion},
+		{Name: CheckToolsFile, Run: checkToolsFile},
+		{Name: CheckProtocPlugins, Run: checkProtocPlugins},
+		{Name: CheckConfig, Run: checkConfig},
+		{Name: CheckAppFile, Run: checkAppFile},
+		{Name: CheckPorts, Run: checkPorts},
+		{Name: CheckKeyring, Run: checkKeyring},
+		{Name: CheckCache, Run: checkCache},
+	}
+}
+
+// checkGoVersion checks that the installed Go version supports
+// the Go version required by the app's go.mod.
+func checkGoVersion(_ context.Context, env Env) Result {
+	modFile, err := gomodule.ParseAt(env.AppPath)
+	if err != nil {
+		return Fail("%s", err)
+	}
+
+	goVersion, err := gocmd.Env("GOVERSION")
+	if err != nil {
+		return Fail("cannot read the Go version: %s", err)
+	}
+
+	installed := strings.TrimSpace(goVersion)
+	if modFile.Go == nil {
+		return Pass("%s installed", installed)
+	}
+
+	required := modFile.Go.Version
+	if semver.Compare(goSemver(installed), goSemver(required)) < 0 {
+		return Fail("%s is installed but go.mod requires go %s or newer", installed, required)
+	}
+
+	return Pass("%s installed, go.mod requires go %s", installed, required)
+}
+
+// goSemver converts a Go version like "go1.20.3" or "1.21rc2" to semver.
+func goSemver(v string) string {
+	v = strings.TrimPrefix(v, "go")
+	if i := strings.IndexAny(v, "abcdefghijklmnopqrstuvwxyz "); i >= 0 {
+		v = v[:i]
+	}
+
+	return "v" + v
+}
+
+// checkToolsFile checks that tools/tools.go exists.
+func checkToolsFile(_ context.Context, env Env) Result {
+	_, err := os.Stat(filepath.Join(env.AppPath, toolsGoFile))
+	if os.IsNotExist(err) {
+		return Fail("%s is missing", toolsGoFile).WithFix(func(ctx context.Context) error {
+			return createToolsFile(ctx, env.AppPath)
+		})
+	}
+	if err != nil {
+		return Fail("%s", err)
+	}
+
+	return Pass("%s exists", toolsGoFile)
+}
+
+// createToolsFile creates tools/tools.go and installs the dependency tools.
+func createToolsFile(ctx context.Context, appPath string) error {
+	pathInfo, err := gomodulepath.ParseAt(appPath)
+	if err != nil {
+		return err
+	}
+
+	g, err := app.NewGenerator(&app.Options{
+		ModulePath:       pathInfo.RawPath,
+		AppName:          pathInfo.Package,
+		BinaryNamePrefix: pathInfo.Root,
+		IncludePrefixes:  []string{toolsGoFile},
+	})
+	if err != nil {
+		return err
+	}
+
+	runner := genny.WetRunner(ctx)
+	runner.Root = appPath
+	if err := runner.With(g); err != nil {
+		return err
+	}
+	if err := runner.Run(); err != nil {
+		return err
+	}
+
+	return cosmosgen.InstallDepTools(ctx, appPath)
+}
+
+// checkProtocPlugins checks that the protoc plugins used to generate code are installed.
+func checkProtocPlugins(_ context.Context, env Env) Result {
+	var missing []string
+	for _, dep := range cosmosgen.DepTools() {
+		name := path.Base(dep)
+		if _, err := os.Stat(filepath.Join(goenv.Bin(), name)); err == nil {
+			continue
+		}
+		if _, err := exec.LookPath(name); err == nil {
+			continue
+		}
+
+		missing = append(missing, name)
+	}
+
+	if len(missing) > 0 {
+		return Warn(
+			"%s not installed, they are installed when the code is generated",
+			strings.Join(missing, ", "),
+		).WithFix(func(ctx context.Context) error {
+			return cosmosgen.InstallDepTools(ctx, env.AppPath)
+		})
+	}
+
+	return Pass("all plugins installed")
+}
+
+// checkConfig checks that the chain config is valid and uses the latest version.
+func checkConfig(_ context.Context, env Env) Result {
+	configPath, err := chainconfig.LocateDefault(env.AppPath)
+	if err != nil {
+		return Fail("%s", err)
+	}
+
+	if _, err := chainconfig.ParseFile(configPath); err != nil {
+		return Fail("%s", err)
+	}
+
+	data, err := os.ReadFile(configPath)
+	if err != nil {
+		return Fail("%s", err)
+	}
+
+	version, err := chainconfig.ReadConfigVersion(bytes.NewReader(data))
+	if err != nil {
+		return Fail("%s", err)
+	}
+
+	if version != chainconfig.LatestVersion {
+		return Warn(
+			"config file version %d is outdated, the latest version is %d",
+			version,
+			chainconfig.LatestVersion,
+		).WithFix(func(context.Context) error {
+			return migrateConfig(configPath, data)
+		})
+	}
+
+	return Pass("config file OK")
+}
+
+// migrateConfig migrates the config file to the latest version.
+func migrateConfig(configPath string, data []byte) error {
+	var buf bytes.Buffer
+	if err := chainconfig.MigrateLatest(bytes.NewReader(data), &buf); err != nil {
+		return err
+	}
+
+	if err := os.WriteFile(configPath, buf.Bytes(), 0o755); err != nil {
+		return fmt.Errorf("config file migration failed: %w", err)
+	}
+
+	return nil
+}
+
+// checkAppFile checks that app.go contains the placeholders used to scaffold modules.
+func checkAppFile(_ context.Context, env Env) Result {
+	appFilePath, err := cosmosanalysis.FindAppFilePath(env.AppPath)
+	if err != nil {
+		return Warn("%s", err)
+	}
+
+	wiring, err := appanalysis.CheckAppWiring(env.AppPath)
+	if err != nil {
+		return Fail("%s", err)
+	}
+
+	if wiring {
+		return Warn("app uses app wiring, scaffolding modules with the app.go placeholders is not supported")
+	}
+
+	data, err := os.ReadFile(appFilePath)
+	if err != nil {
+		return Fail("%s", err)
+	}
+
+	var missing []string
+	for _, p := range appPlaceholders {
+		if !bytes.Contains(data, []byte(p)) {
+			missing = append(missing, strings.TrimPrefix(p, "// this line is used by starport scaffolding # "))
+		}
+	}
+
+	if len(missing) > 0 {
+		return Warn(
+			"%s is missing the placeholders %s, scaffolding modules will fail",
+			appFilePath,
+			strings.Join(missing, ", "),
+		)
+	}
+
+	return Pass("%s contains all the placeholders", appFilePath)
+}
+
+// checkPorts checks that the ports used by the chain are not used by other programs.
+func checkPorts(_ context.Context, env Env) Result {
+	configPath, err := chainconfig.LocateDefault(env.AppPath)
+	if err != nil {
+		return Pass("skipped, no config file found")
+	}
+
+	cfg, err := chainconfig.ParseFile(configPath)
+	if err != nil {
+		return Pass("skipped, config file is not valid")
+	}
+
+	type server struct{ name, address string }
+	var servers []server
+
+	for _, v := range cfg.Validators {
+		s, err := v.GetServers()
+		if err != nil {
+			return Fail("%s", err)
+		}
+
+		servers = append(servers,
+			server{fmt.Sprintf("%s rpc", v.Name), s.RPC.Address},
+			server{fmt.Sprintf("%s p2p", v.Name), s.P2P.Address},
+			server{fmt.Sprintf("%s grpc", v.Name), s.GRPC.Address},
+			server{fmt.Sprintf("%s api", v.Name), s.API.Address},
+		)
+	}
+
+	if cfg.Faucet.Name != nil {
+		servers = append(servers, server{"faucet", cfg.Faucet.Host})
+	}
+
+	var inUse []string
+	for _, s := range servers {
+		port, err := addressPort(s.address)
+		if err != nil {
+			return Fail("invalid %s address %q: %s", s.name, s.address, err)
+		}
+
+		if !availableport.IsAvailable(port) {
+			inUse = append(inUse, fmt.Sprintf("%d (%s)", port, s.name))
+		}
+	}
+
+	if len(inUse) > 0 {
+		return Warn(
+			"ports already in use: %s, stop the programs using them or change the addresses in %s",
+			strings.Join(inUse, ", "),
+			filepath.Base(configPath),
+		)
+	}
+
+	return Pass("all ports available")
+}
+
+// addressPort returns the port of addresses like "0.0.0.0:1317" or "tcp://0.0.0.0:26657".
+func addressPort(address string) (int, error) {
+	if i := strings.Index(address, "://"); i >= 0 {
+		address = address[i+3:]
+	}
+
+	_, port, err := net.SplitHostPort(address)
+	if err != nil {
+		return 0, err
+	}
+
+	return strconv.Atoi(port)
+}
+
+// checkKeyring checks that the accounts of the Ignite keyring can be read.
+func checkKeyring(_ context.Context, env Env) Result {
+	if _, err := os.Stat(env.KeyringHome); os.IsNotExist(err) {
+		return Pass("no keyring found in %s", env.KeyringHome)
+	}
+
+	registry, err := cosmosaccount.New(cosmosaccount.WithHome(env.KeyringHome))
+	if err != nil {
+		return Fail("cannot open the keyring: %s", err)
+	}
+
+	accounts, err := registry.List()
+	if err != nil {
+		return Fail("cannot read the keyring accounts: %s", err)
+	}
+
+	return Pass("%d accounts found in %s", len(accounts), env.KeyringHome)
+}
+
+// checkCache checks that the Ignite cache is not corrupted.
+func checkCache(_ context.Context, env Env) Result {
+	if env.CacheStorage == (cache.Storage{}) {
+		return Pass("skipped, cache storage is not configured")
+	}
+
+	err := env.CacheStorage.Check()
+	if errors.Is(err, cache.ErrStorageLocked) {
+		return Warn("%s, it will be checked when it's not used", err)
+	}
+	if err != nil {
+		return Fail("cache is corrupted: %s", err).WithFix(func(context.Context) error {
+			return env.CacheStorage.Remove()
+		})
+	}
+
+	return Pass("cache OK")
+}
@@ -1,32 +1,31 @@
 package doctor
 
 import (
-	"bytes"
 	"context"
 	"fmt"
-	"os"
-	"path"
 
-	"github.com/gobuffalo/genny/v2"
-
-	chainconfig "github.com/ignite/cli/ignite/config/chain"
-	"github.com/ignite/cli/ignite/pkg/cliui/colors"
-	"github.com/ignite/cli/ignite/pkg/cliui/icons"
-	"github.com/ignite/cli/ignite/pkg/cosmosgen"
+	"github.com/ignite/cli/ignite/pkg/cache"
+	"github.com/ignite/cli/ignite/pkg/cosmosaccount"
 	"github.com/ignite/cli/ignite/pkg/events"
-	"github.com/ignite/cli/ignite/pkg/gomodulepath"
-	"github.com/ignite/cli/ignite/templates/app"
 )
 
-// DONTCOVER: Doctor read and write the filesystem intensively, so it's better
-// to rely on integration tests only. See integration/doctor package.
+// Doctor diagnoses the development environment of a blockchain app
+// by running the checks of a registry.
 type Doctor struct {
-	ev events.Bus
+	ev       events.Bus
+	registry *Registry
+	env      Env
 }
 
 // New returns a new doctor.
 func New(opts ...Option) *Doctor {
-	d := &Doctor{}
+	d := &Doctor{
+		registry: DefaultRegistry(),
+		env: Env{
+			AppPath:     ".",
+			KeyringHome: cosmosaccount.KeyringHome,
+		},
+	}
 	for _, opt := range opts {
 		opt(d)
 	}
@@ -42,104 +41,89 @@ func CollectEvents(ev events.Bus) Option {
 	}
 }
 
-// MigrateConfig migrates the chain config if required.
-func (d *Doctor) MigrateConfig(_ context.Context) error {
-	errf := func(err error) error {
-		return fmt.Errorf("doctor migrate config: %w", err)
+// WithRegistry sets the registry with the checks to run.
+func WithRegistry(r *Registry) Option {
+	return func(d *Doctor) {
+		d.registry = r
 	}
+}
 
-	d.ev.Send("Checking chain config file:", events.ProgressFinish())
-
-	configPath, err := chainconfig.LocateDefault(".")
-	if err != nil {
-		return errf(err)
-	}
-	f, err := os.Open(configPath)
-	if err != nil {
-		return errf(err)
+// WithAppPath sets the path of the blockchain app.
+func WithAppPath(path string) Option {
+	return func(d *Doctor) {
+		d.env.AppPath = path
 	}
-	defer f.Close()
+}
 
-	version, err := chainconfig.ReadConfigVersion(f)
-	if err != nil {
-		return errf(err)
-	}
-	if version != chainconfig.LatestVersion {
-		// migrate config file
-		// Convert the current config to the latest version and update the YAML file
-		var buf bytes.Buffer
-		f.Seek(0, 0)
-		if err := chainconfig.MigrateLatest(f, &buf); err != nil {
-			return errf(err)
-		}
-		if err := os.WriteFile(configPath, buf.Bytes(), 0o755); err != nil {
-			return errf(fmt.Errorf("config file migration failed: %w", err))
-		}
-		d.ev.Send(fmt.Sprintf("config file %s", colors.Success("migrated")),
-			events.Icon(icons.OK), events.ProgressFinish())
+// WithCacheStorage sets the Ignite cache storage to check.
+func WithCacheStorage(storage cache.Storage) Option {
+	return func(d *Doctor) {
+		d.env.CacheStorage = storage
 	}
-	d.ev.Send("config file OK", events.Icon(icons.OK), events.ProgressFinish())
-
-	return nil
 }
 
-// FixDependencyTools ensures that:
-// - tools/tools.go is present and populated properly
-// - dependency tools are installed.
-func (d *Doctor) FixDependencyTools(ctx context.Context) error {
-	errf := func(err error) error {
-		return fmt.Errorf("doctor fix dependency tools: %w", err)
+// WithKeyringHome sets the directory of the Ignite accounts keyring to check.
+func WithKeyringHome(path string) Option {
+	return func(d *Doctor) {
+		d.env.KeyringHome = path
 	}
+}
 
-	d.ev.Send("Checking dependency tools:", events.ProgressFinish())
+// Report is the result of running a check.
+type Report struct {
+	// Check is the name of the check.
+	Check string `json:"check"`
 
-	const toolsGoFile = "tools/tools.go"
-	_, err := os.Stat(toolsGoFile)
+	// Status is the status of the check.
+	Status Status `json:"status"`
 
-	switch {
-	case err == nil:
-		// tools.go exists
-		d.ev.Send(fmt.Sprintf("%s exists", toolsGoFile), events.Icon(icons.OK),
-			events.ProgressFinish())
-		// TODO ensure tools.go has the required dependencies
+	// Message describes the result of the check.
+	Message string `json:"message"`
 
-	case os.IsNotExist(err):
-		// create tools.go
-		pathInfo, err := gomodulepath.ParseAt(".")
-		if err != nil {
-			return errf(err)
-		}
-		g, err := app.NewGenerator(&app.Options{
-			ModulePath:       pathInfo.RawPath,
-			AppName:          pathInfo.Package,
-			BinaryNamePrefix: pathInfo.Root,
-			IncludePrefixes:  []string{toolsGoFile},
-		})
-		if err != nil {
-			return errf(err)
-		}
-		// run generator
-		runner := genny.WetRunner(ctx)
-		if err := runner.With(g); err != nil {
-			return errf(err)
-		}
-		if err := runner.Run(); err != nil {
-			return errf(err)
+	// Fixable is true when the problem found can be fixed automatically.
+	Fixable bool `json:"fixable"`
+
+	// Fixed is true when the problem found was fixed.
+	Fixed bool `json:"fixed"`
+}
+
+// Diagnose runs all the checks and returns their reports.
+// When fix is true the problems that can be fixed automatically are fixed
+// and the checks run again to report the result after the fix.
+func (d *Doctor) Diagnose(ctx context.Context, fix bool) ([]Report, error) {
+	var reports []Report
+	for _, c := range d.registry.Checks() {
+		if err := ctx.Err(); err != nil {
+			return nil, err
 		}
-		d.ev.Send(fmt.Sprintf("%s %s", toolsGoFile, colors.Success("created")),
-			events.Icon(icons.OK), events.ProgressFinish())
 
-		d.ev.Send("Installing dependency tools", events.ProgressStart())
-		if err := cosmosgen.InstallDepTools(ctx, "."); err != nil {
-			return errf(err)
+		d.ev.Send(fmt.Sprintf("Checking %s...", c.Name), events.ProgressStart())
+
+		result := c.Run(ctx, d.env)
+		report := Report{
+			Check:   c.Name,
+			Status:  result.Status,
+			Message: result.Message,
+			Fixable: result.Status != StatusPass && result.Fix != nil,
 		}
-		for _, dep := range cosmosgen.DepTools() {
-			d.ev.Send(fmt.Sprintf("%s %s", path.Base(dep), colors.Success("installed")),
-				events.Icon(icons.OK), events.ProgressFinish())
+
+		if fix && report.Fixable {
+			d.ev.Send(fmt.Sprintf("Fixing %s...", c.Name), events.ProgressUpdate())
+
+			if err := result.Fix(ctx); err != nil {
+				report.Status = StatusFail
+				report.Message = fmt.Sprintf("%s: fix failed: %s", result.Message, err)
+			} else {
+				result = c.Run(ctx, d.env)
+				report.Status = result.Status
+				report.Message = result.Message
+				report.Fixable = result.Status != StatusPass && result.Fix != nil
+				report.Fixed = true
+			}
 		}
 
-	default:
-		return errf(err)
+		reports = append(reports, report)
 	}
-	return nil
+
+	return reports, nil
 }
@@ -0,0 +1,156 @@
+package doctor_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ignite/cli/ignite/services/doctor"
+)
+
+func TestRegistryRegister(t *testing.T) {
+	run := func(context.Context, doctor.Env) doctor.Result {
+		return doctor.Pass("ok")
+	}
+
+	cases := []struct {
+		name   string
+		checks []doctor.Check
+		err    string
+	}{
+		{
+			name:   "missing name",
+			checks: []doctor.Check{{Run: run}},
+			err:    "check name is required",
+		},
+		{
+			name:   "missing run function",
+			checks: []doctor.Check{{Name: "foo"}},
+			err:    `check "foo" must have a run function`,
+		},
+		{
+			name:   "duplicated name",
+			checks: []doctor.Check{{Name: "foo", Run: run}, {Name: "foo", Run: run}},
+			err:    `check "foo" is already registered`,
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := doctor.NewRegistry(tt.checks...)
+
+			require.EqualError(t, err, tt.err)
+		})
+	}
+}
+
+func TestDefaultRegistry(t *testing.T) {
+	r := doctor.DefaultRegistry()
+
+	var names []string
+	for _, c := range r.Checks() {
+		names = append(names, c.Name)
+	}
+
+	require.Equal(t, []string{
+		doctor.CheckGoVersion,
+		doctor.CheckToolsFile,
+		doctor.CheckProtocPlugins,
+		doctor.CheckConfig,
+		doctor.CheckAppFile,
+		doctor.CheckPorts,
+		doctor.CheckKeyring,
+		doctor.CheckCache,
+	}, names)
+}
+
+func TestDiagnose(t *testing.T) {
+	// Arrange
+	broken := true
+	r, err := doctor.NewRegistry(
+		doctor.Check{
+			Name: "pass",
+			Run: func(context.Context, doctor.Env) doctor.Result {
+				return doctor.Pass("all good")
+			},
+		},
+		doctor.Check{
+			Name: "warn",
+			Run: func(context.Context, doctor.Env) doctor.Result {
+				return doctor.Warn("not great")
+			},
+		},
+		doctor.Check{
+			Name: "fixable",
+			Run: func(context.Context, doctor.Env) doctor.Result {
+				if !broken {
+					return doctor.Pass("fixed")
+				}
+
+				return doctor.Fail("broken").WithFix(func(context.Context) error {
+					broken = false
+					return nil
+				})
+			},
+		},
+		doctor.Check{
+			Name: "fix fails",
+			Run: func(context.Context, doctor.Env) doctor.Result {
+				return doctor.Fail("broken").WithFix(func(context.Context) error {
+					return errors.New("oops")
+				})
+			},
+		},
+	)
+	require.NoError(t, err)
+
+	d := doctor.New(doctor.WithRegistry(r))
+
+	// Act
+	reports, err := d.Diagnose(context.Background(), false)
+	require.NoError(t, err)
+
+	fixReports, fixErr := d.Diagnose(context.Background(), true)
+
+	// Assert
+	require.Equal(t, []doctor.Report{
+		{Check: "pass", Status: doctor.StatusPass, Message: "all good"},
+		{Check: "warn", Status: doctor.StatusWarn, Message: "not great"},
+		{Check: "fixable", Status: doctor.StatusFail, Message: "broken", Fixable: true},
+		{Check: "fix fails", Status: doctor.StatusFail, Message: "broken", Fixable: true},
+	}, reports)
+
+	require.NoError(t, fixErr)
+	require.Equal(t, []doctor.Report{
+		{Check: "pass", Status: doctor.StatusPass, Message: "all good"},
+		{Check: "warn", Status: doctor.StatusWarn, Message: "not great"},
+		{Check: "fixable", Status: doctor.StatusPass, Message: "fixed", Fixed: true},
+		{Check: "fix fails", Status: doctor.StatusFail, Message: "broken: fix failed: oops", Fixable: true},
+	}, fixReports)
+}
+
+func TestDiagnoseCacheNotConfigured(t *testing.T) {
+	// Arrange
+	var checks []doctor.Check
+	for _, c := range doctor.DefaultRegistry().Checks() {
+		if c.Name == doctor.CheckCache {
+			checks = append(checks, c)
+		}
+	}
+
+	r, err := doctor.NewRegistry(checks...)
+	require.NoError(t, err)
+
+	d := doctor.New(doctor.WithRegistry(r))
+
+	// Act
+	reports, err := d.Diagnose(context.Background(), false)
+
+	// Assert
+	require.NoError(t, err)
+	require.Equal(t, []doctor.Report{
+		{Check: doctor.CheckCache, Status: doctor.StatusPass, Message: "skipped, cache storage is not configured"},
+	}, reports)
+}
@@ -0,0 +1,268 @@
+package plugin
+
+import (
+	"context"
+	"encoding/gob"
+	"errors"
+	"fmt"
+	"net/rpc"
+	"sync"
+
+	chainconfig "github.com/ignite/cli/ignite/config/chain"
+	"github.com/ignite/cli/ignite/pkg/cosmosanalysis/module"
+	"github.com/ignite/cli/ignite/pkg/cosmosclient"
+	"github.com/ignite/cli/ignite/pkg/xurl"
+)
+
+func init() {
+	gob.Register(ChainInfo{})
+	// Register the types used by the generic values of the chain config
+	gob.Register(map[string]interface{}{})
+	gob.Register([]interface{}{})
+}
+
+// ErrClientAPIUnavailable is returned when the host doesn't provide a client API.
+var ErrClientAPIUnavailable = errors.New("client API is not available")
+
+// ClientAPI defines the API that plugins can use to request information from
+// the host, which is the ignite process that executes the plugin.
+type ClientAPI interface {
+	// GetChainInfo returns information about the chain where the plugin
+	// command or hook is executed.
+	GetChainInfo(ctx context.Context) (ChainInfo, error)
+
+	// GetModules returns the Cosmos SDK modules registered by the chain app.
+	GetModules(ctx context.Context) ([]module.Module, error)
+}
+
+// ChainInfo contains information about the chain where a plugin command or
+// hook is executed.
+type ChainInfo struct {
+	// ChainID is the ID of the chain.
+	ChainID string
+	// AppPath is the absolute path of the chain source code.
+	AppPath string
+	// ConfigPath is the path of the chain config file.
+	// Empty when the chain has no config file.
+	ConfigPath string
+	// Home is the path of the chain home directory.
+	Home string
+	// RPCAddress is the HTTP address of the chain node's RPC interface.
+	RPCAddress string
+	// Config is the parsed chain config.
+	Config *chainconfig.Config
+}
+
+// NewChainClient creates a new Cosmos client connected to the node of the
+// chain where a plugin command or hook is executed.
+func NewChainClient(ctx context.Context, api ClientAPI, options ...cosmosclient.Option) (cosmosclient.Client, error) {
+	info, err := api.GetChainInfo(ctx)
+	if err != nil {
+		return cosmosclient.Client{}, err
+	}
+
+	options = append([]cosmosclient.Option{
+		cosmosclient.WithHome(info.Home),
+		cosmosclient.WithNodeAddress(info.RPCAddress),
+	}, options...)
+
+	return cosmosclient.New(ctx, options...)
+}
+
+// Chain defines the chain that the client API gives plugins access to.
+type Chain interface {
+	// ID returns the ID of the chain.
+	ID() (string, error)
+	// AppPath returns the absolute path of the chain source code.
+	AppPath() string
+	// ConfigPath returns the path of the chain config file.
+	ConfigPath() string
+	// Home returns the path of the chain home directory.
+	Home() (string, error)
+	// RPCPublicAddress returns the address of the chain node's RPC interface.
+	RPCPublicAddress() (string, error)
+	// Config returns the parsed chain config.
+	Config() (*chainconfig.Config, error)
+}
+
+// ChainLoader returns the chain where a plugin command or hook is executed.
+type ChainLoader func() (Chain, error)
+
+type clientAPI struct {
+	load ChainLoader
+
+	once  sync.Once
+	chain Chain
+	err   error
+}
+
+// NewClientAPI creates a new client API that gives plugins access to the
+// chain returned by load. The chain is loaded the first time that a plugin
+// requests it.
+func NewClientAPI(load ChainLoader) ClientAPI {
+	return &clientAPI{load: load}
+}
+
+func (api *clientAPI) getChain() (Chain, error) {
+	api.once.Do(func() {
+		api.chain, api.err = api.load()
+	})
+	return api.chain, api.err
+}
+
+// GetChainInfo implements ClientAPI.GetChainInfo.
+func (api *clientAPI) GetChainInfo(context.Context) (ChainInfo, error) {
+	c, err := api.getChain()
+	if err != nil {
+		return ChainInfo{}, err
+	}
+
+	chainID, err := c.ID()
+	if err != nil {
+		return ChainInfo{}, err
+	}
+
+	home, err := c.Home()
+	if err != nil {
+		return ChainInfo{}, err
+	}
+
+	cfg, err := c.Config()
+	if err != nil {
+		return ChainInfo{}, err
+	}
+
+	rpcAddress, err := c.RPCPublicAddress()
+	if err != nil {
+		return ChainInfo{}, err
+	}
+
+	rpcAddress, err = xurl.HTTP(rpcAddress)
+	if err != nil {
+		return ChainInfo{}, fmt.Errorf("invalid rpc address format: %w", err)
+	}
+
+	return ChainInfo{
+		ChainID:    chainID,
+		AppPath:    c.AppPath(),
+		ConfigPath: c.ConfigPath(),
+		Home:       home,
+		RPCAddress: xurl.HTTPEnsurePort(rpcAddress),
+		Config:     cfg,
+	}, nil
+}
+
+// GetModules implements ClientAPI.GetModules.
+func (api *clientAPI) GetModules(ctx context.Context) ([]module.Module, error) {
+	c, err := api.getChain()
+	if err != nil {
+		return nil, err
+	}
+
+	cfg, err := c.Config()
+	if err != nil {
+		return nil, err
+	}
+
+	appPath := c.AppPath()
+	return module.Discover(ctx, appPath, appPath, cfg.Build.Proto.Path)
+}
+
+// hostClientAPI is the client API served by the host to the plugin.
+// A single server is used for all the calls to the plugin, so the API
+// of the executed command is set before each call.
+type hostClientAPI struct {
+	mu  sync.RWMutex
+	api ClientAPI
+}
+
+func (h *hostClientAPI) set(api ClientAPI) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.api = api
+}
+
+func (h *hostClientAPI) get() ClientAPI {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	if h.api == nil {
+		return unavailableClientAPI{}
+	}
+	return h.api
+}
+
+// GetChainInfo implements ClientAPI.GetChainInfo.
+func (h *hostClientAPI) GetChainInfo(ctx context.Context) (ChainInfo, error) {
+	return h.get().GetChainInfo(ctx)
+}
+
+// GetModules implements ClientAPI.GetModules.
+func (h *hostClientAPI) GetModules(ctx context.Context) ([]module.Module, error) {
+	return h.get().GetModules(ctx)
+}
+
+// unavailableClientAPI is used when the host doesn't provide a client API.
+type unavailableClientAPI struct{}
+
+func (unavailableClientAPI) GetChainInfo(context.Context) (ChainInfo, error) {
+	return ChainInfo{}, ErrClientAPIUnavailable
+}
+
+func (unavailableClientAPI) GetModules(context.Context) ([]module.Module, error) {
+	return nil, ErrClientAPIUnavailable
+}
+
+// ClientAPIRPC is an implementation of ClientAPI that talks over RPC.
+type ClientAPIRPC struct{ client *rpc.Client }
+
+// GetChainInfo implements ClientAPI.GetChainInfo.
+func (g *ClientAPIRPC) GetChainInfo(ctx context.Context) (ChainInfo, error) {
+	var resp ChainInfo
+	if err := g.call(ctx, "Plugin.GetChainInfo", &resp); err != nil {
+		return ChainInfo{}, err
+	}
+	return resp, nil
+}
+
+// GetModules implements ClientAPI.GetModules.
+func (g *ClientAPIRPC) GetModules(ctx context.Context) ([]module.Module, error) {
+	var resp []module.Module
+	if err := g.call(ctx, "Plugin.GetModules", &resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// Close closes the connection with the host.
+func (g *ClientAPIRPC) Close() error {
+	return g.client.Close()
+}
+
+func (g *ClientAPIRPC) call(ctx context.Context, method string, resp interface{}) error {
+	call := g.client.Go(method, new(interface{}), resp, nil)
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-call.Done:
+		return call.Error
+	}
+}
+
+// ClientAPIRPCServer is the RPC server that ClientAPIRPC talks to, conforming
+// to the requirements of net/rpc.
+type ClientAPIRPCServer struct {
+	// This is the real implementation
+	Impl ClientAPI
+}
+
+func (s *ClientAPIRPCServer) GetChainInfo(_ interface{}, resp *ChainInfo) error {
+	var err error
+	*resp, err = s.Impl.GetChainInfo(context.Background())
+	return err
+}
+
+func (s *ClientAPIRPCServer) GetModules(_ interface{}, resp *[]module.Module) error {
+	var err error
+	*resp, err = s.Impl.GetModules(context.Background())
+	return err
+}
@@ -0,0 +1,179 @@
+package plugin_test
+
+import (
+	"context"
+	"testing"
+
+	hplugin "github.com/hashicorp/go-plugin"
+	"github.com/stretchr/testify/require"
+
+	chainconfig "github.com/ignite/cli/ignite/config/chain"
+	"github.com/ignite/cli/ignite/pkg/cosmosanalysis/module"
+	"github.com/ignite/cli/ignite/services/plugin"
+)
+
+type testClientAPI struct {
+	chainInfo plugin.ChainInfo
+	modules   []module.Module
+}
+
+func (api testClientAPI) GetChainInfo(context.Context) (plugin.ChainInfo, error) {
+	return api.chainInfo, nil
+}
+
+func (api testClientAPI) GetModules(context.Context) ([]module.Module, error) {
+	return api.modules, nil
+}
+
+// testPlugin is a plugin that requests the chain info and the modules to the
+// host when a command or a hook is executed.
+type testPlugin struct {
+	plugin.Interface
+
+	chainInfo plugin.ChainInfo
+	modules   []module.Module
+}
+
+func (p *testPlugin) Execute(cmd plugin.ExecutedCommand) (err error) {
+	ctx := context.Background()
+	if p.chainInfo, err = cmd.ClientAPI().GetChainInfo(ctx); err != nil {
+		return err
+	}
+	p.modules, err = cmd.ClientAPI().GetModules(ctx)
+	return err
+}
+
+func (p *testPlugin) ExecuteHookPre(hook plugin.ExecutedHook) error {
+	return p.Execute(hook.ExecutedCommand)
+}
+
+func dispenseTestPlugin(t *testing.T, impl plugin.Interface) plugin.Interface {
+	t.Helper()
+
+	client, _ := hplugin.TestPluginRPCConn(t, map[string]hplugin.Plugin{
+		"test": &plugin.InterfacePlugin{Impl: impl},
+	}, nil)
+	t.Cleanup(func() { client.Close() })
+
+	raw, err := client.Dispense("test")
+	require.NoError(t, err)
+	return raw.(plugin.Interface)
+}
+
+func TestClientAPI(t *testing.T) {
+	// Arrange
+	cfg := chainconfig.DefaultChainConfig()
+	cfg.Genesis = map[string]interface{}{
+		"app_state": map[string]interface{}{
+			"staking": map[string]interface{}{
+				"params": map[string]interface{}{"bond_denom": "stake"},
+			},
+		},
+		"validators": []interface{}{"alice", "bob"},
+	}
+	api := testClientAPI{
+		chainInfo: plugin.ChainInfo{
+			ChainID:    "mars",
+			AppPath:    "/apps/mars",
+			ConfigPath: "/apps/mars/config.yml",
+			Home:       "/home/.mars",
+			RPCAddress: "http://localhost:26657",
+			Config:     cfg,
+		},
+		modules: []module.Module{
+			{Name: "mars", GoModulePath: "github.com/test/mars"},
+		},
+	}
+	impl := &testPlugin{}
+	p := dispenseTestPlugin(t, impl)
+
+	cmd := plugin.ExecutedCommand{Path: "ignite mars"}
+	cmd.SetClientAPI(api)
+	hook := plugin.ExecutedHook{ExecutedCommand: cmd}
+
+	// Act
+	errCmd := p.Execute(cmd)
+	cmdChainInfo, cmdModules := impl.chainInfo, impl.modules
+	errHook := p.ExecuteHookPre(hook)
+
+	// Assert
+	require.NoError(t, errCmd)
+	require.Equal(t, api.chainInfo, cmdChainInfo)
+	require.Equal(t, api.modules, cmdModules)
+	require.NoError(t, errHook)
+	require.Equal(t, api.chainInfo, impl.chainInfo)
+	require.Equal(t, api.modules, impl.modules)
+}
+
+func TestClientAPIUnavailable(t *testing.T) {
+	// Arrange
+	p := dispenseTestPlugin(t, &testPlugin{})
+
+	// Act
+	err := p.Execute(plugin.ExecutedCommand{Path: "ignite mars"})
+
+	// Assert
+	require.EqualError(t, err, plugin.ErrClientAPIUnavailable.Error())
+}
+
+type testChain struct {
+	cfg *chainconfig.Config
+}
+
+func (testChain) ID() (string, error)                    { return "mars", nil }
+func (testChain) AppPath() string                        { return "/apps/mars" }
+func (testChain) ConfigPath() string                     { return "/apps/mars/config.yml" }
+func (testChain) Home() (string, error)                  { return "/home/.mars", nil }
+func (testChain) RPCPublicAddress() (string, error)      { return "localhost:26657", nil }
+func (c testChain) Config() (*chainconfig.Config, error) { return c.cfg, nil }
+
+func TestNewClientAPI(t *testing.T) {
+	// Arrange
+	cfg := chainconfig.DefaultChainConfig()
+	loads := 0
+	api := plugin.NewClientAPI(func() (plugin.Chain, error) {
+		loads++
+		return testChain{cfg: cfg}, nil
+	})
+
+	// Act
+	info, err := api.GetChainInfo(context.Background())
+	_, _ = api.GetChainInfo(context.Background())
+
+	// Assert
+	require.NoError(t, err)
+	require.Equal(t, plugin.ChainInfo{
+		ChainID:    "mars",
+		AppPath:    "/apps/mars",
+		ConfigPath: "/apps/mars/config.yml",
+		Home:       "/home/.mars",
+		RPCAddress: "http://localhost:26657",
+		Config:     cfg,
+	}, info)
+	require.Equal(t, 1, loads)
+}
+
+func TestClientAPIChangesBetweenCalls(t *testing.T) {
+	// Arrange
+	impl := &testPlugin{}
+	p := dispenseTestPlugin(t, impl)
+
+	mars := testClientAPI{chainInfo: plugin.ChainInfo{ChainID: "mars"}}
+	venus := testClientAPI{chainInfo: plugin.ChainInfo{ChainID: "venus"}}
+
+	marsCmd := plugin.ExecutedCommand{Path: "ignite mars"}
+	marsCmd.SetClientAPI(mars)
+	venusCmd := plugin.ExecutedCommand{Path: "ignite venus"}
+	venusCmd.SetClientAPI(venus)
+
+	// Act
+	errMars := p.Execute(marsCmd)
+	marsChainID := impl.chainInfo.ChainID
+	errVenus := p.Execute(venusCmd)
+
+	// Assert
+	require.NoError(t, errMars)
+	require.Equal(t, "mars", marsChainID)
+	require.NoError(t, errVenus)
+	require.Equal(t, "venus", impl.chainInfo.ChainID)
+}
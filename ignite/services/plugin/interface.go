@@ -8,6 +8,7 @@ import (
 	"os"
 	"strconv"
 	"strings"
+	"sync"
 
 	"github.com/hashicorp/go-plugin"
 	"github.com/spf13/cobra"
@@ -182,6 +183,7 @@ type ExecutedCommand struct {
 
 	flags  *pflag.FlagSet
 	pflags *pflag.FlagSet
+	api    ClientAPI
 }
 
 // ExecutedHook represents a plugin hook under execution.
@@ -216,6 +218,21 @@ func (c *ExecutedCommand) SetFlags(cmd *cobra.Command) {
 	c.pflags = cmd.PersistentFlags()
 }
 
+// ClientAPI gives access to the API that the host provides to the plugins,
+// which can be used to request information about the chain.
+func (c *ExecutedCommand) ClientAPI() ClientAPI {
+	if c.api == nil {
+		return unavailableClientAPI{}
+	}
+	return c.api
+}
+
+// SetClientAPI set the client API.
+// As a plugin developer, you probably don't need to use it.
+func (c *ExecutedCommand) SetClientAPI(api ClientAPI) {
+	c.api = api
+}
+
 // Flag is a serializable representation of pflag.Flag.
 type Flag struct {
 	Name      string // name as it appears on command line
@@ -379,7 +396,15 @@ func HandshakeConfig() plugin.HandshakeConfig {
 }
 
 // InterfaceRPC is an implementation that talks over RPC.
-type InterfaceRPC struct{ client *rpc.Client }
+type InterfaceRPC struct {
+	client *rpc.Client
+	broker *plugin.MuxBroker
+
+	// api is the client API served to the plugin in the connection with ID apiID.
+	apiOnce sync.Once
+	api     *hostClientAPI
+	apiID   uint32
+}
 
 // Manifest implements Interface.Manifest.
 func (g *InterfaceRPC) Manifest() (Manifest, error) {
@@ -390,30 +415,47 @@ func (g *InterfaceRPC) Manifest() (Manifest, error) {
 // Execute implements Interface.Commands.
 func (g *InterfaceRPC) Execute(c ExecutedCommand) error {
 	var resp interface{}
-	return g.client.Call("Plugin.Execute", map[string]interface{}{
+	return g.client.Call("Plugin.Execute", g.withClientAPI(c, map[string]interface{}{
 		"executedCommand": c,
-	}, &resp)
+	}), &resp)
 }
 
 func (g *InterfaceRPC) ExecuteHookPre(hook ExecutedHook) error {
 	var resp interface{}
-	return g.client.Call("Plugin.ExecuteHookPre", map[string]interface{}{
+	return g.client.Call("Plugin.ExecuteHookPre", g.withClientAPI(hook.ExecutedCommand, map[string]interface{}{
 		"executedHook": hook,
-	}, &resp)
+	}), &resp)
 }
 
 func (g *InterfaceRPC) ExecuteHookPost(hook ExecutedHook) error {
 	var resp interface{}
-	return g.client.Call("Plugin.ExecuteHookPost", map[string]interface{}{
+	return g.client.Call("Plugin.ExecuteHookPost", g.withClientAPI(hook.ExecutedCommand, map[string]interface{}{
 		"executedHook": hook,
-	}, &resp)
+	}), &resp)
 }
 
 func (g *InterfaceRPC) ExecuteHookCleanUp(hook ExecutedHook) error {
 	var resp interface{}
-	return g.client.Call("Plugin.ExecuteHookCleanUp", map[string]interface{}{
+	return g.client.Call("Plugin.ExecuteHookCleanUp", g.withClientAPI(hook.ExecutedCommand, map[string]interface{}{
 		"executedHook": hook,
-	}, &resp)
+	}), &resp)
+}
+
+// withClientAPI serves the client API of the executed command and adds the
+// ID of its multiplexed connection to the call arguments, so the plugin can
+// dial it. The connection is served once and shared by all the calls.
+func (g *InterfaceRPC) withClientAPI(c ExecutedCommand, args map[string]interface{}) map[string]interface{} {
+	if c.api == nil || g.broker == nil {
+		return args
+	}
+	g.apiOnce.Do(func() {
+		g.api = &hostClientAPI{}
+		g.apiID = g.broker.NextId()
+		go g.broker.AcceptAndServe(g.apiID, &ClientAPIRPCServer{Impl: g.api})
+	})
+	g.api.set(c.api)
+	args["clientAPIID"] = g.apiID
+	return args
 }
 
 // InterfaceRPCServer is the RPC server that InterfaceRPC talks to, conforming to
@@ -421,6 +463,13 @@ func (g *InterfaceRPC) ExecuteHookCleanUp(hook ExecutedHook) error {
 type InterfaceRPCServer struct {
 	// This is the real implementation
 	Impl Interface
+
+	broker *plugin.MuxBroker
+
+	// api is the connection to the client API served by the host.
+	apiOnce sync.Once
+	api     *ClientAPIRPC
+	apiErr  error
 }
 
 func (s *InterfaceRPCServer) Manifest(_ interface{}, resp *Manifest) error {
@@ -430,19 +479,58 @@ func (s *InterfaceRPCServer) Manifest(_ interface{}, resp *Manifest) error {
 }
 
 func (s *InterfaceRPCServer) Execute(args map[string]interface{}, _ *interface{}) error {
-	return s.Impl.Execute(args["executedCommand"].(ExecutedCommand))
+	c := args["executedCommand"].(ExecutedCommand)
+	if err := s.dialClientAPI(args, &c); err != nil {
+		return err
+	}
+	return s.Impl.Execute(c)
 }
 
 func (s *InterfaceRPCServer) ExecuteHookPre(args map[string]interface{}, _ *interface{}) error {
-	return s.Impl.ExecuteHookPre(args["executedHook"].(ExecutedHook))
+	hook := args["executedHook"].(ExecutedHook)
+	if err := s.dialClientAPI(args, &hook.ExecutedCommand); err != nil {
+		return err
+	}
+	return s.Impl.ExecuteHookPre(hook)
 }
 
 func (s *InterfaceRPCServer) ExecuteHookPost(args map[string]interface{}, _ *interface{}) error {
-	return s.Impl.ExecuteHookPost(args["executedHook"].(ExecutedHook))
+	hook := args["executedHook"].(ExecutedHook)
+	if err := s.dialClientAPI(args, &hook.ExecutedCommand); err != nil {
+		return err
+	}
+	return s.Impl.ExecuteHookPost(hook)
 }
 
 func (s *InterfaceRPCServer) ExecuteHookCleanUp(args map[string]interface{}, _ *interface{}) error {
-	return s.Impl.ExecuteHookCleanUp(args["executedHook"].(ExecutedHook))
+	hook := args["executedHook"].(ExecutedHook)
+	if err := s.dialClientAPI(args, &hook.ExecutedCommand); err != nil {
+		return err
+	}
+	return s.Impl.ExecuteHookCleanUp(hook)
+}
+
+// dialClientAPI connects to the client API served by the host, when
+// available, and sets it to the executed command. The host serves the
+// client API once, so the connection is kept open for the next calls.
+func (s *InterfaceRPCServer) dialClientAPI(args map[string]interface{}, c *ExecutedCommand) error {
+	id, ok := args["clientAPIID"].(uint32)
+	if !ok || s.broker == nil {
+		return nil
+	}
+	s.apiOnce.Do(func() {
+		conn, err := s.broker.Dial(id)
+		if err != nil {
+			s.apiErr = fmt.Errorf("dial client API: %w", err)
+			return
+		}
+		s.api = &ClientAPIRPC{client: rpc.NewClient(conn)}
+	})
+	if s.apiErr != nil {
+		return s.apiErr
+	}
+	c.SetClientAPI(s.api)
+	return nil
 }
 
 // This is the implementation of plugin.Interface so we can serve/consume this
@@ -453,17 +541,17 @@ func (s *InterfaceRPCServer) ExecuteHookCleanUp(args map[string]interface{}, _ *
 // Client must return an implementation of our interface that communicates
 // over an RPC client. We return InterfaceRPC for this.
 //
-// Ignore MuxBroker. That is used to create more multiplexed streams on our
-// plugin connection and is a more advanced use case.
+// The MuxBroker is used to create a multiplexed stream on the plugin
+// connection, through which the plugin can call the host's ClientAPI.
 type InterfacePlugin struct {
 	// Impl Injection
 	Impl Interface
 }
 
-func (p *InterfacePlugin) Server(*plugin.MuxBroker) (interface{}, error) {
-	return &InterfaceRPCServer{Impl: p.Impl}, nil
+func (p *InterfacePlugin) Server(b *plugin.MuxBroker) (interface{}, error) {
+	return &InterfaceRPCServer{Impl: p.Impl, broker: b}, nil
 }
 
-func (InterfacePlugin) Client(_ *plugin.MuxBroker, c *rpc.Client) (interface{}, error) {
-	return &InterfaceRPC{client: c}, nil
+func (InterfacePlugin) Client(b *plugin.MuxBroker, c *rpc.Client) (interface{}, error) {
+	return &InterfaceRPC{client: c, broker: b}, nil
 }
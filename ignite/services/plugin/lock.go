@@ -0,0 +1,137 @@
+package plugin
+
+import (
+	"context"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+
+	pluginsconfig "github.com/ignite/cli/ignite/config/plugins"
+	"github.com/ignite/cli/ignite/pkg/xgit"
+)
+
+// ErrLockMismatch is returned when a plugin doesn't match the version
+// recorded in the plugins lock.
+var ErrLockMismatch = errors.New("plugin doesn't match the locked version")
+
+// WithLock verifies that the remote plugins match the versions recorded in
+// the plugins lock before they are loaded.
+func WithLock(l *pluginsconfig.Lock) Option {
+	return func(p *Plugin) {
+		p.lock = l
+	}
+}
+
+// UpdateLock removes the cache directory of plugins, fetches and builds them
+// again and records the resolved commit and the checksum of the plugin source
+// in the plugins lock.
+// Local plugins are not locked.
+func UpdateLock(ctx context.Context, plugins ...*Plugin) error {
+	for _, p := range plugins {
+		if p.IsLocalPath() {
+			continue
+		}
+		if p.lock == nil {
+			return errors.Errorf("plugin %q has no lock", p.Path)
+		}
+		// The plugin is updated to a new version so the previous lock
+		// doesn't apply anymore.
+		if errors.Is(p.Error, ErrLockMismatch) {
+			p.Error = nil
+		}
+		if err := p.clean(); err != nil {
+			return err
+		}
+		p.fetch()
+		p.build(ctx)
+		if p.Error != nil {
+			return p.Error
+		}
+		commit, err := xgit.HeadHash(p.cloneDir)
+		if err != nil {
+			return err
+		}
+		sum, err := p.sourceChecksum()
+		if err != nil {
+			return err
+		}
+		p.lock.Set(pluginsconfig.LockedPlugin{
+			Path:     p.Path,
+			Commit:   commit,
+			Checksum: sum,
+		})
+		if err := p.lock.Save(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// locked returns the version of the plugin recorded in the plugins lock.
+func (p *Plugin) locked() (pluginsconfig.LockedPlugin, bool) {
+	if p.lock == nil || p.IsLocalPath() {
+		return pluginsconfig.LockedPlugin{}, false
+	}
+	return p.lock.Get(p.Path)
+}
+
+// verifyCommit ensures the fetched plugin source matches the locked commit.
+func (p *Plugin) verifyCommit() {
+	if p.Error != nil {
+		return
+	}
+	locked, ok := p.locked()
+	if !ok {
+		return
+	}
+	commit, err := xgit.HeadHash(p.cloneDir)
+	if err != nil {
+		p.Error = err
+		return
+	}
+	if commit != locked.Commit {
+		p.Error = errors.Wrapf(
+			ErrLockMismatch,
+			"%q resolved to commit %s but it is locked to %s in %s",
+			p.Path, commit, locked.Commit, p.lock.Path(),
+		)
+	}
+}
+
+// sourceChecksum returns the checksum of the committed plugin source files.
+// The binary is not used because its checksum depends on the platform and
+// the Go version used to build it.
+func (p *Plugin) sourceChecksum() (string, error) {
+	dir, err := filepath.Rel(p.cloneDir, p.srcPath)
+	if err != nil {
+		return "", err
+	}
+	sum, err := xgit.SourceChecksum(p.cloneDir, dir)
+	if err != nil {
+		return "", errors.Wrapf(err, "checksum %q", p.srcPath)
+	}
+	return sum, nil
+}
+
+// verifyChecksum ensures the fetched plugin source matches the locked checksum.
+func (p *Plugin) verifyChecksum() {
+	if p.Error != nil {
+		return
+	}
+	locked, ok := p.locked()
+	if !ok {
+		return
+	}
+	sum, err := p.sourceChecksum()
+	if err != nil {
+		p.Error = err
+		return
+	}
+	if sum != locked.Checksum {
+		p.Error = errors.Wrapf(
+			ErrLockMismatch,
+			"%q source checksum is %s but it is locked to %s in %s",
+			p.Path, sum, locked.Checksum, p.lock.Path(),
+		)
+	}
+}
@@ -0,0 +1,151 @@
+package plugin
+
+import (
+	"context"
+	"os"
+	"path"
+	"testing"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	pluginsconfig "github.com/ignite/cli/ignite/config/plugins"
+	"github.com/ignite/cli/ignite/pkg/xgit"
+)
+
+// makeLockGitRepo creates a local git repository with a minimal Go program in
+// the name directory, which builds without downloading dependencies.
+// Returns the repo directory.
+func makeLockGitRepo(t *testing.T, name string) string {
+	require := require.New(t)
+	repoDir := t.TempDir()
+	srcDir := path.Join(repoDir, name)
+	require.NoError(os.Mkdir(srcDir, 0o755))
+	err := os.WriteFile(path.Join(srcDir, "go.mod"), []byte("module example.com/"+name+"\n\ngo 1.19\n"), 0o644)
+	require.NoError(err)
+	err = os.WriteFile(path.Join(srcDir, "main.go"), []byte("package main\n\nfunc main() {}\n"), 0o644)
+	require.NoError(err)
+	repo, err := git.PlainInit(repoDir, false)
+	require.NoError(err)
+	w, err := repo.Worktree()
+	require.NoError(err)
+	_, err = w.Add(".")
+	require.NoError(err)
+	_, err = w.Commit("msg", &git.CommitOptions{
+		Author: &object.Signature{
+			Name:  "bob",
+			Email: "bob@example.com",
+			When:  time.Now(),
+		},
+	})
+	require.NoError(err)
+	return repoDir
+}
+
+func newLockedPlugin(t *testing.T, name string, locked ...pluginsconfig.LockedPlugin) *Plugin {
+	repoDir := makeLockGitRepo(t, name)
+	cloneDir := t.TempDir()
+	lock, err := pluginsconfig.ParseLockDir(t.TempDir())
+	require.NoError(t, err)
+	for _, lp := range locked {
+		lock.Set(lp)
+	}
+	return &Plugin{
+		Plugin:     pluginsconfig.Plugin{Path: "github.com/ignite/" + name},
+		cloneURL:   repoDir,
+		cloneDir:   cloneDir,
+		srcPath:    path.Join(cloneDir, name),
+		binaryName: name,
+		lock:       lock,
+	}
+}
+
+func TestPluginLoadLock(t *testing.T) {
+	tests := []struct {
+		name          string
+		locked        pluginsconfig.LockedPlugin
+		expectedError string
+	}{
+		{
+			name: "fail: commit doesn't match the lock",
+			locked: pluginsconfig.LockedPlugin{
+				Path:   "github.com/ignite/foo",
+				Commit: "4d6e5bc1c5a7c6f0de4b1a1e48b1e0c3d0e3a5b2",
+			},
+			expectedError: `"github.com/ignite/foo" resolved to commit .* but it is locked to 4d6e5bc1c5a7c6f0de4b1a1e48b1e0c3d0e3a5b2`,
+		},
+		{
+			name: "fail: source checksum doesn't match the lock",
+			locked: pluginsconfig.LockedPlugin{
+				Path:     "github.com/ignite/foo",
+				Checksum: "9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08",
+			},
+			expectedError: `"github.com/ignite/foo" source checksum is .* but it is locked to 9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := newLockedPlugin(t, "foo")
+			if tt.locked.Commit == "" {
+				// Lock the right commit to verify the checksum
+				p.fetch()
+				require.NoError(t, p.Error)
+				tt.locked.Commit, p.Error = xgit.HeadHash(p.cloneDir)
+				require.NoError(t, p.Error)
+			}
+			p.lock.Set(tt.locked)
+			defer p.KillClient()
+
+			p.load(context.Background())
+
+			require.ErrorIs(t, p.Error, ErrLockMismatch)
+			require.Regexp(t, tt.expectedError, p.Error.Error())
+			require.Nil(t, p.Interface)
+		})
+	}
+}
+
+func TestUpdateLock(t *testing.T) {
+	// Arrange
+	p := newLockedPlugin(t, "foo")
+	p.Error = errors.Wrap(ErrLockMismatch, "outdated")
+
+	// Act
+	err := UpdateLock(context.Background(), p)
+
+	// Assert
+	require.NoError(t, err)
+	require.NoError(t, p.Error)
+	commit, err := xgit.HeadHash(p.cloneDir)
+	require.NoError(t, err)
+	sum, err := xgit.SourceChecksum(p.cloneDir, "foo")
+	require.NoError(t, err)
+	lock, err := pluginsconfig.ParseLockDir(path.Dir(p.lock.Path()))
+	require.NoError(t, err)
+	require.Equal(t, []pluginsconfig.LockedPlugin{{
+		Path:     "github.com/ignite/foo",
+		Commit:   commit,
+		Checksum: sum,
+	}}, lock.Plugins)
+	// The built binary doesn't change the checksum of the plugin source
+	require.FileExists(t, p.binaryPath())
+	p.verifyChecksum()
+	require.NoError(t, p.Error)
+}
+
+func TestUpdateLockMismatch(t *testing.T) {
+	// Arrange
+	p := newLockedPlugin(t, "foo", pluginsconfig.LockedPlugin{
+		Path:   "github.com/ignite/foo",
+		Commit: "4d6e5bc1c5a7c6f0de4b1a1e48b1e0c3d0e3a5b2",
+	})
+
+	// Act
+	err := Update(p)
+
+	// Assert
+	require.ErrorIs(t, err, ErrLockMismatch)
+}
@@ -58,6 +58,9 @@ type Plugin struct {
 	// plugin instance is controlling the rpc server.
 	isHost bool
 
+	// lock holds the versions of the plugins that must be loaded.
+	lock *pluginsconfig.Lock
+
 	ev events.Bus
 }
 
@@ -99,6 +102,7 @@ func Load(ctx context.Context, plugins []pluginsconfig.Plugin, options ...Option
 }
 
 // Update removes the cache directory of plugins and fetch them again.
+// Plugins that no longer match the plugins lock return an error.
 func Update(plugins ...*Plugin) error {
 	for _, p := range plugins {
 		err := p.clean()
@@ -106,6 +110,10 @@ func Update(plugins ...*Plugin) error {
 			return err
 		}
 		p.fetch()
+		p.verifyCommit()
+		if errors.Is(p.Error, ErrLockMismatch) {
+			return p.Error
+		}
 	}
 	return nil
 }
@@ -210,6 +218,12 @@ func (p *Plugin) load(ctx context.Context) {
 			return
 		}
 	}
+	// Refuse to load a plugin when its source doesn't match the lock
+	p.verifyCommit()
+	p.verifyChecksum()
+	if p.Error != nil {
+		return
+	}
 
 	if p.IsLocalPath() {
 		// trigger rebuild for local plugin if binary is outdated
@@ -341,7 +355,10 @@ func (p *Plugin) build(ctx context.Context) {
 		p.Error = errors.Wrapf(err, "go mod tidy")
 		return
 	}
-	if err := gocmd.Build(ctx, p.binaryName, p.srcPath, nil); err != nil {
+	// Trim the file system paths from the binary so its checksum doesn't
+	// depend on the location of the plugins cache directory.
+	flags := []string{gocmd.FlagTrimPath}
+	if err := gocmd.Build(ctx, p.binaryName, p.srcPath, flags); err != nil {
 		p.Error = errors.Wrapf(err, "go build")
 		return
 	}
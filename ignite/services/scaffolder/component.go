@@ -112,17 +112,25 @@ func checkComponentCreated(appPath, moduleName string, compName multiformatname.
 func checkCustomTypes(ctx context.Context, path, appName, module string, fields []string) error {
 	protoPath := filepath.Join(path, protoFolder, appName, module)
 	customFieldTypes := make([]string, 0)
+	enumFieldTypes := make([]string, 0)
 	for _, field := range fields {
 		ft, ok := fieldType(field)
 		if !ok {
 			continue
 		}
 
+		if enumName, ok := datatype.IsEnumType(datatype.Name(ft)); ok {
+			enumFieldTypes = append(enumFieldTypes, enumName)
+			continue
+		}
 		if _, ok := datatype.IsSupportedType(datatype.Name(ft)); !ok {
 			customFieldTypes = append(customFieldTypes, ft)
 		}
 	}
-	return protoanalysis.HasMessages(ctx, protoPath, customFieldTypes...)
+	if err := protoanalysis.HasMessages(ctx, protoPath, customFieldTypes...); err != nil {
+		return err
+	}
+	return protoanalysis.HasEnums(ctx, protoPath, enumFieldTypes...)
 }
 
 // checkForbiddenComponentName returns true if the name is forbidden as a component name.
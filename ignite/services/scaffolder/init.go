@@ -12,6 +12,7 @@ import (
 	"github.com/ignite/cli/ignite/pkg/gocmd"
 	"github.com/ignite/cli/ignite/pkg/gomodulepath"
 	"github.com/ignite/cli/ignite/pkg/placeholder"
+	"github.com/ignite/cli/ignite/pkg/xgenny"
 	"github.com/ignite/cli/ignite/pkg/xgit"
 	"github.com/ignite/cli/ignite/templates/app"
 	modulecreate "github.com/ignite/cli/ignite/templates/module/create"
@@ -26,23 +27,11 @@ func Init(
 	root, name, addressPrefix string,
 	noDefaultModule, skipGit bool,
 ) (path string, err error) {
-	pathInfo, err := gomodulepath.Parse(name)
+	pathInfo, path, err := appPath(root, name)
 	if err != nil {
 		return "", err
 	}
 
-	// Create a new folder named as the blockchain when a custom path is not specified
-	var appFolder string
-	if root == "" {
-		appFolder = pathInfo.Root
-	}
-
-	if root, err = filepath.Abs(root); err != nil {
-		return "", err
-	}
-
-	path = filepath.Join(root, appFolder)
-
 	// create the project
 	if err := generate(ctx, tracer, pathInfo, addressPrefix, path, noDefaultModule); err != nil {
 		return "", err
@@ -62,6 +51,48 @@ func Init(
 	return path, nil
 }
 
+// InitDryRun runs the app generators in memory without writing anything to
+// disk. The returned source modification contains the diff of the files that
+// Init would create.
+func InitDryRun(
+	ctx context.Context,
+	tracer *placeholder.Tracer,
+	root, name, addressPrefix string,
+	noDefaultModule bool,
+) (xgenny.SourceModification, error) {
+	pathInfo, path, err := appPath(root, name)
+	if err != nil {
+		return xgenny.SourceModification{}, err
+	}
+
+	gens, err := generators(tracer, pathInfo, addressPrefix, path, noDefaultModule)
+	if err != nil {
+		return xgenny.SourceModification{}, err
+	}
+	return xgenny.DryRun(ctx, path, tracer, gens...)
+}
+
+// appPath returns the parsed module path of the app and the absolute path of
+// the directory where the app is initialized.
+func appPath(root, name string) (gomodulepath.Path, string, error) {
+	pathInfo, err := gomodulepath.Parse(name)
+	if err != nil {
+		return gomodulepath.Path{}, "", err
+	}
+
+	// Create a new folder named as the blockchain when a custom path is not specified
+	var appFolder string
+	if root == "" {
+		appFolder = pathInfo.Root
+	}
+
+	if root, err = filepath.Abs(root); err != nil {
+		return gomodulepath.Path{}, "", err
+	}
+
+	return pathInfo, filepath.Join(root, appFolder), nil
+}
+
 //nolint:interfacer
 func generate(
 	ctx context.Context,
@@ -71,6 +102,33 @@ func generate(
 	absRoot string,
 	noDefaultModule bool,
 ) error {
+	gens, err := generators(tracer, pathInfo, addressPrefix, absRoot, noDefaultModule)
+	if err != nil {
+		return err
+	}
+
+	for _, g := range gens {
+		runner := genny.WetRunner(ctx)
+		runner.With(g)
+		runner.Root = absRoot
+		if err := runner.Run(); err != nil {
+			return err
+		}
+	}
+	return gocmd.ModTidy(ctx, absRoot)
+}
+
+// generators returns the generators that scaffold the app, in the order they
+// must run.
+//
+//nolint:interfacer
+func generators(
+	tracer *placeholder.Tracer,
+	pathInfo gomodulepath.Path,
+	addressPrefix,
+	absRoot string,
+	noDefaultModule bool,
+) ([]*genny.Generator, error) {
 	githubPath := gomodulepath.ExtractAppPath(pathInfo.RawPath)
 	if !strings.Contains(githubPath, "/") {
 		// A username must be added when the app module path has a single element
@@ -87,21 +145,13 @@ func generate(
 		AddressPrefix:    addressPrefix,
 	})
 	if err != nil {
-		return err
+		return nil, err
 	}
 	// Create the 'testutil' package with the test helpers
 	if err := testutil.Register(g, absRoot); err != nil {
-		return err
-	}
-
-	run := func(runner *genny.Runner, gen *genny.Generator) error {
-		runner.With(gen)
-		runner.Root = absRoot
-		return runner.Run()
-	}
-	if err := run(genny.WetRunner(ctx), g); err != nil {
-		return err
+		return nil, err
 	}
+	gens := []*genny.Generator{g}
 
 	// generate module template
 	if !noDefaultModule {
@@ -114,16 +164,9 @@ func generate(
 		}
 		g, err = modulecreate.NewGenerator(opts)
 		if err != nil {
-			return err
-		}
-		if err := run(genny.WetRunner(ctx), g); err != nil {
-			return err
+			return nil, err
 		}
-		g = modulecreate.NewAppModify(tracer, opts)
-		if err := run(genny.WetRunner(ctx), g); err != nil {
-			return err
-		}
-
+		gens = append(gens, g, modulecreate.NewAppModify(tracer, opts))
 	}
-	return gocmd.ModTidy(ctx, absRoot)
+	return gens, nil
 }
@@ -163,11 +163,11 @@ func (s Scaffolder) AddMessage(
 		return sm, err
 	}
 	gens = append(gens, g)
-	sm, err = xgenny.RunWithValidation(tracer, gens...)
+	sm, err = s.run(ctx, tracer, gens...)
 	if err != nil {
 		return sm, err
 	}
-	return sm, finish(ctx, cacheStorage, opts.AppPath, s.modpath.RawPath)
+	return sm, s.finish(ctx, cacheStorage)
 }
 
 // checkForbiddenMessageField returns true if the name is forbidden as a message name.
@@ -234,20 +234,20 @@ func (s Scaffolder) CreateModule(
 		}
 		gens = append(gens, g)
 	}
-	sm, err = xgenny.RunWithValidation(tracer, gens...)
+	sm, err = s.run(ctx, tracer, gens...)
 	if err != nil {
 		return sm, err
 	}
 
 	// Modify app.go to register the module
-	newSourceModification, runErr := xgenny.RunWithValidation(tracer, modulecreate.NewAppModify(tracer, opts))
+	newSourceModification, runErr := s.run(ctx, tracer, modulecreate.NewAppModify(tracer, opts))
 	sm.Merge(newSourceModification)
 	var validationErr validation.Error
 	if runErr != nil && !errors.As(runErr, &validationErr) {
 		return sm, runErr
 	}
 
-	return sm, finish(ctx, cacheStorage, opts.AppPath, s.modpath.RawPath)
+	return sm, s.finish(ctx, cacheStorage)
 }
 
 // ImportModule imports specified module with name to the scaffolded app.
@@ -281,7 +281,7 @@ func (s Scaffolder) ImportModule(
 		return sm, err
 	}
 
-	sm, err = xgenny.RunWithValidation(tracer, g)
+	sm, err = s.run(ctx, tracer, g)
 	if err != nil {
 		var validationErr validation.Error
 		if errors.As(err, &validationErr) {
@@ -291,13 +291,17 @@ func (s Scaffolder) ImportModule(
 		return sm, err
 	}
 
+	if s.dryRunner != nil {
+		return sm, nil
+	}
+
 	// import a specific version of ComsWasm
 	// NOTE(dshulyak) it must be installed after validation
 	if err := s.installWasm(); err != nil {
 		return sm, err
 	}
 
-	return sm, finish(ctx, cacheStorage, s.path, s.modpath.RawPath)
+	return sm, s.finish(ctx, cacheStorage)
 }
 
 // moduleExists checks if the module exists in the app.
@@ -111,11 +111,11 @@ func (s *Scaffolder) AddOracle(
 	if err != nil {
 		return sm, err
 	}
-	sm, err = xgenny.RunWithValidation(tracer, g)
+	sm, err = s.run(ctx, tracer, g)
 	if err != nil {
 		return sm, err
 	}
-	return sm, finish(ctx, cacheStorage, opts.AppPath, s.modpath.RawPath)
+	return sm, s.finish(ctx, cacheStorage)
 }
 
 // Deprecated: This function is no longer maintained.
@@ -139,11 +139,11 @@ func (s Scaffolder) AddPacket(
 	if err != nil {
 		return sm, err
 	}
-	sm, err = xgenny.RunWithValidation(tracer, g)
+	sm, err = s.run(ctx, tracer, g)
 	if err != nil {
 		return sm, err
 	}
-	return sm, finish(ctx, cacheStorage, opts.AppPath, s.modpath.RawPath)
+	return sm, s.finish(ctx, cacheStorage)
 }
 
 // isIBCModule returns true if the provided module implements the IBC module interface
@@ -83,9 +83,9 @@ func (s Scaffolder) AddQuery(
 	if err != nil {
 		return sm, err
 	}
-	sm, err = xgenny.RunWithValidation(tracer, g)
+	sm, err = s.run(ctx, tracer, g)
 	if err != nil {
 		return sm, err
 	}
-	return sm, finish(ctx, cacheStorage, opts.AppPath, s.modpath.RawPath)
+	return sm, s.finish(ctx, cacheStorage)
 }
@@ -7,6 +7,8 @@ import (
 	"fmt"
 	"path/filepath"
 
+	"github.com/gobuffalo/genny/v2"
+
 	chainconfig "github.com/ignite/cli/ignite/config/chain"
 	"github.com/ignite/cli/ignite/pkg/cache"
 	"github.com/ignite/cli/ignite/pkg/cosmosanalysis"
@@ -14,6 +16,8 @@ import (
 	"github.com/ignite/cli/ignite/pkg/cosmosver"
 	"github.com/ignite/cli/ignite/pkg/gocmd"
 	"github.com/ignite/cli/ignite/pkg/gomodulepath"
+	"github.com/ignite/cli/ignite/pkg/placeholder"
+	"github.com/ignite/cli/ignite/pkg/xgenny"
 )
 
 // Scaffolder is Ignite CLI app scaffolder.
@@ -26,14 +30,33 @@ type Scaffolder struct {
 
 	// modpath represents the go module path of the app.
 	modpath gomodulepath.Path
+
+	// dryRunner runs the generators in memory without modifying the app.
+	// It is shared by all the runs so each one sees the files of the previous ones.
+	dryRunner *xgenny.MemoryRunner
+}
+
+// Option configures the scaffolder.
+type Option func(*Scaffolder)
+
+// WithDryRun runs the scaffolding in memory without modifying the app source
+// code. The returned source modifications contain the diff of the changes that
+// the scaffolding would apply.
+func WithDryRun() Option {
+	return func(s *Scaffolder) {
+		s.dryRunner = xgenny.NewMemoryRunner(s.path)
+	}
 }
 
 // New creates a new scaffold app.
-func New(appPath string) (Scaffolder, error) {
+func New(appPath string, options ...Option) (Scaffolder, error) {
 	sc, err := newScaffolder(appPath)
 	if err != nil {
 		return sc, err
 	}
+	for _, apply := range options {
+		apply(&sc)
+	}
 
 	if sc.Version.LT(cosmosver.StargateFortyFourVersion) {
 		return sc, fmt.Errorf(
@@ -75,6 +98,27 @@ func newScaffolder(path string) (Scaffolder, error) {
 	return s, nil
 }
 
+// run executes the generators, in memory when the scaffolder is in dry run mode.
+func (s Scaffolder) run(
+	ctx context.Context,
+	tracer *placeholder.Tracer,
+	gens ...*genny.Generator,
+) (xgenny.SourceModification, error) {
+	if s.dryRunner != nil {
+		return s.dryRunner.Run(ctx, tracer, gens...)
+	}
+	return xgenny.RunWithValidation(tracer, gens...)
+}
+
+// finish generates the code from the proto files and formats the app source
+// code. Nothing is done in dry run mode because the app is not modified.
+func (s Scaffolder) finish(ctx context.Context, cacheStorage cache.Storage) error {
+	if s.dryRunner != nil {
+		return nil
+	}
+	return finish(ctx, cacheStorage, s.path, s.modpath.RawPath)
+}
+
 func finish(ctx context.Context, cacheStorage cache.Storage, path, gomodPath string) error {
 	if err := protoc(ctx, cacheStorage, path, gomodPath); err != nil {
 		return err
@@ -236,12 +236,12 @@ func (s Scaffolder) AddType(
 
 	// run the generation
 	gens = append(gens, g)
-	sm, err = xgenny.RunWithValidation(tracer, gens...)
+	sm, err = s.run(ctx, tracer, gens...)
 	if err != nil {
 		return sm, err
 	}
 
-	return sm, finish(ctx, cacheStorage, opts.AppPath, s.modpath.RawPath)
+	return sm, s.finish(ctx, cacheStorage)
 }
 
 // checkForbiddenTypeIndex returns true if the name is forbidden as a index name.
@@ -0,0 +1,34 @@
+package datatype
+
+import (
+	"fmt"
+
+	"github.com/emicklei/proto"
+
+	"github.com/ignite/cli/ignite/pkg/multiformatname"
+	"github.com/ignite/cli/ignite/pkg/protoanalysis/protoutil"
+)
+
+// DataAddress is an account address data type definition.
+var DataAddress = DataType{
+	DataType:         func(string) string { return "string" },
+	DefaultTestValue: "cosmos1qqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqnrql8a",
+	ValueSample:      "sample.AccAddress()",
+	ValueSimulation:  "simAccount.Address.String()",
+	ProtoType: func(_, name string, index int) string {
+		return fmt.Sprintf("string %s = %d", name, index)
+	},
+	GenesisArgs: func(multiformatname.Name, int) string { return "" },
+	CLIArgs: func(name multiformatname.Name, _, prefix string, argIndex int) string {
+		return fmt.Sprintf("%s%s := args[%d]", prefix, name.UpperCamel, argIndex)
+	},
+	ValidateBasic: func(name multiformatname.Name, _, prefix string) string {
+		return fmt.Sprintf(`if _, err := sdk.AccAddressFromBech32(%[1]v%[2]v); err != nil {
+		return sdkerrors.Wrapf(sdkerrors.ErrInvalidAddress, "invalid %[3]v address (%%s)", err)
+	}`, prefix, name.UpperCamel, name.LowerCamel)
+	},
+	ToProtoField: func(_, name string, index int) *proto.NormalField {
+		return protoutil.NewField(name, "string", index)
+	},
+	NonIndex: true,
+}
@@ -0,0 +1,53 @@
+package datatype
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/emicklei/proto"
+
+	"github.com/ignite/cli/ignite/pkg/multiformatname"
+	"github.com/ignite/cli/ignite/pkg/protoanalysis/protoutil"
+)
+
+// DataEnum is a proto enum data type definition.
+// The enum must be defined in the module proto files.
+var DataEnum = DataType{
+	DataType:         func(datatype string) string { return datatype },
+	DefaultTestValue: "0",
+	ProtoType: func(datatype, name string, index int) string {
+		return fmt.Sprintf("%s %s = %d", datatype, name, index)
+	},
+	GenesisArgs: func(multiformatname.Name, int) string { return "" },
+	CLIArgs: func(name multiformatname.Name, datatype, prefix string, argIndex int) string {
+		return fmt.Sprintf(`%[1]v%[2]vValue, ok := types.%[3]v_value[args[%[4]v]]
+					if !ok {
+						value, err := cast.ToInt32E(args[%[4]v])
+						if err != nil {
+							return err
+						}
+						%[1]v%[2]vValue = value
+					}
+					%[1]v%[2]v := types.%[3]v(%[1]v%[2]vValue)`, prefix, name.UpperCamel, datatype, argIndex)
+	},
+	ValidateBasic: func(name multiformatname.Name, datatype, prefix string) string {
+		return fmt.Sprintf(`if _, ok := %[3]v_name[int32(%[1]v%[2]v)]; !ok {
+		return sdkerrors.Wrapf(sdkerrors.ErrInvalidRequest, "invalid %[4]v (%%d)", %[1]v%[2]v)
+	}`, prefix, name.UpperCamel, datatype, name.LowerCamel)
+	},
+	GoCLIImports: []GoImport{{Name: "github.com/spf13/cast"}},
+	NonIndex:     true,
+	ToProtoField: func(datatype, name string, index int) *proto.NormalField {
+		return protoutil.NewField(name, datatype, index)
+	},
+}
+
+// IsEnumType checks if the given typename refers to a proto enum, using the
+// format enum.EnumName. Returns the enum name when it does.
+func IsEnumType(typename Name) (string, bool) {
+	enumName := strings.TrimPrefix(string(typename), string(Enum)+".")
+	if enumName == string(typename) || enumName == "" {
+		return "", false
+	}
+	return enumName, true
+}
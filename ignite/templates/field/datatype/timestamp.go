@@ -0,0 +1,40 @@
+package datatype
+
+import (
+	"fmt"
+
+	"github.com/emicklei/proto"
+
+	"github.com/ignite/cli/ignite/pkg/multiformatname"
+	"github.com/ignite/cli/ignite/pkg/protoanalysis/protoutil"
+)
+
+// DataTimestamp is a timestamp data type definition.
+var DataTimestamp = DataType{
+	DataType:         func(string) string { return "time.Time" },
+	DefaultTestValue: "2023-01-01T00:00:00Z",
+	ProtoType: func(_, name string, index int) string {
+		return fmt.Sprintf(
+			"google.protobuf.Timestamp %s = %d [(gogoproto.stdtime) = true, (gogoproto.nullable) = false]",
+			name, index,
+		)
+	},
+	GenesisArgs: func(multiformatname.Name, int) string { return "" },
+	CLIArgs: func(name multiformatname.Name, _, prefix string, argIndex int) string {
+		return fmt.Sprintf(`%s%s, err := time.Parse(time.RFC3339, args[%d])
+					if err != nil {
+						return err
+					}`, prefix, name.UpperCamel, argIndex)
+	},
+	GoImports:    []GoImport{{Name: "time"}},
+	GoCLIImports: []GoImport{{Name: "time"}},
+	ProtoImports: []string{"gogoproto/gogo.proto", "google/protobuf/timestamp.proto"},
+	NonIndex:     true,
+	ToProtoField: func(_, name string, index int) *proto.NormalField {
+		stdTime := protoutil.NewOption("gogoproto.stdtime", "true", protoutil.Custom())
+		nullable := protoutil.NewOption("gogoproto.nullable", "false", protoutil.Custom())
+		return protoutil.NewField(
+			name, "google.protobuf.Timestamp", index, protoutil.WithFieldOptions(stdTime, nullable),
+		)
+	},
+}
@@ -28,6 +28,17 @@ const (
 	Coin Name = "coin"
 	// Coins represents the coin array type name.
 	Coins Name = "array.coin"
+	// Address represents the account address type name.
+	Address Name = "address"
+	// Decimal represents the decimal type name.
+	Decimal Name = "decimal"
+	// Bytes represents the bytes type name.
+	Bytes Name = "bytes"
+	// Timestamp represents the timestamp type name.
+	Timestamp Name = "timestamp"
+	// Enum represents the proto enum type name.
+	// Fields use it with the name of the enum, e.g. enum.Status.
+	Enum Name = "enum"
 	// Custom represents the custom type name.
 	Custom Name = Name(TypeCustom)
 
@@ -59,6 +70,11 @@ var supportedTypes = map[Name]DataType{
 	Coin:             DataCoin,
 	Coins:            DataCoinSlice,
 	CoinSliceAlias:   DataCoinSlice,
+	Address:          DataAddress,
+	Decimal:          DataDecimal,
+	Bytes:            DataBytes,
+	Timestamp:        DataTimestamp,
+	Enum:             DataEnum,
 	Custom:           DataCustom,
 }
 
@@ -71,15 +87,19 @@ type DataType struct {
 	ProtoType         func(datatype, name string, index int) string
 	GenesisArgs       func(name multiformatname.Name, value int) string
 	ProtoImports      []string
+	GoImports         []GoImport
 	GoCLIImports      []GoImport
 	DefaultTestValue  string
 	ValueLoop         string
 	ValueIndex        string
 	ValueInvalidIndex string
+	ValueSample       string
+	ValueSimulation   string
 	ToBytes           func(name string) string
 	ToString          func(name string) string
 	ToProtoField      func(datatype, name string, index int) *proto.NormalField
 	CLIArgs           func(name multiformatname.Name, datatype, prefix string, argIndex int) string
+	ValidateBasic     func(name multiformatname.Name, datatype, prefix string) string
 	NonIndex          bool
 }
 
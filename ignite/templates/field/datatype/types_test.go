@@ -59,6 +59,31 @@ func TestIsSupportedType(t *testing.T) {
 			typename: datatype.Coins,
 			ok:       true,
 		},
+		{
+			name:     "address",
+			typename: datatype.Address,
+			ok:       true,
+		},
+		{
+			name:     "decimal",
+			typename: datatype.Decimal,
+			ok:       true,
+		},
+		{
+			name:     "bytes",
+			typename: datatype.Bytes,
+			ok:       true,
+		},
+		{
+			name:     "timestamp",
+			typename: datatype.Timestamp,
+			ok:       true,
+		},
+		{
+			name:     "enum",
+			typename: datatype.Enum,
+			ok:       true,
+		},
 		{
 			name:     "custom",
 			typename: datatype.Custom,
@@ -98,3 +123,42 @@ func TestIsSupportedType(t *testing.T) {
 		})
 	}
 }
+
+func TestIsEnumType(t *testing.T) {
+	tests := []struct {
+		name     string
+		typename datatype.Name
+		enumName string
+		ok       bool
+	}{
+		{
+			name:     "enum",
+			typename: datatype.Name("enum.Status"),
+			enumName: "Status",
+			ok:       true,
+		},
+		{
+			name:     "enum without name",
+			typename: datatype.Enum,
+			ok:       false,
+		},
+		{
+			name:     "enum with empty name",
+			typename: datatype.Name("enum."),
+			ok:       false,
+		},
+		{
+			name:     "not an enum",
+			typename: datatype.String,
+			ok:       false,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			enumName, ok := datatype.IsEnumType(tc.typename)
+			require.Equal(t, tc.ok, ok)
+			require.Equal(t, tc.enumName, enumName)
+		})
+	}
+}
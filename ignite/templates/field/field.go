@@ -3,6 +3,7 @@ package field
 
 import (
 	"fmt"
+	"html/template"
 
 	"github.com/emicklei/proto"
 
@@ -104,6 +105,41 @@ func (f Field) CLIArgs(prefix string, argIndex int) string {
 	return dt.CLIArgs(f.Name, f.Datatype, prefix, argIndex)
 }
 
+// ValueSample returns a valid Datatype value for unit tests.
+// Returns an empty string when the zero value of the Datatype is valid.
+func (f Field) ValueSample() string {
+	dt, ok := datatype.IsSupportedType(f.DatatypeName)
+	if !ok {
+		panic(fmt.Sprintf("unknown type %s", f.DatatypeName))
+	}
+	return dt.ValueSample
+}
+
+// ValueSimulation returns a valid Datatype value for simulations.
+// Returns an empty string when the zero value of the Datatype is valid.
+func (f Field) ValueSimulation() string {
+	dt, ok := datatype.IsSupportedType(f.DatatypeName)
+	if !ok {
+		panic(fmt.Sprintf("unknown type %s", f.DatatypeName))
+	}
+	return dt.ValueSimulation
+}
+
+// ValidateBasic returns the Datatype stateless validation of the field,
+// prefix is the expression that holds the field, e.g. "msg.".
+// Returns an empty string when the Datatype doesn't require validation.
+// The code is returned as HTML to prevent templates from escaping it.
+func (f Field) ValidateBasic(prefix string) template.HTML {
+	dt, ok := datatype.IsSupportedType(f.DatatypeName)
+	if !ok {
+		panic(fmt.Sprintf("unknown type %s", f.DatatypeName))
+	}
+	if dt.ValidateBasic == nil {
+		return ""
+	}
+	return template.HTML(dt.ValidateBasic(f.Name, f.Datatype, prefix))
+}
+
 // ToBytes returns the Datatype byte array cast.
 func (f Field) ToBytes(name string) string {
 	dt, ok := datatype.IsSupportedType(f.DatatypeName)
@@ -138,6 +174,15 @@ func (f Field) ToProtoField(index int) *proto.NormalField {
 	return dt.ToProtoField(f.Datatype, f.Name.LowerCamel, index)
 }
 
+// GoImports returns the Datatype imports required by the Go type.
+func (f Field) GoImports() []datatype.GoImport {
+	dt, ok := datatype.IsSupportedType(f.DatatypeName)
+	if !ok {
+		panic(fmt.Sprintf("unknown type %s", f.DatatypeName))
+	}
+	return dt.GoImports
+}
+
 // GoCLIImports returns the Datatype imports for CLI package.
 func (f Field) GoCLIImports() []datatype.GoImport {
 	dt, ok := datatype.IsSupportedType(f.DatatypeName)
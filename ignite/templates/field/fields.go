@@ -10,6 +10,22 @@ import (
 // Fields represents a Field slice.
 type Fields []Field
 
+// GoImports returns all go imports required by the fields Go types.
+func (f Fields) GoImports() []datatype.GoImport {
+	allImports := make([]datatype.GoImport, 0)
+	exist := make(map[string]struct{})
+	for _, fields := range f {
+		for _, goImport := range fields.GoImports() {
+			if _, ok := exist[goImport.Name]; ok {
+				continue
+			}
+			exist[goImport.Name] = struct{}{}
+			allImports = append(allImports, goImport)
+		}
+	}
+	return allImports
+}
+
 // GoCLIImports returns all go CLI imports.
 func (f Fields) GoCLIImports() []datatype.GoImport {
 	allImports := make([]datatype.GoImport, 0)
@@ -52,10 +68,12 @@ func (f Fields) String() string {
 }
 
 // Custom return a list of custom fields.
+// Enum fields are included because, like custom types, they are defined in
+// the module proto files.
 func (f Fields) Custom() []string {
 	fields := make([]string, 0)
 	for _, field := range f {
-		if field.DatatypeName == datatype.TypeCustom {
+		if field.DatatypeName == datatype.TypeCustom || field.DatatypeName == datatype.Enum {
 			dataType, err := multiformatname.NewName(field.Datatype)
 			if err != nil {
 				panic(err)
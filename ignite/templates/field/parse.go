@@ -62,6 +62,19 @@ func ParseFields(
 		}
 		existingFields[name.LowerCamel] = struct{}{}
 
+		// Check if is an enum type
+		if datatypeName == datatype.Enum {
+			return parsedFields, fmt.Errorf("the field %s must specify the enum name, e.g. %s:enum.Status", name.Original, name.Original)
+		}
+		if enumName, ok := datatype.IsEnumType(datatypeName); ok {
+			parsedFields = append(parsedFields, Field{
+				Name:         name,
+				Datatype:     enumName,
+				DatatypeName: datatype.Enum,
+			})
+			continue
+		}
+
 		// Check if is a static type
 		if _, ok := datatype.IsSupportedType(datatypeName); ok {
 			parsedFields = append(parsedFields, Field{
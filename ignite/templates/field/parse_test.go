@@ -35,6 +35,10 @@ func TestForbiddenParseFields(t *testing.T) {
 	// invalid format
 	_, err = ParseFields([]string{"foo:int:int"}, alwaysInvalid)
 	require.Error(t, err)
+
+	// enum without name
+	_, err = ParseFields([]string{"foo:enum"}, noCheck)
+	require.Error(t, err)
 }
 
 func TestParseFields1(t *testing.T) {
@@ -182,6 +186,46 @@ func TestParseFields1(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "test address, decimal, bytes and timestamp types",
+			fields: []string{
+				name1.Original + ":address",
+				name2.Original + ":decimal",
+				name3.Original + ":bytes",
+				name4.Original + ":timestamp",
+			},
+			want: Fields{
+				{
+					Name:         name1,
+					DatatypeName: datatype.Address,
+				},
+				{
+					Name:         name2,
+					DatatypeName: datatype.Decimal,
+				},
+				{
+					Name:         name3,
+					DatatypeName: datatype.Bytes,
+				},
+				{
+					Name:         name4,
+					DatatypeName: datatype.Timestamp,
+				},
+			},
+		},
+		{
+			name: "test enum types",
+			fields: []string{
+				name1.Original + ":enum.Status",
+			},
+			want: Fields{
+				{
+					Name:         name1,
+					DatatypeName: datatype.Enum,
+					Datatype:     "Status",
+				},
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
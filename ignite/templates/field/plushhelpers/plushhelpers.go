@@ -13,6 +13,7 @@ import (
 // ExtendPlushContext sets available field helpers on the provided context.
 func ExtendPlushContext(ctx *plush.Context) {
 	ctx.Set("mergeGoImports", mergeGoImports)
+	ctx.Set("mergeGoTypeImports", mergeGoTypeImports)
 	ctx.Set("mergeProtoImports", mergeProtoImports)
 	ctx.Set("mergeCustomImports", mergeCustomImports)
 	ctx.Set("title", xstrings.Title)
@@ -49,6 +50,14 @@ func mergeGoImports(fields ...field.Fields) []datatype.GoImport {
 	return allImports
 }
 
+func mergeGoTypeImports(fields ...field.Fields) []datatype.GoImport {
+	allFields := make(field.Fields, 0)
+	for _, f := range fields {
+		allFields = append(allFields, f...)
+	}
+	return allFields.GoImports()
+}
+
 func mergeProtoImports(fields ...field.Fields) []string {
 	allImports := make([]string, 0)
 	exist := make(map[string]struct{})